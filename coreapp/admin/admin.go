@@ -0,0 +1,155 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const AdminServerName = "admin"
+
+const (
+	DEFAULT_ADDRESS          = "localhost:8090"
+	DEFAULT_SHUTDOWN_TIMEOUT = 5 * time.Second
+)
+
+// AdminServerImpl serves the HTTP API for the operators to look into the engine,
+// e.g. to answer when a job will run.
+//
+//	GET    /admin/queue              the jobs in the scheduler with their estimated start and end
+//	GET    /admin/queue/{job_id}     the job in the scheduler
+//	GET    /admin/reservations       the reservations of the devices
+//	POST   /admin/reservations       reserves a device, or every device without device_id, for a window
+//	DELETE /admin/reservations/{id}  deletes the reservation
+type AdminServerImpl struct {
+	Address string `toml:"address"`
+
+	server *http.Server
+}
+
+type QueueResponse struct {
+	QueueSize int                 `json:"queue_size"`
+	FreeSlots int                 `json:"free_slots"`
+	Jobs      []core.ScheduledJob `json:"jobs"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func (a *AdminServerImpl) GetEmptyParams() interface{} {
+	return a
+}
+
+func (a *AdminServerImpl) SetParams(p interface{}) error {
+	if p == nil {
+		zap.L().Debug("no params for admin server")
+		return nil
+	}
+	mp, ok := p.(map[string]interface{})
+	if !ok {
+		msg := fmt.Errorf("failed to set params for admin server/params: %s", p)
+		zap.L().Error(msg.Error())
+		return msg
+	}
+	if address, ok := mp["address"].(string); ok {
+		a.Address = address
+	}
+	return nil
+}
+
+func (a *AdminServerImpl) Setup() error {
+	if a.Address == "" {
+		a.Address = DEFAULT_ADDRESS
+	}
+	a.server = &http.Server{
+		Addr:    a.Address,
+		Handler: NewHandler(core.GetSystemComponents()),
+	}
+	return nil
+}
+
+func (a *AdminServerImpl) Serve() error {
+	zap.L().Info(fmt.Sprintf("admin server is listening on %s", a.Address))
+	if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (a *AdminServerImpl) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SHUTDOWN_TIMEOUT)
+	defer cancel()
+	if err := a.server.Shutdown(ctx); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to shut down admin server/reason:%s", err))
+	}
+}
+
+func NewHandler(s *core.SystemComponents) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/queue", func(w http.ResponseWriter, r *http.Request) {
+		jobs := s.ListScheduledJobs()
+		if jobs == nil {
+			jobs = []core.ScheduledJob{}
+		}
+		writeJSON(w, http.StatusOK, &QueueResponse{
+			QueueSize: s.GetCurrentQueueSize(),
+			FreeSlots: s.GetQueueFreeSlots(),
+			Jobs:      jobs,
+		})
+	})
+	mux.HandleFunc("GET /admin/queue/{job_id}", func(w http.ResponseWriter, r *http.Request) {
+		jobID := r.PathValue("job_id")
+		for _, j := range s.ListScheduledJobs() {
+			if j.JobID == jobID {
+				writeJSON(w, http.StatusOK, &j)
+				return
+			}
+		}
+		writeJSON(w, http.StatusNotFound,
+			&ErrorResponse{Message: fmt.Sprintf("job(%s) is not in the scheduler", jobID)})
+	})
+	mux.HandleFunc("GET /admin/reservations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, core.GetReservations().List())
+	})
+	mux.HandleFunc("POST /admin/reservations", func(w http.ResponseWriter, r *http.Request) {
+		req := core.Reservation{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest,
+				&ErrorResponse{Message: fmt.Sprintf("invalid reservation: %s", err)})
+			return
+		}
+		added, err := core.GetReservations().Add(req)
+		if errors.Is(err, core.ErrorReservationConflict) {
+			writeJSON(w, http.StatusConflict, &ErrorResponse{Message: err.Error()})
+			return
+		}
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, &ErrorResponse{Message: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusCreated, &added)
+	})
+	mux.HandleFunc("DELETE /admin/reservations/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := core.GetReservations().Delete(r.PathValue("id")); err != nil {
+			writeJSON(w, http.StatusNotFound, &ErrorResponse{Message: err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to write the response of admin server/reason:%s", err))
+	}
+}
@@ -0,0 +1,74 @@
+//go:build unit
+// +build unit
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	h := NewHandler(s)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/queue", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	res := &QueueResponse{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), res))
+	assert.Equal(t, 0, res.QueueSize)
+	assert.Equal(t, []core.ScheduledJob{}, res.Jobs)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/queue/unknown_job", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/queue", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestReservationHandler(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	h := NewHandler(s)
+
+	body := `{"start":"2099-01-05T09:00:00Z","end":"2099-01-05T12:00:00Z","reason":"calibration"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reservations", strings.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	added := &core.Reservation{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), added))
+	assert.NotEmpty(t, added.ID)
+	defer core.GetReservations().Delete(added.ID)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reservations", strings.NewReader(body)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reservations", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rs := []core.Reservation{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &rs))
+	assert.Len(t, rs, 1)
+	assert.Equal(t, "calibration", rs[0].Reason)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/reservations/"+added.ID, nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/reservations/"+added.ID, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
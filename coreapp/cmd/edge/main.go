@@ -4,24 +4,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/massn/envordot"
 	"github.com/oklog/run"
 
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/admin"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/db"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/estimation"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/filedrop"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/jobservice"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/log"
 	multiprog "github.com/oqtopus-team/oqtopus-engine/coreapp/multiprog/manual"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/poller"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/qpu"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/rest"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/scheduler"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sse"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sse/router"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/transpiler"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/webhook"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/workflow"
 
 	"go.uber.org/dig"
 	"go.uber.org/zap"
@@ -51,9 +58,9 @@ type Edge struct {
 }
 
 type DIContainerParameters struct {
-	DBManager  string `long:"db" description:"db" default:"memory" choice:"memory" choice:"service" env:"QIQB_EDGE_DB_MANAGER_TYPE"`
+	DBManager  string `long:"db" description:"db" default:"memory" choice:"memory" choice:"service" choice:"bolt" choice:"file" env:"QIQB_EDGE_DB_MANAGER_TYPE"`
 	Transpiler string `long:"transpiler" description:"transpiler-type" default:"tranqu" choice:"tranqu" env:"QIQB_EDGE_TRANSPILER_TYPE"`
-	QPU        string `long:"qpu" description:"qpu-type" default:"dummy" choice:"dummy" choice:"it" choice:"gateway" env:"QIQB_EDGE_QPU_TYPE"`
+	QPU        string `long:"qpu" description:"qpu-type" default:"dummy" choice:"dummy" choice:"it" choice:"gateway" choice:"multi" env:"QIQB_EDGE_QPU_TYPE"`
 	Scheduler  string `long:"scheduler" description:"scheduler-type" default:"normal" env:"QIQB_EDGE_SCHEDULER_TYPE"`
 }
 
@@ -88,6 +95,8 @@ func (e *Edge) provideDIContainer() (c *dig.Container, err error) {
 			return &qpu.DummyQPU{}, nil
 		case "gateway":
 			return &qpu.GatewayQPU{}, nil
+		case "multi":
+			return &qpu.MultiQPU{}, nil
 		default:
 			return &qpu.DummyQPU{}, fmt.Errorf("%s is an unknown QPU", e.DIContainerParameters.QPU)
 		}
@@ -106,7 +115,28 @@ func (e *Edge) provideDIContainer() (c *dig.Container, err error) {
 	if err != nil {
 		return &dig.Container{}, err
 	}
-	err = c.Provide(func() core.Scheduler { return &scheduler.NormalScheduler{} })
+	err = c.Provide(func() (core.Scheduler, error) {
+		newScheduler := func() core.Scheduler {
+			switch e.DIContainerParameters.Scheduler {
+			case "priority":
+				return &scheduler.PriorityScheduler{}
+			case "fair_share":
+				return &scheduler.FairShareScheduler{}
+			default:
+				return &scheduler.NormalScheduler{}
+			}
+		}
+		switch e.DIContainerParameters.Scheduler {
+		case "normal", "priority", "fair_share":
+		default:
+			return &scheduler.NormalScheduler{}, fmt.Errorf("%s is an unknown Scheduler", e.DIContainerParameters.Scheduler)
+		}
+		// each device hosted in the engine has its own queue
+		if e.DIContainerParameters.QPU == "multi" {
+			return scheduler.NewDeviceScheduler(newScheduler), nil
+		}
+		return newScheduler(), nil
+	})
 	if err != nil {
 		return &dig.Container{}, err
 	}
@@ -115,7 +145,14 @@ func (e *Edge) provideDIContainer() (c *dig.Container, err error) {
 		case "memory":
 			return &core.MemoryDB{}, nil
 		case "service":
+			if jobservice.UseGRPC() {
+				return &db.GRPCServiceDB{}, nil
+			}
 			return &db.ServiceDB{}, nil
+		case "bolt":
+			return &db.BoltDB{}, nil
+		case "file":
+			return &db.FileDB{}, nil
 		default:
 			return &core.MemoryDB{}, fmt.Errorf("%s is an unknown DB", e.DIContainerParameters.DBManager)
 		}
@@ -138,6 +175,7 @@ func (e *Edge) startCore(conf *core.Conf) error {
 		&sse.SSEJob{},
 		&multiprog.ManualJob{},
 		&estimation.EstimationJob{},
+		&workflow.WorkflowJob{},
 	)
 	err := core.GetSystemComponents().StartContainer()
 	if err != nil {
@@ -249,11 +287,20 @@ func (c *pollerCmd) Execute(args []string) error {
 
 	im := &core.ImplMaps{
 		PeriodicTaskImplMap: core.PeriodicTaskImplMap{
-			poller.PollerTaskName:  &poller.Poller{},
-			log.VersionLogTaskName: &log.VersionLogTaskImpl{},
-			log.MetricsLogTaskName: &log.MetricsLogTaskImpl{},
+			poller.PollerTaskName:     &poller.Poller{},
+			log.VersionLogTaskName:    &log.VersionLogTaskImpl{},
+			log.MetricsLogTaskName:    &log.MetricsLogTaskImpl{},
+			scheduler.JobTTLTaskName:  &scheduler.JobTTLTaskImpl{},
+			filedrop.FileDropTaskName: &filedrop.FileDropTaskImpl{},
+		},
+		InternalJobServerImplMap: core.InternalJobServerImplMap{
+			log.AuditLogServerName:    &log.AuditLogServerImpl{},
+			webhook.WebhookServerName: &webhook.WebhookServerImpl{},
+		},
+		APIServerImplMap: core.APIServerImplMap{
+			admin.AdminServerName: &admin.AdminServerImpl{},
+			rest.RestServerName:   &rest.RestServerImpl{},
 		},
-		APIServerImplMap: core.APIServerImplMap{},
 	}
 	rc, err := core.NewRunContextWithSettingPath(edge.Conf.SettingPath, im)
 	if err != nil {
@@ -281,7 +328,11 @@ func (c *pollerCmd) setupRunGroup(rc *core.RunContext) error {
 	rc.Add(
 		run.SignalHandler(
 			rc.Context,
-			os.Interrupt))
+			os.Interrupt, syscall.SIGTERM))
+	// the jobs in flight are drained after the polling has stopped
+	if err := rc.AddSystemComponents(core.GetSystemComponents(), edge.Conf.DrainTimeout); err != nil {
+		return err
+	}
 	core.SetRunContext(rc)
 	return nil
 }
@@ -319,7 +370,17 @@ func setupSystemComponents(conf *core.Conf) *core.SystemComponents {
 }
 
 func registerSetting() {
-	core.RegisterSetting("gateway", qpu.NewDefaultGatewayAgentSetting())
+	core.RegisterSetting(qpu.GATEWAY_SETTING_KEY, qpu.NewDefaultGatewayAgentSetting())
+	core.RegisterSetting(core.DEVICES_SETTING_KEY, core.NewDevicesSetting())
+	core.RegisterSetting(jobservice.JOB_SERVICE_SETTING_KEY, jobservice.NewJobServiceSetting())
 	core.RegisterSetting("tranqu", transpiler.NewTranquSetting())
 	core.RegisterSetting(estimation.ESTIMATION_SETTING_KEY, estimation.NewEstimationSetting())
+	core.RegisterSetting(core.JOB_TIMEOUT_SETTING_KEY, core.NewJobTimeoutSetting())
+	core.RegisterSetting(core.JOB_TTL_SETTING_KEY, core.NewJobTTLSetting())
+	core.RegisterSetting(core.SHOTS_SETTING_KEY, core.NewShotsSetting())
+	core.RegisterSetting(core.RETRY_SETTING_KEY, core.NewRetrySetting())
+	core.RegisterSetting(core.WORKER_POOL_SETTING_KEY, core.NewWorkerPoolSetting())
+	core.RegisterSetting(core.RESERVATION_SETTING_KEY, core.NewReservationSetting())
+	core.RegisterSetting(scheduler.PRIORITY_SETTING_KEY, scheduler.NewPrioritySetting())
+	core.RegisterSetting(scheduler.FAIR_SHARE_SETTING_KEY, scheduler.NewFairShareSetting())
 }
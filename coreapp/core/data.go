@@ -142,7 +142,10 @@ type Result struct {
 	TranspilerInfo *TranspilerInfo `json:"transpiler_info"`
 	Estimation     *Estimation     `json:"estimation"`
 	Message        string          `json:"message"`
+	ErrorKind      ErrorKind       `json:"error_kind,omitempty"`
 	ExecutionTime  time.Duration   `json:"execution_time"`
+	// StepResults is the results of the steps of a workflow job keyed by the step name
+	StepResults map[string]*Result `json:"step_results,omitempty"`
 }
 
 type TranspilerInfo struct {
@@ -170,14 +173,20 @@ func cloneCounts(counts Counts) Counts {
 	return clone
 }
 
-func cloneTranspilerInfo(info *TranspilerInfo) *TranspilerInfo {
+// CloneTranspilerInfo returns a deep copy of the transpiler info, e.g. the qubit mapping of a program
+// run again without transpiling.
+func CloneTranspilerInfo(info *TranspilerInfo) *TranspilerInfo {
 	clone := &TranspilerInfo{}
+	clone.StatsRaw = StatsRaw(append(json.RawMessage(nil), info.StatsRaw...))
 	clone.PhysicalVirtualMapping = make(PhysicalVirtualMapping)
 
 	for k, v := range info.PhysicalVirtualMapping {
 		clone.PhysicalVirtualMapping[k] = v
 	}
 	clone.VirtualPhysicalMappingRaw = VirtualPhysicalMappingRaw(append(json.RawMessage(nil), info.VirtualPhysicalMappingRaw...))
+	if info.VirtualPhysicalMappingMap != nil {
+		clone.VirtualPhysicalMappingMap = make(VirtualPhysicalMappingMap)
+	}
 	for k, v := range info.VirtualPhysicalMappingMap {
 		clone.VirtualPhysicalMappingMap[k] = v
 	}
@@ -204,6 +213,18 @@ type JobData struct {
 	Ended          strfmt.DateTime
 	Info           string
 	MitigationInfo string
+	// StatusHistory is the transitions of the status made by SetStatus
+	StatusHistory []StatusTransition
+	// Priority is the priority class given to the job. The default of the job type is used when empty.
+	// The priority field of the provider API is an extension which the cloud has to provide.
+	Priority string
+	// Owner is the user or the project of the job for the fair-share scheduling.
+	// The owner field of the provider API is an extension which the cloud has to provide.
+	// The jobs from a cloud without it share one empty owner and are scheduled as a FIFO.
+	Owner string
+	// DeviceID is the device to run the job when the engine hosts several devices.
+	// The default device is used when empty.
+	DeviceID string
 
 	// VeryAdhoc
 	UseJobInfoUpdate          bool
@@ -250,10 +271,14 @@ func CloneJobData(i *JobData) *JobData {
 	o.QASM = i.QASM
 	o.TranspiledQASM = i.TranspiledQASM
 	o.Result.Counts = cloneCounts(i.Result.Counts)
-	o.Result.TranspilerInfo = cloneTranspilerInfo(i.Result.TranspilerInfo)
+	o.Result.TranspilerInfo = CloneTranspilerInfo(i.Result.TranspilerInfo)
 	o.JobType = i.JobType
 	o.Created = i.Created
 	o.Ended = i.Ended
+	o.Priority = i.Priority
+	o.Owner = i.Owner
+	o.DeviceID = i.DeviceID
+	o.StatusHistory = append([]StatusTransition{}, i.StatusHistory...)
 	if i.JobType == "estimation" {
 		o.Result.Estimation = cloneEstimation(i.Result.Estimation)
 	}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -8,20 +9,23 @@ import (
 )
 
 type MemoryDB struct {
-	dbMap  map[string]Job
-	dbChan <-chan Job
-	mu     sync.RWMutex
+	dbMap map[string]Job
+	sub   *Subscription
+	done  chan struct{}
+	mu    sync.RWMutex
 }
 
-func (d *MemoryDB) Setup(dbc DBChan, c *Conf) error {
+func (d *MemoryDB) Setup(bus *EventBus, c *Conf) error {
 	d.dbMap = make(map[string]Job)
-	d.dbChan = dbc
+	d.sub = bus.Subscribe("MemoryDB", DEFAULT_EVENT_BUFFER_SIZE, Coalesce)
+	d.done = make(chan struct{})
 	go func() {
-		for {
-			job := <-d.dbChan
-			if job == nil { //when dbChan is closed
-				return //TODO :remove this adhoc code. Use RunGroup
+		defer close(d.done)
+		for e := range d.sub.Events() { // until the bus is closed
+			if !e.Type.ChangesStatus() {
+				continue
 			}
+			job := e.Job
 			zap.L().Debug(fmt.Sprintf("[MemoryDB] Received %s", job.JobData().ID))
 			if err := d.Update(job); err != nil {
 				zap.L().Error(fmt.Sprintf("failed to update a job(%s). Reason:%s",
@@ -32,13 +36,43 @@ func (d *MemoryDB) Setup(dbc DBChan, c *Conf) error {
 	return nil
 }
 
+func (d *MemoryDB) Flush(ctx context.Context) error {
+	return WaitForFlush(ctx, "MemoryDB", d.done)
+}
+
+// WaitForFlush waits until the event loop of the DB writer closes done after the bus is closed.
+func WaitForFlush(ctx context.Context, name string, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s has not written the pending events: %w", name, context.Cause(ctx))
+	}
+}
+
 func (d *MemoryDB) Insert(j Job) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.dbMap[j.JobData().ID] = j
+	id := j.JobData().ID
+	if old, ok := d.dbMap[id]; ok && IsAnotherActiveJob(old.JobData(), j.JobData()) {
+		err := fmt.Errorf("%w: job(%s) is in %s", ErrorJobIDConflict, id, old.JobData().Status)
+		CountDuplicateJob(id, err)
+		return err
+	}
+	d.dbMap[id] = j
 	return nil
 }
 
+// IsAnotherActiveJob reports whether the stored job is not finished and is not the inserted job itself,
+// e.g. the same job fetched twice. A job recovered from the DB is the same job, which has the same creation time.
+func IsAnotherActiveJob(stored *JobData, inserted *JobData) bool {
+	if IsTerminal(stored.Status) {
+		return false
+	}
+	// compared in the precision of the DB
+	return stored.Created.String() != inserted.Created.String()
+}
+
 func (d *MemoryDB) Get(jobID string) (Job, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
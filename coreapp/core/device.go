@@ -0,0 +1,154 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const DEVICES_SETTING_KEY = "devices"
+
+var ErrorUnknownDevice = errors.New("unknown device")
+
+// DevicesSetting is the setting of the devices hosted in one engine in [com.devices].
+// Each backend is set in [com.devices.backends.<device_id>] with the type of its QPU.
+// The jobs without a device are routed to the default device.
+type DevicesSetting struct {
+	Default  string                    `toml:"default"`
+	Backends map[string]BackendSetting `toml:"backends"`
+}
+
+type BackendSetting struct {
+	// dummy or gateway
+	QPU string `toml:"qpu"`
+	// the name of the component setting of the gateway. "gateway" when empty
+	GatewaySetting string `toml:"gateway_setting"`
+}
+
+func NewDevicesSetting() DevicesSetting {
+	return DevicesSetting{
+		Backends: map[string]BackendSetting{},
+	}
+}
+
+func GetDevicesSetting() DevicesSetting {
+	return GetSetting(DEVICES_SETTING_KEY, NewDevicesSetting())
+}
+
+// DeviceIDs returns the IDs of the devices with the default device first.
+func (s DevicesSetting) DeviceIDs() []string {
+	ids := []string{}
+	for id := range s.Backends {
+		if id != s.Default {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if _, ok := s.Backends[s.Default]; ok {
+		ids = append([]string{s.Default}, ids...)
+	}
+	return ids
+}
+
+func (s DevicesSetting) Validate() error {
+	if len(s.Backends) == 0 {
+		return fmt.Errorf("no backend in %s setting", DEVICES_SETTING_KEY)
+	}
+	if _, ok := s.Backends[s.Default]; !ok {
+		return fmt.Errorf("the default device %q is not in the backends", s.Default)
+	}
+	return nil
+}
+
+// MultiDeviceQPUManager is implemented by the QPUManager hosting several devices.
+// Send routes the job to the device of JobData.DeviceID.
+type MultiDeviceQPUManager interface {
+	QPUManager
+	GetDeviceInfoOf(deviceID string) (*DeviceInfo, error)
+	ValidateOf(deviceID string, qasm string) error
+}
+
+// MultiDeviceScheduler is implemented by the Scheduler which has a queue for each device.
+type MultiDeviceScheduler interface {
+	Scheduler
+	DeviceQueue(deviceID string) (Scheduler, error)
+}
+
+// GetDeviceInfoOf returns the info of the device. The engine hosting one device has the device for any ID.
+func (s *SystemComponents) GetDeviceInfoOf(deviceID string) (*DeviceInfo, error) {
+	var (
+		deviceInfo *DeviceInfo
+		err        error
+	)
+	s.Invoke(
+		func(q QPUManager) {
+			if m, ok := q.(MultiDeviceQPUManager); ok {
+				deviceInfo, err = m.GetDeviceInfoOf(deviceID)
+				return
+			}
+			deviceInfo = q.GetDeviceInfo()
+		})
+	return deviceInfo, err
+}
+
+// ValidateOf validates the QASM for the device. The engine hosting one device validates it for any ID.
+func (s *SystemComponents) ValidateOf(deviceID string, qasm string) error {
+	var err error
+	s.Invoke(
+		func(q QPUManager) {
+			if m, ok := q.(MultiDeviceQPUManager); ok {
+				err = m.ValidateOf(deviceID, qasm)
+				return
+			}
+			err = q.Validate(qasm)
+		})
+	return err
+}
+
+// deviceQueue returns the scheduler of the queue of the device.
+func (s *SystemComponents) deviceQueue(deviceID string) (Scheduler, error) {
+	var (
+		queue Scheduler
+		err   error
+	)
+	s.Invoke(
+		func(sc Scheduler) {
+			if m, ok := sc.(MultiDeviceScheduler); ok {
+				queue, err = m.DeviceQueue(deviceID)
+				return
+			}
+			queue = sc
+		})
+	return queue, err
+}
+
+// HostsDevice reports whether the engine has the queue of the device. The engine hosting one device
+// hosts any ID.
+func (s *SystemComponents) HostsDevice(deviceID string) bool {
+	_, err := s.deviceQueue(deviceID)
+	return err == nil
+}
+
+func (s *SystemComponents) GetQueueFreeSlotsOf(deviceID string) int {
+	q, err := s.deviceQueue(deviceID)
+	if err != nil {
+		return 0
+	}
+	return q.GetQueueFreeSlots()
+}
+
+func (s *SystemComponents) IsQueueOverRefillThresholdOf(deviceID string) bool {
+	q, err := s.deviceQueue(deviceID)
+	if err != nil {
+		return true
+	}
+	return q.IsOverRefillThreshold()
+}
+
+func (s *SystemComponents) GetCurrentQueueSizeOf(deviceID string) int {
+	q, err := s.deviceQueue(deviceID)
+	if err != nil {
+		return 0
+	}
+	return q.GetCurrentQueueSize()
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorKind classifies the failures of jobs. The kind is also the label of the failure metrics.
+type ErrorKind string
+
+const (
+	// the job itself is invalid, e.g. a bad QASM or too many shots
+	UserInputError ErrorKind = "user_input"
+	// the QPU is not connected or not available
+	DeviceUnavailableError ErrorKind = "device_unavailable"
+	// a service such as the transpiler, the mitigator or the estimator failed
+	DependencyFailureError ErrorKind = "dependency_failure"
+	// a bug or an unexpected state of the engine
+	InternalError ErrorKind = "internal"
+)
+
+func ErrorKinds() []ErrorKind {
+	return []ErrorKind{UserInputError, DeviceUnavailableError, DependencyFailureError, InternalError}
+}
+
+// userMessages are shown to the user instead of the errors not made for the user.
+var userMessages = map[ErrorKind]string{
+	UserInputError:         "the job is invalid",
+	DeviceUnavailableError: "the device is not available",
+	DependencyFailureError: "a service required to run the job failed",
+	InternalError:          "an internal error occurred",
+}
+
+// JobError is a failure of a job with its kind.
+// Message is shown to the user, and Err is the detail only written to the log for the operators.
+type JobError struct {
+	Kind    ErrorKind
+	Message string
+	Err     error
+}
+
+func (e *JobError) Error() string {
+	if e.Err == nil || e.Err.Error() == e.Message {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Message, e.Err)
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+func NewUserInputError(msg string, err error) error {
+	return &JobError{Kind: UserInputError, Message: msg, Err: err}
+}
+
+func NewDeviceUnavailableError(msg string, err error) error {
+	return &JobError{Kind: DeviceUnavailableError, Message: msg, Err: err}
+}
+
+func NewDependencyFailureError(msg string, err error) error {
+	return &JobError{Kind: DependencyFailureError, Message: msg, Err: err}
+}
+
+func NewInternalError(msg string, err error) error {
+	return &JobError{Kind: InternalError, Message: msg, Err: err}
+}
+
+// WithErrorKind gives the kind to the error. The message of the error is shown to the user as it is,
+// unless the error is a raw gRPC error. The kind of a JobError is kept.
+func WithErrorKind(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	var je *JobError
+	if errors.As(err, &je) {
+		return err
+	}
+	msg := err.Error()
+	if _, ok := status.FromError(err); ok {
+		msg = userMessages[kind]
+	}
+	return &JobError{Kind: kind, Message: msg, Err: err}
+}
+
+// ErrorKindOf returns the kind of the error. The errors without kind are classified by their gRPC code.
+func ErrorKindOf(err error) ErrorKind {
+	var je *JobError
+	if errors.As(err, &je) {
+		return je.Kind
+	}
+	if errors.Is(err, ErrorJobTimeout) {
+		return DependencyFailureError
+	}
+	if _, ok := status.FromError(err); !ok {
+		return InternalError
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return UserInputError
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return DependencyFailureError
+	default:
+		return InternalError
+	}
+}
+
+// UserMessage returns the message of the error which is safe to show to the user.
+// The raw gRPC errors are replaced with the message of their kind.
+func UserMessage(err error) string {
+	var je *JobError
+	if errors.As(err, &je) {
+		return je.Message
+	}
+	if _, ok := status.FromError(err); ok {
+		return userMessages[ErrorKindOf(err)]
+	}
+	return err.Error()
+}
+
+// FailureMessage returns the message of the failed job for the user.
+// The message of the error kind is used when the job has failed without a message.
+func FailureMessage(jd *JobData) string {
+	if jd.Result.Message != "" {
+		return jd.Result.Message
+	}
+	kind := jd.Result.ErrorKind
+	if kind == "" {
+		kind = InternalError
+	}
+	return userMessages[kind]
+}
+
+var (
+	failureCountsMu sync.Mutex
+	failureCounts   = map[ErrorKind]uint64{}
+)
+
+// CountFailure counts the failed job with its error kind. It is called once for a job
+// when the job has finished, because a job can be failed more than once by the retries.
+func CountFailure(jd *JobData) {
+	kind := jd.Result.ErrorKind
+	if kind == "" {
+		kind = InternalError
+	}
+	failureCountsMu.Lock()
+	defer failureCountsMu.Unlock()
+	failureCounts[kind]++
+}
+
+// FailureCounts returns the number of the failed jobs for each error kind since the start of the engine.
+func FailureCounts() map[ErrorKind]uint64 {
+	failureCountsMu.Lock()
+	defer failureCountsMu.Unlock()
+	counts := map[ErrorKind]uint64{}
+	for _, k := range ErrorKinds() {
+		counts[k] = failureCounts[k]
+	}
+	return counts
+}
@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorKind(t *testing.T) {
+	grpcErr := status.Error(codes.Unavailable, "connection refused to 10.0.0.1:5002")
+	tests := []struct {
+		name        string
+		err         error
+		wantKind    ErrorKind
+		wantMessage string
+	}{
+		{
+			name:        "user input",
+			err:         NewUserInputError("shots(0) must be greater than 0", nil),
+			wantKind:    UserInputError,
+			wantMessage: "shots(0) must be greater than 0",
+		},
+		{
+			name:        "dependency failure wrapping gRPC error",
+			err:         NewDependencyFailureError("failed to call the transpiler", grpcErr),
+			wantKind:    DependencyFailureError,
+			wantMessage: "failed to call the transpiler",
+		},
+		{
+			name:        "wrapped job error",
+			err:         fmt.Errorf("step a: %w", NewDeviceUnavailableError("Gateway QPU is not connected", nil)),
+			wantKind:    DeviceUnavailableError,
+			wantMessage: "Gateway QPU is not connected",
+		},
+		{
+			name:        "raw gRPC error",
+			err:         grpcErr,
+			wantKind:    DependencyFailureError,
+			wantMessage: userMessages[DependencyFailureError],
+		},
+		{
+			name:        "raw gRPC error with kind",
+			err:         WithErrorKind(DeviceUnavailableError, grpcErr),
+			wantKind:    DeviceUnavailableError,
+			wantMessage: userMessages[DeviceUnavailableError],
+		},
+		{
+			name:        "plain error",
+			err:         fmt.Errorf("unexpected"),
+			wantKind:    InternalError,
+			wantMessage: "unexpected",
+		},
+		{
+			name:        "timeout",
+			err:         fmt.Errorf("%w: processing", ErrorJobTimeout),
+			wantKind:    DependencyFailureError,
+			wantMessage: "job timed out: processing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantKind, ErrorKindOf(tt.err))
+			assert.Equal(t, tt.wantMessage, UserMessage(tt.err))
+
+			jd := NewJobData()
+			SetFailureWithErrorToJobData(jd, tt.err)
+			assert.Equal(t, FAILED, jd.Status)
+			assert.Equal(t, tt.wantKind, jd.Result.ErrorKind)
+			assert.Equal(t, tt.wantMessage, jd.Result.Message)
+			assert.NotContains(t, jd.Result.Message, "10.0.0.1")
+		})
+	}
+	// the code is kept for the retry
+	assert.Equal(t, codes.Unavailable, status.Code(NewDependencyFailureError("failed", grpcErr)))
+}
+
+func TestCountFailure(t *testing.T) {
+	before := FailureCounts()
+	jd := NewJobData()
+	SetFailureWithErrorToJobData(jd, NewUserInputError("invalid", nil))
+	CountFailure(jd)
+	CountFailure(NewJobData())
+	after := FailureCounts()
+	assert.Equal(t, before[UserInputError]+1, after[UserInputError])
+	assert.Equal(t, before[InternalError]+1, after[InternalError])
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type EventType string
+
+const (
+	JobQueued        EventType = "queued"
+	JobPreprocessing EventType = "preprocessing"
+	JobRunning       EventType = "running"
+	JobMitigating    EventType = "mitigating"
+	JobSucceeded     EventType = "succeeded"
+	JobFailed        EventType = "failed"
+	JobCancelled     EventType = "cancelled"
+)
+
+// ChangesStatus reports whether the event reports a new status of the job to be written to the DB.
+func (t EventType) ChangesStatus() bool {
+	return t != JobPreprocessing && t != JobMitigating
+}
+
+// EventTypeOf returns the event type which reports the status of the job.
+func EventTypeOf(st Status) EventType {
+	switch st {
+	case RUNNING:
+		return JobRunning
+	case SUCCEEDED:
+		return JobSucceeded
+	case FAILED:
+		return JobFailed
+	case CANCELLED:
+		return JobCancelled
+	default:
+		return JobQueued
+	}
+}
+
+// JobEvent is a change in the lifecycle of a job. Job is a clone taken when the event is published.
+type JobEvent struct {
+	Type EventType
+	Job  Job
+	Time time.Time
+}
+
+// OverflowPolicy decides what a subscription does when its subscriber is slower than the publishers.
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest event when the buffer of the subscription is full.
+	// e.g. metrics, which can lose events
+	DropOldest OverflowPolicy = iota
+	// Coalesce drops the pending events of a job when a newer event of the job arrives, so that only
+	// the latest state of each job is delivered. The oldest event is dropped as in DropOldest only
+	// when every pending event is of a different job. e.g. DB
+	Coalesce
+)
+
+// EventBus delivers the lifecycle events of the jobs to the subscribers.
+// Publish never blocks, and each subscriber receives the events through its own buffer,
+// so that a slow subscriber delays neither the scheduler nor the other subscribers.
+type EventBus struct {
+	mu     sync.RWMutex
+	subs   []*Subscription
+	closed bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe returns a subscription which receives the events published after the call.
+// size is the number of the events buffered for the subscriber.
+func (b *EventBus) Subscribe(name string, size int, policy OverflowPolicy) *Subscription {
+	s := &Subscription{
+		name:   name,
+		size:   size,
+		policy: policy,
+		notify: make(chan struct{}, 1),
+		out:    make(chan JobEvent),
+	}
+	go s.pump()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.close()
+		return s
+	}
+	b.subs = append(b.subs, s)
+	return s
+}
+
+// Publish sends the event with a clone of the job to all the subscribers.
+func (b *EventBus) Publish(t EventType, j Job) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		zap.L().Debug(fmt.Sprintf("event bus is closed. drop %s event of job(%s)", t, j.JobData().ID))
+		return
+	}
+	if len(b.subs) == 0 {
+		return
+	}
+	e := JobEvent{Type: t, Job: j.Clone(), Time: time.Now()}
+	for _, s := range b.subs {
+		s.push(e)
+	}
+}
+
+// Close closes the subscriptions after they deliver the pending events.
+func (b *EventBus) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, s := range b.subs {
+		s.close()
+	}
+}
+
+type Subscription struct {
+	name   string
+	size   int
+	policy OverflowPolicy
+
+	mu      sync.Mutex
+	pending []JobEvent
+	dropped uint64
+	closed  bool
+	notify  chan struct{}
+	out     chan JobEvent
+}
+
+// Events returns the channel of the events. The channel is closed when the bus is closed.
+func (s *Subscription) Events() <-chan JobEvent {
+	return s.out
+}
+
+// Dropped returns the number of the events dropped for the slow subscriber.
+func (s *Subscription) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+func (s *Subscription) push(e JobEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if len(s.pending) >= s.size {
+		switch s.policy {
+		case Coalesce:
+			s.coalesce(e.Job.JobData().ID)
+			if len(s.pending) < s.size {
+				break
+			}
+			// every pending event is the latest one of its job
+			fallthrough
+		default:
+			zap.L().Warn(fmt.Sprintf("[%s] subscriber is slow. drop %s event of job(%s)",
+				s.name, s.pending[0].Type, s.pending[0].Job.JobData().ID))
+			s.pending = s.pending[1:]
+			s.dropped++
+		}
+	}
+	s.pending = append(s.pending, e)
+	s.wake()
+}
+
+// coalesce drops the pending events of the job, whose newer event is appended, and the older
+// events of the jobs which have a newer pending event. The events of a job are kept in order.
+// coalesce must be called with s.mu held.
+func (s *Subscription) coalesce(jobID string) {
+	latest := map[string]int{jobID: len(s.pending)}
+	for i, p := range s.pending {
+		if id := p.Job.JobData().ID; id != jobID {
+			latest[id] = i
+		}
+	}
+	kept := s.pending[:0]
+	for i, p := range s.pending {
+		if id := p.Job.JobData().ID; latest[id] != i {
+			zap.L().Debug(fmt.Sprintf("[%s] coalesce %s event of job(%s) into a newer event", s.name, p.Type, id))
+			s.dropped++
+			continue
+		}
+		kept = append(kept, p)
+	}
+	s.pending = kept
+}
+
+func (s *Subscription) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.wake()
+}
+
+// pump moves the pending events to the subscriber. Only the pump waits for the subscriber.
+func (s *Subscription) pump() {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		if len(s.pending) == 0 {
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return
+			}
+			<-s.notify
+			continue
+		}
+		e := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+		s.out <- e
+	}
+}
@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newJobForEventTest(id string, st Status) Job {
+	jd := NewJobData()
+	jd.ID = id
+	jd.Status = st
+	return &NormalJob{jobData: jd}
+}
+
+func receive(t *testing.T, s *Subscription) JobEvent {
+	select {
+	case e := <-s.Events():
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return JobEvent{}
+	}
+}
+
+func TestEventBusPublish(t *testing.T) {
+	b := NewEventBus()
+	s1 := b.Subscribe("s1", 10, DropOldest)
+	s2 := b.Subscribe("s2", 10, Coalesce)
+	j := newJobForEventTest("job1", READY)
+	b.Publish(JobQueued, j)
+	// the subscribers receive a clone taken at the publication
+	j.JobData().Status = RUNNING
+
+	for _, s := range []*Subscription{s1, s2} {
+		e := receive(t, s)
+		assert.Equal(t, JobQueued, e.Type)
+		assert.Equal(t, "job1", e.Job.JobData().ID)
+		assert.Equal(t, READY, e.Job.JobData().Status)
+	}
+}
+
+func TestEventBusDropOldest(t *testing.T) {
+	b := NewEventBus()
+	s := b.Subscribe("slow", 2, DropOldest)
+	// the first event is taken by the pump, which waits for the subscriber
+	b.Publish(JobQueued, newJobForEventTest("job0", READY))
+	time.Sleep(50 * time.Millisecond)
+	for _, id := range []string{"job1", "job2", "job3"} {
+		b.Publish(JobQueued, newJobForEventTest(id, READY))
+	}
+	b.Close()
+
+	ids := []string{}
+	for e := range s.Events() {
+		ids = append(ids, e.Job.JobData().ID)
+	}
+	assert.Equal(t, []string{"job0", "job2", "job3"}, ids)
+	assert.Equal(t, uint64(1), s.Dropped())
+}
+
+func TestEventBusCoalesce(t *testing.T) {
+	b := NewEventBus()
+	s := b.Subscribe("slow", 2, Coalesce)
+	b.Publish(JobQueued, newJobForEventTest("job0", READY))
+	time.Sleep(50 * time.Millisecond)
+	b.Publish(JobQueued, newJobForEventTest("job1", READY))
+	b.Publish(JobQueued, newJobForEventTest("job2", READY))
+	b.Publish(JobRunning, newJobForEventTest("job1", RUNNING))
+	b.Publish(JobSucceeded, newJobForEventTest("job1", SUCCEEDED))
+	b.Close()
+
+	events := []JobEvent{}
+	for e := range s.Events() {
+		events = append(events, e)
+	}
+	assert.Len(t, events, 3)
+	// the older events of job1 are dropped and its latest event is delivered last
+	assert.Equal(t, "job2", events[1].Job.JobData().ID)
+	assert.Equal(t, "job1", events[2].Job.JobData().ID)
+	assert.Equal(t, JobSucceeded, events[2].Type)
+	assert.Equal(t, uint64(2), s.Dropped())
+}
+
+func TestEventBusCoalesceBound(t *testing.T) {
+	b := NewEventBus()
+	s := b.Subscribe("slow", 3, Coalesce)
+	b.Publish(JobQueued, newJobForEventTest("job0", READY))
+	time.Sleep(50 * time.Millisecond)
+	b.Publish(JobQueued, newJobForEventTest("job1", READY))
+	b.Publish(JobRunning, newJobForEventTest("job1", RUNNING))
+	b.Publish(JobQueued, newJobForEventTest("job2", READY))
+	// the older event of job1 is dropped to make room
+	b.Publish(JobQueued, newJobForEventTest("job3", READY))
+	// every pending event is the latest one of its job, so the oldest is dropped
+	b.Publish(JobQueued, newJobForEventTest("job4", READY))
+	b.Close()
+
+	events := []string{}
+	for e := range s.Events() {
+		events = append(events, fmt.Sprintf("%s:%s", e.Job.JobData().ID, e.Type))
+	}
+	assert.Equal(t, []string{"job0:queued", "job2:queued", "job3:queued", "job4:queued"}, events)
+	assert.Equal(t, uint64(2), s.Dropped())
+}
+
+func TestEventBusClose(t *testing.T) {
+	b := NewEventBus()
+	s := b.Subscribe("s", 10, DropOldest)
+	b.Close()
+	b.Publish(JobQueued, newJobForEventTest("job1", READY))
+	_, ok := <-s.Events()
+	assert.False(t, ok)
+
+	// the nil bus of the jobs without the system components
+	var nilBus *EventBus
+	nilBus.Publish(JobQueued, newJobForEventTest("job1", READY))
+	nilBus.Close()
+}
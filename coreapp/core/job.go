@@ -1,16 +1,22 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-openapi/strfmt"
 	"go.uber.org/zap"
 )
 
 var ErrorJobIDConflict = errors.New("jobID is already used")
+var ErrorJobCancelled = errors.New("job is cancelled")
+var ErrorJobInterrupted = errors.New("job was interrupted by the restart of the engine")
+var ErrorQueueFull = errors.New("queue is full")
+var ErrorDraining = errors.New("engine is draining")
 var jobManager *JobManager
 
 const NORMAL_JOB = "normal"
@@ -18,10 +24,12 @@ const NORMAL_JOB = "normal"
 type Job interface {
 	// Job Control
 	New(*JobData, *JobContext) Job
-	PreProcess()
-	Process()
-	PostProcess()
+	PreProcess(context.Context)
+	Process(context.Context)
+	PostProcess(context.Context)
 	IsFinished() bool
+	// Validate checks the job data specific to the job type before the job is queued
+	Validate() error
 
 	// Data Access
 	JobData() *JobData // Get mutable JobData
@@ -30,8 +38,26 @@ type Job interface {
 	Clone() Job
 }
 
+// ParentJob is a job whose work is done by its children handled in the scheduler, e.g. a workflow.
+// The scheduler calls RunChildren instead of queueing the parent after the pre-processing, so that
+// the parent holds neither the QPU nor a worker while its children wait in the queue like the other jobs.
+// handle handles the child in the scheduler and returns when the child has finished, or returns
+// the error when the scheduler refuses the child.
+type ParentJob interface {
+	Job
+	RunChildren(ctx context.Context, handle func(child Job) error)
+}
+
 type JobContext struct {
 	*Channels
+
+	// ctx is cancelled when the job is cancelled by the user
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// deadline is set when the scheduler admits the job, and shared by all the stages
+	deadline time.Time
+	timeout  time.Duration
 }
 
 func NewJobContext() (*JobContext, error) {
@@ -43,11 +69,55 @@ func NewJobContext() (*JobContext, error) {
 	if c == nil {
 		return nil, fmt.Errorf("channels is not initialized")
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &JobContext{
 		Channels: GetSystemComponents().Channels,
+		ctx:      ctx,
+		cancel:   cancel,
 	}, nil
 }
 
+// NewChildJobContext returns the context of a job which runs inside the parent job.
+// The child is cancelled with the parent. The events of the child have no subscriber,
+// because only the parent is known to the DB. Close must be called when the child has finished.
+func NewChildJobContext(parent *JobContext) *JobContext {
+	ctx, cancel := context.WithCancel(parent.Context())
+	return &JobContext{
+		Channels: NewChannels(),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Publish publishes the event of the job to the subscribers such as the DB.
+func (jc *JobContext) Publish(t EventType, j Job) {
+	if jc == nil || jc.Channels == nil {
+		return
+	}
+	jc.EventBus.Publish(t, j)
+}
+
+// Context returns the context which is done when the job is cancelled.
+// It never returns nil, even for a job without JobContext.
+func (jc *JobContext) Context() context.Context {
+	if jc == nil || jc.ctx == nil {
+		return context.Background()
+	}
+	return jc.ctx
+}
+
+// Cancel aborts the calls made with the context of the job.
+func (jc *JobContext) Cancel() {
+	if jc == nil || jc.cancel == nil {
+		return
+	}
+	jc.cancel()
+}
+
+func (jc *JobContext) IsCancelled() bool {
+	return jc.Context().Err() != nil
+}
+
 type JobParam struct {
 	JobID      string
 	QASM       string
@@ -68,8 +138,12 @@ func (j *NormalJob) New(jd *JobData, jc *JobContext) Job {
 	}
 }
 
-func (j *NormalJob) PreProcess() {
-	if err := j.preProcessImpl(); err != nil {
+func (j *NormalJob) Validate() error {
+	return ValidateShots(j.JobData())
+}
+
+func (j *NormalJob) PreProcess(ctx context.Context) {
+	if err := j.preProcessImpl(ctx); err != nil {
 		zap.L().Error(fmt.Sprintf("failed to pre-process a job(%s). Reason:%s",
 			j.JobData().ID, err.Error()))
 		SetFailureWithError(j, err)
@@ -78,7 +152,7 @@ func (j *NormalJob) PreProcess() {
 	return
 }
 
-func (j *NormalJob) preProcessImpl() (err error) {
+func (j *NormalJob) preProcessImpl(ctx context.Context) (err error) {
 	err = nil
 	jd := j.JobData()
 	container := GetSystemComponents().Container
@@ -88,10 +162,13 @@ func (j *NormalJob) preProcessImpl() (err error) {
 	if jd.NeedTranspiling() {
 		err = container.Invoke(
 			func(t Transpiler) error {
-				return t.Transpile(j)
+				return Retry(ctx, j.JobData(), "transpiler", func(ctx context.Context) error {
+					return t.Transpile(ctx, j)
+				})
 			})
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("failed to transpile a job(%s). Reason:%s", jd.ID, err.Error()))
+			err = WithErrorKind(DependencyFailureError, err)
 			return
 		}
 	} else {
@@ -101,25 +178,27 @@ func (j *NormalJob) preProcessImpl() (err error) {
 	return
 }
 
-func (j *NormalJob) Process() {
+func (j *NormalJob) Process(ctx context.Context) {
 	c := GetSystemComponents().Container
 	err := c.Invoke(
 		func(q QPUManager) error {
-			return q.Send(j)
+			return Retry(ctx, j.JobData(), "qpu", func(ctx context.Context) error {
+				return q.Send(ctx, j)
+			})
 		})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to send a job(%s) to QPU. Reason:%s", j.JobData().ID, err.Error()))
-		j.JobData().Status = FAILED
+		SetFailureWithError(j, WithErrorKind(DeviceUnavailableError, err))
 	}
 	zap.L().Debug(fmt.Sprintf("finished to process a job(%s)/status:%s", j.JobData().ID, j.JobData().Status))
 }
 
-func (j *NormalJob) PostProcess() {
+func (j *NormalJob) PostProcess(ctx context.Context) {
 	return
 }
 
 func (j *NormalJob) IsFinished() bool {
-	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED
+	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED || j.JobData().Status == CANCELLED
 }
 
 func (j *NormalJob) JobData() *JobData {
@@ -159,20 +238,24 @@ func (j *UnknownJob) New(jd *JobData, jc *JobContext) Job {
 	}
 }
 
-func (j *UnknownJob) PreProcess() {
+func (j *UnknownJob) Validate() error {
+	return nil
+}
+
+func (j *UnknownJob) PreProcess(ctx context.Context) {
 	return
 }
 
-func (j *UnknownJob) Process() {
+func (j *UnknownJob) Process(ctx context.Context) {
 	return
 }
 
-func (j *UnknownJob) PostProcess() {
+func (j *UnknownJob) PostProcess(ctx context.Context) {
 	return
 }
 
 func (j *UnknownJob) IsFinished() bool {
-	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED
+	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED || j.JobData().Status == CANCELLED
 }
 
 func (j *UnknownJob) JobData() *JobData {
@@ -229,6 +312,9 @@ func DeleteJob(id string) bool {
 // factory pattern
 type JobManager struct {
 	acceptableJobs []Job //empty jobs
+
+	mu         sync.Mutex
+	activeJobs map[string]struct{} // the IDs of the jobs handled in the engine
 }
 
 func (j *JobManager) RegisterJob(jobs ...Job) error {
@@ -254,15 +340,65 @@ func (j *JobManager) AcceptableJobTypes() []string {
 	return types
 }
 
+// Activate marks the job ID as active until Release is called.
+// A job ID which is already active is refused with ErrorJobIDConflict,
+// e.g. the same job fetched twice from the cloud.
+func (j *JobManager) Activate(jobID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.activeJobs == nil {
+		j.activeJobs = make(map[string]struct{})
+	}
+	if _, ok := j.activeJobs[jobID]; ok {
+		return fmt.Errorf("%w: job(%s) is already active", ErrorJobIDConflict, jobID)
+	}
+	j.activeJobs[jobID] = struct{}{}
+	return nil
+}
+
+func (j *JobManager) Release(jobID string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.activeJobs, jobID)
+}
+
+func (j *JobManager) IsActive(jobID string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, ok := j.activeJobs[jobID]
+	return ok
+}
+
+var duplicateJobCount atomic.Uint64
+
+// CountDuplicateJob counts the job refused because its ID is already active.
+func CountDuplicateJob(jobID string, err error) {
+	zap.L().Warn(fmt.Sprintf("refused duplicate job(%s)/reason:%s", jobID, err))
+	duplicateJobCount.Add(1)
+}
+
+// DuplicateJobCount returns the number of the duplicate jobs refused since the start of the engine.
+func DuplicateJobCount() uint64 {
+	return duplicateJobCount.Load()
+}
+
 func (j *JobManager) NewJobWithValidation(param *JobParam, jc *JobContext) (Job, error) {
 	if param.JobType == "" { // default job type
 		param.JobType = NORMAL_JOB
 	}
 	if err := validateJobParam(param); err != nil {
 		zap.L().Info(fmt.Sprintf("failed to validate job param. Reason:%s", err.Error()))
-		return nil, err
+		return nil, WithErrorKind(UserInputError, err)
+	}
+	job, err := j.NewJob(param, jc)
+	if err != nil {
+		return nil, WithErrorKind(UserInputError, err)
+	}
+	if err := job.Validate(); err != nil {
+		zap.L().Info(fmt.Sprintf("failed to validate job param. Reason:%s", err.Error()))
+		return nil, WithErrorKind(UserInputError, err)
 	}
-	return j.NewJob(param, jc)
+	return job, nil
 }
 
 func (j *JobManager) NewJob(param *JobParam, jc *JobContext) (Job, error) {
@@ -288,9 +424,17 @@ func (j *JobManager) NewJobFromJobDataWithValidation(jd *JobData, jc *JobContext
 	}
 	if err := validateJobParam(p); err != nil {
 		zap.L().Info(fmt.Sprintf("failed to validate job data. Reason:%s", err.Error()))
-		return nil, err
+		return nil, WithErrorKind(UserInputError, err)
 	}
-	return j.NewJobFromJobData(jd, jc)
+	job, err := j.NewJobFromJobData(jd, jc)
+	if err != nil {
+		return nil, WithErrorKind(UserInputError, err)
+	}
+	if err := job.Validate(); err != nil {
+		zap.L().Info(fmt.Sprintf("failed to validate job data. Reason:%s", err.Error()))
+		return nil, WithErrorKind(UserInputError, err)
+	}
+	return job, nil
 }
 
 func (j *JobManager) NewJobFromJobData(jd *JobData, jc *JobContext) (Job, error) {
@@ -317,21 +461,7 @@ func validateJobParam(p *JobParam) (err error) {
 		return fmt.Errorf("jobID is empty")
 	}
 
-	// TODO validate using PreProcess or Validate method in Job interface
-	if p.JobType == NORMAL_JOB {
-		if p.Shots <= 0 {
-			msg := fmt.Sprintf("shots(%d) must be greater than 0", p.Shots)
-			zap.L().Info(msg + fmt.Sprintf("/jobID:%s", p.JobID))
-			return fmt.Errorf(msg)
-		}
-		maxShots := GetSystemComponents().GetDeviceInfo().MaxShots
-		if p.Shots > maxShots {
-			msg := fmt.Sprintf("shots(%d) is over the limit(%d)",
-				p.Shots, maxShots)
-			zap.L().Info(msg + fmt.Sprintf("/jobID:%s", p.JobID))
-			return fmt.Errorf(msg)
-		}
-	}
+	// the validation specific to the job type is done in Validate of the job
 	container := GetSystemComponents().Container
 	err = container.Invoke(
 		func(t Transpiler) error {
@@ -350,6 +480,41 @@ func validateJobParam(p *JobParam) (err error) {
 	return
 }
 
+// ValidateShots checks the shots of the job against the device.
+func ValidateShots(jd *JobData) error {
+	return validateShots(jd, false)
+}
+
+// ValidateSplittableShots checks the shots of the job which can be split into several runs on the device.
+// The shots over the max shots of the device are accepted up to max_total_shots in [com.shots].
+func ValidateSplittableShots(jd *JobData) error {
+	return validateShots(jd, true)
+}
+
+func validateShots(jd *JobData, splittable bool) error {
+	if jd.Shots <= 0 {
+		msg := fmt.Sprintf("shots(%d) must be greater than 0", jd.Shots)
+		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", jd.ID))
+		return fmt.Errorf(msg)
+	}
+	di, err := GetSystemComponents().GetDeviceInfoOf(jd.DeviceID)
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("failed to get the device of the job/jobID:%s/reason:%s", jd.ID, err))
+		return err
+	}
+	maxShots := di.MaxShots
+	if total := GetShotsSetting().MaxTotalShots; splittable && total > maxShots {
+		maxShots = total
+	}
+	if jd.Shots > maxShots {
+		msg := fmt.Sprintf("shots(%d) is over the limit(%d)",
+			jd.Shots, maxShots)
+		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", jd.ID))
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
 func NewJobManager(jobs ...Job) (*JobManager, error) {
 	jm := &JobManager{}
 	for _, job := range jobs {
@@ -372,10 +537,51 @@ func SetFailureWithError(j Job, err error) (msg string) {
 	return SetFailureWithErrorToJobData(jd, err)
 }
 
+// SetFailureWithErrorToJobData fails the job with the message of the error for the user.
+// The detail of the error is only written to the log.
 func SetFailureWithErrorToJobData(jd *JobData, err error) (msg string) {
-	msg = err.Error()
+	kind := ErrorKindOf(err)
+	msg = UserMessage(err)
+	zap.L().Info(fmt.Sprintf("job(%s) failed/kind:%s/reason:%s", jd.ID, kind, err))
+	if err := jd.SetStatus(FAILED); err != nil {
+		// e.g. the job has been cancelled
+		return msg
+	}
 	jd.Result.Message = msg
-	jd.Status = FAILED
-	jd.Ended = strfmt.DateTime(time.Now())
+	jd.Result.ErrorKind = kind
 	return msg
 }
+
+// IsReofferedRefusal reports whether the job refused for the error is left in its status in the cloud,
+// to be fetched again instead of being failed.
+//   - ErrorQueueFull: the cloud offers the job again when the queue has a free slot.
+//   - ErrorDraining: the job is fetched by the next run or by another engine.
+//   - ErrorJobIDConflict: the job is the one being handled, whose status must not be overwritten.
+func IsReofferedRefusal(err error) bool {
+	return errors.Is(err, ErrorQueueFull) || errors.Is(err, ErrorDraining) || errors.Is(err, ErrorJobIDConflict)
+}
+
+// RejectJob fails the job refused by the scheduler, e.g. for a device which is not hosted, and reports it.
+// The refusals of IsReofferedRefusal are not reported, so that the job stays in the cloud as it is.
+func RejectJob(j Job, err error) {
+	jd := j.JobData()
+	if IsReofferedRefusal(err) {
+		zap.L().Info(fmt.Sprintf("not report the refused job(%s), which stays in %s/reason:%s", jd.ID, jd.Status, err))
+		return
+	}
+	SetFailureWithError(j, WithErrorKind(InternalError, err))
+	jd.UseJobInfoUpdate = true
+	j.JobContext().Publish(JobFailed, j)
+	CountFailure(jd)
+}
+
+func SetCancelled(j Job) {
+	SetCancelledToJobData(j.JobData())
+}
+
+func SetCancelledToJobData(jd *JobData) {
+	if err := jd.SetStatus(CANCELLED); err != nil {
+		return
+	}
+	jd.Result.Message = ErrorJobCancelled.Error()
+}
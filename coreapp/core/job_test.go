@@ -202,3 +202,38 @@ func TestCloneNormalJob(t *testing.T) {
 	cloned.JobData().Status = SUCCEEDED
 	assert.NotEqual(t, cloned.JobData().Status, org.JobData().Status)
 }
+
+func TestRejectJob(t *testing.T) {
+	s := SCWithDBContainer()
+	defer s.TearDown()
+	sub := s.EventBus.Subscribe("test", DEFAULT_EVENT_BUFFER_SIZE, Coalesce)
+
+	newReadyJob := func(id string) Job {
+		jc, err := NewJobContext()
+		assert.Nil(t, err)
+		jd := NewJobData()
+		jd.ID = id
+		jd.Status = READY
+		return (&NormalJob{}).New(jd, jc)
+	}
+	// the job stays in the cloud to be offered again
+	for _, err := range []error{
+		NewDeviceUnavailableError("the queue of the device is full", ErrorQueueFull),
+		ErrorDraining,
+		fmt.Errorf("%w: job(conflict) is already active", ErrorJobIDConflict),
+	} {
+		j := newReadyJob("refused")
+		RejectJob(j, err)
+		assert.Equal(t, READY, j.JobData().Status)
+	}
+
+	j := newReadyJob("failed")
+	RejectJob(j, NewUserInputError("the device device2 is not hosted", fmt.Errorf("unknown device")))
+	assert.Equal(t, FAILED, j.JobData().Status)
+	assert.Equal(t, UserInputError, j.JobData().Result.ErrorKind)
+	assert.Equal(t, "the device device2 is not hosted", j.JobData().Result.Message)
+	// only the failed job is reported
+	e := <-sub.Events()
+	assert.Equal(t, JobFailed, e.Type)
+	assert.Equal(t, "failed", e.Job.JobData().ID)
+}
@@ -1,7 +1,10 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"time"
 
 	"go.uber.org/dig"
 )
@@ -22,20 +25,24 @@ func (j *UnimplementedJob) New(jd *JobData, jc *JobContext) Job {
 	}
 }
 
-func (j *UnimplementedJob) PreProcess() {
+func (j *UnimplementedJob) Validate() error {
+	return nil
+}
+
+func (j *UnimplementedJob) PreProcess(ctx context.Context) {
 	return
 }
 
-func (j *UnimplementedJob) Process() {
+func (j *UnimplementedJob) Process(ctx context.Context) {
 	return
 }
 
-func (j *UnimplementedJob) PostProcess() {
+func (j *UnimplementedJob) PostProcess(ctx context.Context) {
 	return
 }
 
 func (j *UnimplementedJob) IsFinished() bool {
-	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED
+	return j.JobData().Status == SUCCEEDED || j.JobData().Status == FAILED || j.JobData().Status == CANCELLED
 }
 
 func (j *UnimplementedJob) JobData() *JobData {
@@ -64,7 +71,7 @@ func (u *UnimplementedQPU) Setup(*Conf) error {
 	return nil
 }
 
-func (u *UnimplementedQPU) Send(Job) error {
+func (u *UnimplementedQPU) Send(context.Context, Job) error {
 	return nil
 }
 
@@ -115,7 +122,7 @@ type successQPUForTest struct {
 	UnimplementedQPU
 }
 
-func (successQPUForTest) Send(j Job) error {
+func (successQPUForTest) Send(ctx context.Context, j Job) error {
 	// TODO: fix this SRP violation
 	j.JobData().Status = SUCCEEDED
 	return nil
@@ -123,7 +130,7 @@ func (successQPUForTest) Send(j Job) error {
 
 type unimplementedDB struct{}
 
-func (u *unimplementedDB) Setup(DBChan, *Conf) error {
+func (u *unimplementedDB) Setup(*EventBus, *Conf) error {
 	return nil
 }
 func (u *unimplementedDB) Insert(Job) error { return nil }
@@ -160,18 +167,23 @@ func (successTranspilerForTest) IsAcceptableTranspilerLib(string) bool {
 	return true
 }
 
-func (successTranspilerForTest) Setup(*Conf) error   { return nil }
-func (successTranspilerForTest) GetHealth() error    { return nil }
-func (successTranspilerForTest) Transpile(Job) error { return nil }
-func (successTranspilerForTest) TearDown()           {}
+func (successTranspilerForTest) Setup(*Conf) error                    { return nil }
+func (successTranspilerForTest) GetHealth() error                     { return nil }
+func (successTranspilerForTest) Transpile(context.Context, Job) error { return nil }
+func (successTranspilerForTest) TearDown()                            {}
 
 type unimplementedScheduler struct{}
 
-func (u *unimplementedScheduler) Setup(*Conf) error           { return nil }
-func (u *unimplementedScheduler) Start() error                { return nil }
-func (u *unimplementedScheduler) HandleJob(_ Job)             { return }
-func (u *unimplementedScheduler) GetCurrentQueueSize() int    { return 0 }
-func (u *unimplementedScheduler) IsOverRefillThreshold() bool { return false }
+func (u *unimplementedScheduler) Setup(*Conf) error             { return nil }
+func (u *unimplementedScheduler) Start() error                  { return nil }
+func (u *unimplementedScheduler) HandleJob(_ Job) error         { return nil }
+func (u *unimplementedScheduler) CancelJob(_ string) error      { return nil }
+func (u *unimplementedScheduler) GetCurrentQueueSize() int      { return 0 }
+func (u *unimplementedScheduler) GetQueueFreeSlots() int        { return math.MaxInt }
+func (u *unimplementedScheduler) IsOverRefillThreshold() bool   { return false }
+func (u *unimplementedScheduler) ListJobs() []ScheduledJob      { return nil }
+func (u *unimplementedScheduler) Drain(context.Context) error   { return nil }
+func (u *unimplementedScheduler) ExpireJobs(time.Time) []string { return nil }
 
 type unimplementedSSEGatewayRouter struct{}
 
@@ -233,3 +245,27 @@ func SCWithScheduler(sc Scheduler) *SystemComponents {
 	s.Setup(&Conf{QueueMaxSize: 1000})
 	return s
 }
+
+func SCWithSchedulerAndDB(sc Scheduler, d DBManager, conf *Conf) *SystemComponents {
+	c := dig.New()
+	c.Provide(func() QPUManager { return &successQPUForTest{} })
+	c.Provide(func() DBManager { return d })
+	c.Provide(func() Transpiler { return &successTranspilerForTest{} })
+	c.Provide(func() Scheduler { return sc })
+	c.Provide(func() SSEGatewayRouter { return &unimplementedSSEGatewayRouter{} })
+	s := NewSystemComponents(c)
+	s.Setup(conf)
+	return s
+}
+
+func SCWithQPU(q QPUManager) *SystemComponents {
+	c := dig.New()
+	c.Provide(func() QPUManager { return q })
+	c.Provide(func() DBManager { return &MemoryDB{} })
+	c.Provide(func() Transpiler { return &successTranspilerForTest{} })
+	c.Provide(func() Scheduler { return &unimplementedScheduler{} })
+	c.Provide(func() SSEGatewayRouter { return &unimplementedSSEGatewayRouter{} })
+	s := NewSystemComponents(c)
+	s.Setup(&Conf{})
+	return s
+}
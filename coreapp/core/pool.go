@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const WORKER_POOL_SETTING_KEY = "worker_pool"
+
+const (
+	PreProcessPool  = "pre_process"
+	PostProcessPool = "post_process"
+)
+
+// DEFAULT_WORKER_POOL_SIZE is the number of the jobs pre-processed or post-processed at the same time
+const DEFAULT_WORKER_POOL_SIZE = 16
+
+// WorkerPoolSetting is the number of the workers of each stage and the concurrency limit of each
+// downstream service in [com.worker_pool], e.g. pre_process = 8 and [com.worker_pool.services] with
+// transpiler = 4. The service is the target of Retry, e.g. transpiler, mitigator or estimator.
+// Zero or a negative number means no limit.
+type WorkerPoolSetting struct {
+	PreProcess  int            `toml:"pre_process"`
+	PostProcess int            `toml:"post_process"`
+	Services    map[string]int `toml:"services"`
+}
+
+func NewWorkerPoolSetting() WorkerPoolSetting {
+	return WorkerPoolSetting{
+		PreProcess:  DEFAULT_WORKER_POOL_SIZE,
+		PostProcess: DEFAULT_WORKER_POOL_SIZE,
+		Services:    map[string]int{},
+	}
+}
+
+func GetWorkerPoolSetting() WorkerPoolSetting {
+	return GetSetting(WORKER_POOL_SETTING_KEY, NewWorkerPoolSetting())
+}
+
+// WorkerPool bounds the number of the jobs running in a stage or calling a service at the same time.
+// The jobs over the size wait for a free worker. A nil WorkerPool has no bound.
+type WorkerPool struct {
+	name    string
+	workers chan struct{}
+	waiting atomic.Int64
+	// the number of the jobs which have found no free worker
+	saturated atomic.Uint64
+	waited    atomic.Int64
+}
+
+// WorkerPoolStats is the usage of a worker pool for the metrics.
+type WorkerPoolStats struct {
+	Size      int     `json:"size"`
+	Busy      int     `json:"busy"`
+	Waiting   int64   `json:"waiting"`
+	Saturated uint64  `json:"saturated"`
+	WaitedSec float64 `json:"waited_sec"`
+}
+
+var workerPools = struct {
+	sync.Mutex
+	m map[string]*WorkerPool
+}{m: map[string]*WorkerPool{}}
+
+// GetWorkerPool returns the worker pool of the name, which is shared in the engine.
+// The pool is made with the size at the first call. Zero or a negative size means no bound.
+func GetWorkerPool(name string, size int) *WorkerPool {
+	if size <= 0 {
+		return nil
+	}
+	workerPools.Lock()
+	defer workerPools.Unlock()
+	if p, ok := workerPools.m[name]; ok {
+		return p
+	}
+	zap.L().Info(fmt.Sprintf("making worker pool %s with %d workers", name, size))
+	p := &WorkerPool{
+		name:    name,
+		workers: make(chan struct{}, size),
+	}
+	workerPools.m[name] = p
+	return p
+}
+
+// StagePool returns the worker pool of the stage, PreProcessPool or PostProcessPool.
+func StagePool(stage string) *WorkerPool {
+	s := GetWorkerPoolSetting()
+	size := 0
+	switch stage {
+	case PreProcessPool:
+		size = s.PreProcess
+	case PostProcessPool:
+		size = s.PostProcess
+	}
+	return GetWorkerPool(stage, size)
+}
+
+// ServicePool returns the worker pool limiting the calls to the downstream service.
+func ServicePool(service string) *WorkerPool {
+	return GetWorkerPool("service/"+service, GetWorkerPoolSetting().Services[service])
+}
+
+// Acquire waits for a free worker until ctx is done. The returned function frees the worker.
+func (p *WorkerPool) Acquire(ctx context.Context) (func(), error) {
+	if p == nil {
+		return func() {}, nil
+	}
+	release := func() { <-p.workers }
+	select {
+	case p.workers <- struct{}{}:
+		return release, nil
+	default:
+	}
+	p.saturated.Add(1)
+	p.waiting.Add(1)
+	defer p.waiting.Add(-1)
+	started := time.Now()
+	defer func() { p.waited.Add(int64(time.Since(started))) }()
+	zap.L().Debug(fmt.Sprintf("waiting for a free worker of %s", p.name))
+	select {
+	case p.workers <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (p *WorkerPool) Stats() WorkerPoolStats {
+	return WorkerPoolStats{
+		Size:      cap(p.workers),
+		Busy:      len(p.workers),
+		Waiting:   p.waiting.Load(),
+		Saturated: p.saturated.Load(),
+		WaitedSec: time.Duration(p.waited.Load()).Seconds(),
+	}
+}
+
+// WorkerPoolStatsOfAll returns the usage of the worker pools labeled by their names.
+func WorkerPoolStatsOfAll() map[string]WorkerPoolStats {
+	workerPools.Lock()
+	defer workerPools.Unlock()
+	stats := make(map[string]WorkerPoolStats, len(workerPools.m))
+	for name, p := range workerPools.m {
+		stats[name] = p.Stats()
+	}
+	return stats
+}
+
+// ResetWorkerPools discards the worker pools, to be made again with the current setting.
+func ResetWorkerPools() {
+	workerPools.Lock()
+	defer workerPools.Unlock()
+	workerPools.m = map[string]*WorkerPool{}
+}
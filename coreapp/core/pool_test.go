@@ -0,0 +1,77 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolSetting(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	ResetWorkerPools()
+	defer ResetWorkerPools()
+	RegisterSetting(WORKER_POOL_SETTING_KEY, NewWorkerPoolSetting())
+	assert.Equal(t, DEFAULT_WORKER_POOL_SIZE, StagePool(PreProcessPool).Stats().Size)
+	assert.Nil(t, ServicePool("transpiler"))
+
+	ResetWorkerPools()
+	err := globalSetting.parseSetting(`
+[com.worker_pool]
+pre_process = 2
+post_process = 0
+  [com.worker_pool.services]
+  transpiler = 4
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, StagePool(PreProcessPool).Stats().Size)
+	assert.Nil(t, StagePool(PostProcessPool))
+	assert.Equal(t, 4, ServicePool("transpiler").Stats().Size)
+	assert.Nil(t, ServicePool("mitigator"))
+}
+
+func TestWorkerPool(t *testing.T) {
+	ResetWorkerPools()
+	defer ResetWorkerPools()
+	p := GetWorkerPool("test", 1)
+	assert.Same(t, p, GetWorkerPool("test", 2))
+
+	release, err := p.Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, WorkerPoolStats{Size: 1, Busy: 1}, p.Stats())
+
+	// no free worker until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = p.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan func())
+	go func() {
+		r, _ := p.Acquire(context.Background())
+		acquired <- r
+	}()
+	assert.Eventually(t, func() bool {
+		return p.Stats().Waiting == 1
+	}, time.Second, 10*time.Millisecond)
+	release()
+	(<-acquired)()
+
+	stats := p.Stats()
+	assert.Equal(t, 0, stats.Busy)
+	assert.Equal(t, int64(0), stats.Waiting)
+	assert.Equal(t, uint64(2), stats.Saturated)
+	assert.Greater(t, stats.WaitedSec, 0.0)
+	assert.Contains(t, WorkerPoolStatsOfAll(), "test")
+
+	// no bound
+	var unbounded *WorkerPool
+	release, err = unbounded.Acquire(context.Background())
+	assert.Nil(t, err)
+	release()
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const RESERVATION_SETTING_KEY = "reservations"
+
+var ErrorReservationConflict = errors.New("reservation overlaps another reservation")
+var ErrorReservationNotFound = errors.New("reservation not found")
+
+// Reservation is a time window in which the device is reserved, e.g. for the calibration or
+// a dedicated experiment. No job is processed in the window, except the jobs of the project
+// of the window when the project is set. The project is compared with the owner of the job.
+// The window reserves every device of the engine when the device is not set.
+type Reservation struct {
+	ID       string    `json:"id" toml:"id"`
+	DeviceID string    `json:"device_id,omitempty" toml:"device_id"`
+	Start    time.Time `json:"start" toml:"start"`
+	End      time.Time `json:"end" toml:"end"`
+	Project  string    `json:"project,omitempty" toml:"project"`
+	Reason   string    `json:"reason,omitempty" toml:"reason"`
+}
+
+// ReservationSetting is the reservations in [[com.reservations.windows]].
+type ReservationSetting struct {
+	Windows []Reservation `toml:"windows"`
+}
+
+func NewReservationSetting() ReservationSetting {
+	return ReservationSetting{
+		Windows: []Reservation{},
+	}
+}
+
+func (r Reservation) Validate() error {
+	if r.Start.IsZero() || r.End.IsZero() {
+		return fmt.Errorf("reservation(%s) has no start or end", r.ID)
+	}
+	if !r.End.After(r.Start) {
+		return fmt.Errorf("reservation(%s) ends at %s before its start %s", r.ID, r.End, r.Start)
+	}
+	return nil
+}
+
+func (r Reservation) contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// reserves reports whether the window reserves the device. The empty device is the only device of
+// the engine, which every window reserves.
+func (r Reservation) reserves(deviceID string) bool {
+	return r.DeviceID == "" || deviceID == "" || r.DeviceID == deviceID
+}
+
+// Allows reports whether the job of the owner can be processed in the window.
+func (r Reservation) Allows(owner string) bool {
+	return r.Project != "" && r.Project == owner
+}
+
+// ReservationCalendar holds the reservations of the device, which do not overlap each other.
+type ReservationCalendar struct {
+	mu           sync.RWMutex
+	reservations []Reservation
+	// changed is closed and renewed when the reservations are changed
+	changed chan struct{}
+}
+
+var reservations = NewReservationCalendar()
+
+func NewReservationCalendar() *ReservationCalendar {
+	return &ReservationCalendar{
+		reservations: []Reservation{},
+		changed:      make(chan struct{}),
+	}
+}
+
+func GetReservations() *ReservationCalendar {
+	return reservations
+}
+
+// LoadReservations replaces the reservations with the reservations in the setting.
+func LoadReservations() error {
+	setting, err := LookupSetting(RESERVATION_SETTING_KEY, NewReservationSetting())
+	if err != nil {
+		return err
+	}
+	rs := setting.Windows
+	c := NewReservationCalendar()
+	for _, r := range rs {
+		if _, err := c.Add(r); err != nil {
+			return err
+		}
+	}
+	reservations.replace(c.reservations)
+	zap.L().Info(fmt.Sprintf("loaded %d reservations", len(rs)))
+	return nil
+}
+
+func (c *ReservationCalendar) replace(rs []Reservation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reservations = rs
+	c.notify()
+}
+
+// notify must be called with the lock
+func (c *ReservationCalendar) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Changed returns the channel closed at the next change of the reservations.
+func (c *ReservationCalendar) Changed() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.changed
+}
+
+// Add adds the reservation with a new ID when the ID is empty. The windows already ended are discarded.
+func (c *ReservationCalendar) Add(r Reservation) (Reservation, error) {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	if err := r.Validate(); err != nil {
+		return r, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	rs := []Reservation{}
+	for _, old := range c.reservations {
+		if old.ID == r.ID {
+			return r, fmt.Errorf("reservation(%s) already exists", r.ID)
+		}
+		if old.Start.Before(r.End) && r.Start.Before(old.End) && old.reserves(r.DeviceID) {
+			return r, fmt.Errorf("%w: %s", ErrorReservationConflict, old.ID)
+		}
+		if old.End.After(now) {
+			rs = append(rs, old)
+		}
+	}
+	rs = append(rs, r)
+	sort.Slice(rs, func(a, b int) bool {
+		return rs[a].Start.Before(rs[b].Start)
+	})
+	c.reservations = rs
+	c.notify()
+	zap.L().Info(fmt.Sprintf("reserved the device from %s to %s/id:%s/device:%s/project:%s/reason:%s",
+		r.Start, r.End, r.ID, r.DeviceID, r.Project, r.Reason))
+	return r, nil
+}
+
+func (c *ReservationCalendar) Delete(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, r := range c.reservations {
+		if r.ID == id {
+			c.reservations = append(c.reservations[:i:i], c.reservations[i+1:]...)
+			c.notify()
+			zap.L().Info(fmt.Sprintf("deleted reservation(%s)", id))
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrorReservationNotFound, id)
+}
+
+// List returns the reservations in the order of their start.
+func (c *ReservationCalendar) List() []Reservation {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	rs := make([]Reservation, len(c.reservations))
+	copy(rs, c.reservations)
+	return rs
+}
+
+// Active returns the reservation of the device whose window contains t.
+func (c *ReservationCalendar) Active(t time.Time, deviceID string) (Reservation, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, r := range c.reservations {
+		if r.reserves(deviceID) && r.contains(t) {
+			return r, true
+		}
+	}
+	return Reservation{}, false
+}
+
+// Next returns the first reservation of the device starting after t.
+func (c *ReservationCalendar) Next(t time.Time, deviceID string) (Reservation, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, r := range c.reservations {
+		if r.reserves(deviceID) && r.Start.After(t) {
+			return r, true
+		}
+	}
+	return Reservation{}, false
+}
+
+// AvailableAt returns the first time from t when the job of the owner can be processed on the device,
+// skipping the consecutive windows not allowing the owner.
+func (c *ReservationCalendar) AvailableAt(t time.Time, deviceID string, owner string) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, r := range c.reservations {
+		if r.reserves(deviceID) && r.contains(t) && !r.Allows(owner) {
+			t = r.End
+		}
+	}
+	return t
+}
+
+// ApplyReservation makes the device unavailable during a reservation of the device, with the end of
+// the reservation as the time when the device is available again.
+func ApplyReservation(di *DeviceInfo, now time.Time) *DeviceInfo {
+	at := GetReservations().AvailableAt(now, di.DeviceName, "")
+	if !at.After(now) {
+		return di
+	}
+	di.Status = Unavailable
+	di.AvailableAt = &at
+	return di
+}
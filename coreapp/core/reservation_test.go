@@ -0,0 +1,113 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationCalendar(t *testing.T) {
+	c := NewReservationCalendar()
+	now := time.Now()
+	calibration, err := c.Add(Reservation{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, calibration.ID)
+	experiment, err := c.Add(Reservation{
+		ID: "experiment", Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Project: "project_a"})
+	assert.Nil(t, err)
+
+	_, err = c.Add(Reservation{Start: now.Add(90 * time.Minute), End: now.Add(4 * time.Hour)})
+	assert.ErrorIs(t, err, ErrorReservationConflict)
+	_, err = c.Add(Reservation{Start: now.Add(5 * time.Hour), End: now.Add(4 * time.Hour)})
+	assert.NotNil(t, err)
+
+	_, ok := c.Active(now, "")
+	assert.False(t, ok)
+	next, ok := c.Next(now, "")
+	assert.True(t, ok)
+	assert.Equal(t, calibration.ID, next.ID)
+	active, ok := c.Active(now.Add(150*time.Minute), "")
+	assert.True(t, ok)
+	assert.Equal(t, "experiment", active.ID)
+
+	// the consecutive windows are skipped unless the window allows the owner
+	assert.Equal(t, now, c.AvailableAt(now, "", ""))
+	assert.Equal(t, experiment.End, c.AvailableAt(now.Add(time.Hour), "", ""))
+	assert.Equal(t, calibration.End, c.AvailableAt(now.Add(time.Hour), "", "project_a"))
+
+	// the window of a device reserves neither the other devices nor their windows
+	device1, err := c.Add(Reservation{DeviceID: "device1", Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour)})
+	assert.Nil(t, err)
+	_, err = c.Add(Reservation{DeviceID: "device2", Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour)})
+	assert.Nil(t, err)
+	_, err = c.Add(Reservation{DeviceID: "device1", Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour)})
+	assert.ErrorIs(t, err, ErrorReservationConflict)
+	_, err = c.Add(Reservation{Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour)})
+	assert.ErrorIs(t, err, ErrorReservationConflict)
+	active, ok = c.Active(now.Add(5*time.Hour), "device1")
+	assert.True(t, ok)
+	assert.Equal(t, device1.ID, active.ID)
+	_, ok = c.Active(now.Add(5*time.Hour), "device3")
+	assert.False(t, ok)
+	next, ok = c.Next(now.Add(3*time.Hour), "device3")
+	assert.False(t, ok)
+	// the windows without the device reserve every device
+	assert.Equal(t, experiment.End, c.AvailableAt(now.Add(time.Hour), "device3", ""))
+	assert.Equal(t, device1.End, c.AvailableAt(now.Add(5*time.Hour), "device1", ""))
+	assert.Equal(t, now.Add(5*time.Hour), c.AvailableAt(now.Add(5*time.Hour), "device3", ""))
+
+	changed := c.Changed()
+	assert.Nil(t, c.Delete(calibration.ID))
+	assert.ErrorIs(t, c.Delete(calibration.ID), ErrorReservationNotFound)
+	<-changed
+	assert.Len(t, c.List(), 3)
+}
+
+func TestLoadReservations(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	defer GetReservations().replace([]Reservation{})
+	RegisterSetting(RESERVATION_SETTING_KEY, NewReservationSetting())
+
+	err := globalSetting.parseSetting(`
+[com.reservations]
+  [[com.reservations.windows]]
+  id = "calibration"
+  start = 2099-01-05T09:00:00+09:00
+  end = 2099-01-05T12:00:00+09:00
+  [[com.reservations.windows]]
+  start = "2099-01-06T13:00:00+09:00"
+  end = "2099-01-06T17:00:00+09:00"
+  project = "project_a"
+  [[com.reservations.windows]]
+  device_id = "device2"
+  start = "2099-01-07T13:00:00+09:00"
+  end = "2099-01-07T17:00:00+09:00"
+`)
+	assert.Nil(t, err)
+	assert.Nil(t, LoadReservations())
+	rs := GetReservations().List()
+	assert.Len(t, rs, 3)
+	assert.Equal(t, "calibration", rs[0].ID)
+	assert.Equal(t, 3*time.Hour, rs[0].End.Sub(rs[0].Start))
+	assert.Equal(t, "project_a", rs[1].Project)
+
+	di := ApplyReservation(&DeviceInfo{Status: Available}, rs[0].Start)
+	assert.Equal(t, Unavailable, di.Status)
+	assert.Equal(t, rs[0].End, *di.AvailableAt)
+	di = ApplyReservation(&DeviceInfo{Status: Available}, rs[0].End)
+	assert.Equal(t, Available, di.Status)
+	assert.Nil(t, di.AvailableAt)
+
+	// the window of a device makes only the device unavailable
+	assert.Equal(t, "device2", rs[2].DeviceID)
+	di = ApplyReservation(&DeviceInfo{DeviceName: "device1", Status: Available}, rs[2].Start)
+	assert.Equal(t, Available, di.Status)
+	di = ApplyReservation(&DeviceInfo{DeviceName: "device2", Status: Available}, rs[2].Start)
+	assert.Equal(t, Unavailable, di.Status)
+	assert.Equal(t, rs[2].End, *di.AvailableAt)
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const RETRY_SETTING_KEY = "retry"
+
+// RetryPolicy is the policy to retry the calls to the QPU and the services for a job type.
+// The policy of a job type is set in [com.retry.<job type>], and [com.retry.default]
+// is used for the job types not listed.
+type RetryPolicy struct {
+	MaxAttempts    int          `toml:"max_attempts"`
+	InitialBackoff string       `toml:"initial_backoff"`
+	MaxBackoff     string       `toml:"max_backoff"`
+	Multiplier     float64      `toml:"multiplier"`
+	RetryableCodes []codes.Code `toml:"-"`
+}
+
+// retryPolicyInFile is a policy in the setting file, which has the names of the gRPC codes.
+type retryPolicyInFile struct {
+	RetryPolicy
+	RetryableCodes []string `toml:"retryable_codes"`
+}
+
+type RetrySetting struct {
+	Default RetryPolicy `toml:"default"`
+}
+
+// NewRetryPolicy returns the policy not to retry.
+func NewRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: "1s",
+		MaxBackoff:     "30s",
+		Multiplier:     2.0,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+}
+
+func NewRetrySetting() RetrySetting {
+	return RetrySetting{
+		Default: NewRetryPolicy(),
+	}
+}
+
+func GetRetryPolicy(jobType string) RetryPolicy {
+	s, ok := GetComponentSetting(RETRY_SETTING_KEY)
+	if !ok {
+		return NewRetryPolicy()
+	}
+	switch setting := s.(type) {
+	case map[string]interface{}:
+		p := NewRetryPolicy()
+		for _, key := range []string{"default", jobType} {
+			if mapped, ok := setting[key].(map[string]interface{}); ok {
+				p = p.overwrite(mapped)
+			}
+		}
+		return p
+	case RetrySetting:
+		return setting.Default
+	default:
+		zap.L().Error(fmt.Sprintf("unexpected type of retry setting:%T", s))
+		return NewRetryPolicy()
+	}
+}
+
+// overwrite returns the policy overwritten by the keys in the table of the setting file.
+func (p RetryPolicy) overwrite(mapped map[string]interface{}) RetryPolicy {
+	f := retryPolicyInFile{RetryPolicy: p}
+	if err := DecodeSetting(mapped, &f); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to decode retry setting/reason:%s", err))
+		return p
+	}
+	if f.RetryableCodes != nil {
+		cs := []codes.Code{}
+		for _, name := range f.RetryableCodes {
+			var c codes.Code
+			// e.g. "UNAVAILABLE"
+			if err := c.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+				zap.L().Error(fmt.Sprintf("unknown gRPC code:%v/reason:%s", name, err))
+				continue
+			}
+			cs = append(cs, c)
+		}
+		f.RetryPolicy.RetryableCodes = cs
+	}
+	return f.RetryPolicy
+}
+
+// IsRetryable reports whether the error has one of the retryable gRPC codes.
+func (p RetryPolicy) IsRetryable(err error) bool {
+	c := status.Code(err)
+	for _, rc := range p.RetryableCodes {
+		if c == rc {
+			return true
+		}
+	}
+	return false
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial, err := time.ParseDuration(p.InitialBackoff)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to parse initial backoff:%s/reason:%s", p.InitialBackoff, err))
+		initial = time.Second
+	}
+	b := float64(initial)
+	for i := 1; i < attempt; i++ {
+		b *= p.Multiplier
+	}
+	if maxBackoff, err := time.ParseDuration(p.MaxBackoff); err == nil && b > float64(maxBackoff) {
+		return maxBackoff
+	}
+	return time.Duration(b)
+}
+
+// Retry calls f until it succeeds or the retry policy of the job type gives up.
+// The failed attempts are recorded in the result message of the job with the messages for the user,
+// and the status of the job changed by a failed attempt is restored before the next attempt.
+// The error returned after the retries keeps the kind of the last error.
+// Each attempt waits for a free worker of the service pool of the target.
+func Retry(ctx context.Context, jd *JobData, target string, f func(context.Context) error) error {
+	p := GetRetryPolicy(jd.JobType)
+	st := jd.Status
+	historyLen := len(jd.StatusHistory)
+	records := []string{}
+	var err error
+	pool := ServicePool(target)
+	for attempt := 1; ; attempt++ {
+		var release func()
+		if release, err = pool.Acquire(ctx); err == nil {
+			err = f(ctx)
+			release()
+		}
+		if err == nil {
+			break
+		}
+		records = append(records, fmt.Sprintf("%s attempt %d/%d failed: %s", target, attempt, p.MaxAttempts, UserMessage(err)))
+		if attempt >= p.MaxAttempts || !p.IsRetryable(err) {
+			break
+		}
+		b := p.backoff(attempt)
+		zap.L().Info(fmt.Sprintf("retrying %s for job(%s) in %s/reason:%s", target, jd.ID, b, err))
+		select {
+		case <-time.After(b):
+		case <-ctx.Done():
+			records = append(records, fmt.Sprintf("%s retry aborted: %s", target, UserMessage(context.Cause(ctx))))
+			err = context.Cause(ctx)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		jd.revertStatus(st, historyLen)
+	}
+	if len(records) <= 1 && err != nil {
+		// not retried
+		return err
+	}
+	record := strings.Join(records, "; ")
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("gave up %s for job(%s)/reason:%s", target, jd.ID, err))
+		err = &JobError{
+			Kind:    ErrorKindOf(err),
+			Message: fmt.Sprintf("%s (%s)", UserMessage(err), record),
+			Err:     err,
+		}
+		jd.Result.Message = UserMessage(err)
+		return err
+	}
+	if len(records) > 0 {
+		if jd.Result.Message == "" {
+			jd.Result.Message = record
+		} else {
+			jd.Result.Message = fmt.Sprintf("%s (%s)", jd.Result.Message, record)
+		}
+	}
+	return nil
+}
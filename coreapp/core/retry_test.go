@@ -0,0 +1,109 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRetryPolicy(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(RETRY_SETTING_KEY, NewRetrySetting())
+	assert.Equal(t, NewRetryPolicy(), GetRetryPolicy("sampling"))
+
+	err := globalSetting.parseSetting(`
+[com.retry]
+  [com.retry.default]
+  max_attempts = 3
+  initial_backoff = "10ms"
+  retryable_codes = ["UNAVAILABLE", "DEADLINE_EXCEEDED"]
+  [com.retry.sse]
+  max_attempts = 1
+`)
+	assert.Nil(t, err)
+	p := GetRetryPolicy("sampling")
+	assert.Equal(t, 3, p.MaxAttempts)
+	assert.Equal(t, "10ms", p.InitialBackoff)
+	assert.Equal(t, []codes.Code{codes.Unavailable, codes.DeadlineExceeded}, p.RetryableCodes)
+	assert.Equal(t, 1, GetRetryPolicy("sse").MaxAttempts)
+	assert.Equal(t, 40*time.Millisecond, p.backoff(3))
+}
+
+func TestRetry(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(RETRY_SETTING_KEY, map[string]interface{}{
+		"default": map[string]interface{}{
+			"max_attempts":    int64(3),
+			"initial_backoff": "1ms",
+		},
+	})
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      bool
+		wantAttempts int
+		wantStatus   Status
+	}{
+		{
+			name:         "success at first",
+			errs:         []error{nil},
+			wantAttempts: 1,
+			wantStatus:   SUCCEEDED,
+		},
+		{
+			name:         "success after retries",
+			errs:         []error{unavailable, unavailable, nil},
+			wantAttempts: 3,
+			wantStatus:   SUCCEEDED,
+		},
+		{
+			name:         "give up after max attempts",
+			errs:         []error{unavailable, unavailable, unavailable},
+			wantErr:      true,
+			wantAttempts: 3,
+			wantStatus:   FAILED,
+		},
+		{
+			name:         "not retryable",
+			errs:         []error{fmt.Errorf("invalid")},
+			wantErr:      true,
+			wantAttempts: 1,
+			wantStatus:   FAILED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := NewJobData()
+			jd.Status = RUNNING
+			attempts := 0
+			err := Retry(context.Background(), jd, "qpu", func(context.Context) error {
+				err := tt.errs[attempts]
+				attempts++
+				if err != nil {
+					SetFailureWithErrorToJobData(jd, err)
+					return err
+				}
+				jd.Status = SUCCEEDED
+				return nil
+			})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Equal(t, tt.wantStatus, jd.Status)
+			if tt.wantAttempts > 1 {
+				assert.Contains(t, jd.Result.Message, "qpu attempt 2/3 failed")
+			}
+		})
+	}
+}
@@ -391,6 +391,30 @@ func (rc *RunContext) AddPeriodicTask(t *PeriodicTask, taskName string) error {
 	return nil
 }
 
+// AddSystemComponents drains the system components when the run group is interrupted, e.g. by SIGTERM,
+// so that the jobs in flight are finished and written to the DB before the exit.
+// The goroutines of the scheduler and the QPU are stopped after the drain.
+func (rc *RunContext) AddSystemComponents(s *SystemComponents, drainTimeout time.Duration) error {
+	ctx, cancel := context.WithCancel(rc.Context)
+	rc.Group.Add(
+		func() error {
+			<-ctx.Done()
+			zap.L().Info(fmt.Sprintf("[SystemComponents/TearDown]draining system components in %s", drainTimeout))
+			err := s.Drain(drainTimeout)
+			s.Stop()
+			if err != nil {
+				return err
+			}
+			zap.L().Info("[SystemComponents/TearDown]drained system components")
+			return nil
+		},
+		func(error) {
+			cancel()
+		},
+	)
+	return nil
+}
+
 type InternalJobServer struct {
 	Params interface{} `toml:"params,omitempty"`
 	InternalJobServerImpl
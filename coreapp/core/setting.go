@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/BurntSushi/toml"
@@ -62,6 +63,50 @@ func GetComponentSetting(name string) (interface{}, bool) {
 	return val, ok
 }
 
+// GetSetting returns the component setting of the key. The setting registered as T is returned as it is,
+// and the setting parsed from the setting file is decoded over def, so that the keys not in the file
+// keep the values of def. def is returned when the setting is not found or cannot be decoded.
+func GetSetting[T any](key string, def T) T {
+	v, err := LookupSetting(key, def)
+	if err != nil {
+		zap.L().Error(err.Error())
+		return def
+	}
+	return v
+}
+
+// LookupSetting is GetSetting returning the error of the setting which cannot be decoded.
+func LookupSetting[T any](key string, def T) (T, error) {
+	s, ok := GetComponentSetting(key)
+	if !ok {
+		return def, nil
+	}
+	switch setting := s.(type) {
+	case T:
+		return setting, nil
+	case map[string]interface{}:
+		v := def
+		if err := DecodeSetting(setting, &v); err != nil {
+			return def, fmt.Errorf("failed to decode %s setting/reason:%w", key, err)
+		}
+		return v, nil
+	default:
+		return def, fmt.Errorf("unexpected type of %s setting:%T", key, s)
+	}
+}
+
+// DecodeSetting decodes a table of the setting file into v by the toml tags of v.
+// The table is decoded in the same way as the setting file, e.g. an integer into a float field
+// and a duration string into a time.Duration field. A value of a wrong type is an error.
+func DecodeSetting(mapped map[string]interface{}, v interface{}) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(mapped); err != nil {
+		return err
+	}
+	_, err := toml.Decode(buf.String(), v)
+	return err
+}
+
 func newSetting() *Setting {
 	return &Setting{
 		ComponentSetting: make(map[string]interface{}),
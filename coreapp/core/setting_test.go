@@ -49,6 +49,45 @@ func TestParseSettings(t *testing.T) {
 	}
 }
 
+func TestGetSetting(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	def := WorkerPoolSetting{PreProcess: 16, PostProcess: 16, Services: map[string]int{}}
+	// the default without the setting
+	assert.Equal(t, def, GetSetting(WORKER_POOL_SETTING_KEY, def))
+
+	RegisterSetting(WORKER_POOL_SETTING_KEY, WorkerPoolSetting{PreProcess: 1})
+	assert.Equal(t, WorkerPoolSetting{PreProcess: 1}, GetSetting(WORKER_POOL_SETTING_KEY, def))
+
+	err := globalSetting.parseSetting(`
+[com.worker_pool]
+pre_process = 8
+  [com.worker_pool.services]
+  transpiler = 4
+[com.fair_share]
+default_weight = 2
+`)
+	assert.Nil(t, err)
+	// the keys not in the file keep the default
+	assert.Equal(t, WorkerPoolSetting{PreProcess: 8, PostProcess: 16, Services: map[string]int{"transpiler": 4}},
+		GetSetting(WORKER_POOL_SETTING_KEY, WorkerPoolSetting{PreProcess: 16, PostProcess: 16, Services: map[string]int{}}))
+	// an integer is decoded into a float field
+	type weightSetting struct {
+		DefaultWeight float64 `toml:"default_weight"`
+	}
+	assert.Equal(t, weightSetting{DefaultWeight: 2}, GetSetting("fair_share", weightSetting{}))
+
+	// a float in an int field is an error, not dropped silently
+	err = globalSetting.parseSetting(`
+[com.worker_pool]
+pre_process = 8.5
+`)
+	assert.Nil(t, err)
+	_, err = LookupSetting(WORKER_POOL_SETTING_KEY, def)
+	assert.NotNil(t, err)
+	assert.Equal(t, def, GetSetting(WORKER_POOL_SETTING_KEY, def))
+}
+
 func registeredSettings() *Setting {
 	ns := newSetting()
 	ns.registerSetting("people", &TestSettingPeople{
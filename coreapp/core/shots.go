@@ -0,0 +1,43 @@
+package core
+
+const SHOTS_SETTING_KEY = "shots"
+
+// ShotsSetting is the limit of the shots of a job in [com.shots].
+// The sampling job over the max shots of the device is split into several runs on the device
+// when max_total_shots is greater than the max shots of the device. max_total_shots is the hard cap
+// of the shots of a job, and zero means no splitting.
+type ShotsSetting struct {
+	MaxTotalShots int `toml:"max_total_shots"`
+}
+
+func NewShotsSetting() ShotsSetting {
+	return ShotsSetting{
+		MaxTotalShots: 0,
+	}
+}
+
+func GetShotsSetting() ShotsSetting {
+	return GetSetting(SHOTS_SETTING_KEY, NewShotsSetting())
+}
+
+// SplitShots splits the shots into the runs of maxShots at most. The shots are not split
+// when maxShots is not positive.
+func SplitShots(shots int, maxShots int) []int {
+	if maxShots <= 0 || shots <= maxShots {
+		return []int{shots}
+	}
+	runs := []int{}
+	for shots > 0 {
+		n := min(shots, maxShots)
+		runs = append(runs, n)
+		shots -= n
+	}
+	return runs
+}
+
+// MergeCounts adds the counts of src to dst.
+func MergeCounts(dst Counts, src Counts) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
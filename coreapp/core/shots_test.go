@@ -0,0 +1,37 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShotsSetting(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(SHOTS_SETTING_KEY, NewShotsSetting())
+	assert.Equal(t, 0, GetShotsSetting().MaxTotalShots)
+
+	err := globalSetting.parseSetting(`
+[com.shots]
+max_total_shots = 100000
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, 100000, GetShotsSetting().MaxTotalShots)
+}
+
+func TestSplitShots(t *testing.T) {
+	assert.Equal(t, []int{1000}, SplitShots(1000, 0))
+	assert.Equal(t, []int{1000}, SplitShots(1000, 1000))
+	assert.Equal(t, []int{1000, 1000}, SplitShots(2000, 1000))
+	assert.Equal(t, []int{1000, 1000, 500}, SplitShots(2500, 1000))
+}
+
+func TestMergeCounts(t *testing.T) {
+	counts := Counts{"00": 10, "11": 5}
+	MergeCounts(counts, Counts{"00": 1, "01": 2})
+	assert.Equal(t, Counts{"00": 11, "01": 2, "11": 5}, counts)
+}
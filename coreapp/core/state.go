@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+)
+
+var ErrorIllegalStatusTransition = errors.New("illegal status transition")
+
+// legalTransitions is the state machine of the status of a job.
+var legalTransitions = map[Status][]Status{
+	SUBMITTED: {READY, FAILED, CANCELLED},
+	READY:     {RUNNING, FAILED, CANCELLED},
+	RUNNING:   {SUCCEEDED, FAILED, CANCELLED},
+	// the QPU has succeeded, but the post-processing such as the mitigation can still fail
+	SUCCEEDED: {FAILED, CANCELLED},
+	// a stage aborted by the cancellation may have failed the job
+	FAILED:    {CANCELLED},
+	CANCELLED: {},
+}
+
+// StatusTransition is a change of the status of a job.
+type StatusTransition struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+func IsLegalTransition(from, to Status) bool {
+	for _, st := range legalTransitions[from] {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+func IsTerminal(st Status) bool {
+	return st == SUCCEEDED || st == FAILED || st == CANCELLED
+}
+
+// SetStatus changes the status of the job and records the time of the transition.
+// The illegal transitions are rejected and the status is kept.
+// Setting the current status again is not a transition and is ignored.
+func (jd *JobData) SetStatus(to Status) error {
+	from := jd.Status
+	if from == to {
+		return nil
+	}
+	if !IsLegalTransition(from, to) {
+		err := fmt.Errorf("%w: job(%s) from %s to %s", ErrorIllegalStatusTransition, jd.ID, from, to)
+		zap.L().Error(err.Error())
+		return err
+	}
+	now := time.Now()
+	jd.Status = to
+	jd.StatusHistory = append(jd.StatusHistory, StatusTransition{From: from, To: to, At: now})
+	if IsTerminal(to) {
+		jd.Ended = strfmt.DateTime(now)
+	}
+	return nil
+}
+
+// InitStatus sets the first status of the job known to the engine, e.g. a job fetched from the cloud
+// in READY. at is the time when the job entered the status.
+func (jd *JobData) InitStatus(st Status, at time.Time) {
+	jd.Status = st
+	jd.StatusHistory = nil
+	if st != SUBMITTED {
+		jd.StatusHistory = append(jd.StatusHistory, StatusTransition{From: SUBMITTED, To: st, At: at})
+	}
+}
+
+// StatusTime returns the time when the job entered the status last.
+func (jd *JobData) StatusTime(st Status) (time.Time, bool) {
+	for i := len(jd.StatusHistory) - 1; i >= 0; i-- {
+		if jd.StatusHistory[i].To == st {
+			return jd.StatusHistory[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// EndedTime returns the time when the job entered its terminal status.
+func (jd *JobData) EndedTime() (time.Time, bool) {
+	if !IsTerminal(jd.Status) {
+		return time.Time{}, false
+	}
+	return jd.StatusTime(jd.Status)
+}
+
+// revertStatus restores the status and the history before the transitions made by a failed attempt.
+func (jd *JobData) revertStatus(st Status, historyLen int) {
+	jd.Status = st
+	if historyLen < len(jd.StatusHistory) {
+		jd.StatusHistory = jd.StatusHistory[:historyLen]
+	}
+}
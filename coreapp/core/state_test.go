@@ -0,0 +1,68 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetStatus(t *testing.T) {
+	jd := NewJobData()
+	jd.InitStatus(READY, time.Now())
+	assert.Nil(t, jd.SetStatus(RUNNING))
+	assert.Nil(t, jd.SetStatus(RUNNING)) // not a transition
+	assert.Nil(t, jd.SetStatus(SUCCEEDED))
+	assert.Nil(t, jd.SetStatus(FAILED)) // e.g. the mitigation failed
+
+	err := jd.SetStatus(RUNNING)
+	assert.True(t, errors.Is(err, ErrorIllegalStatusTransition))
+	assert.Equal(t, FAILED, jd.Status)
+
+	statuses := []Status{}
+	for _, tr := range jd.StatusHistory {
+		statuses = append(statuses, tr.To)
+	}
+	assert.Equal(t, []Status{READY, RUNNING, SUCCEEDED, FAILED}, statuses)
+
+	readyAt, ok := jd.StatusTime(READY)
+	assert.True(t, ok)
+	runningAt, ok := jd.StatusTime(RUNNING)
+	assert.True(t, ok)
+	assert.False(t, runningAt.Before(readyAt))
+	endedAt, ok := jd.EndedTime()
+	assert.True(t, ok)
+	assert.Equal(t, jd.StatusHistory[3].At, endedAt)
+}
+
+func TestSetStatusIllegal(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+	}{
+		{SUBMITTED, RUNNING},
+		{READY, SUCCEEDED},
+		{RUNNING, READY},
+		{CANCELLED, FAILED},
+		{FAILED, SUCCEEDED},
+	}
+	for _, tt := range tests {
+		jd := NewJobData()
+		jd.InitStatus(tt.from, time.Now())
+		assert.NotNil(t, jd.SetStatus(tt.to), "%s to %s", tt.from, tt.to)
+		assert.Equal(t, tt.from, jd.Status)
+	}
+}
+
+func TestSetFailureAfterCancel(t *testing.T) {
+	jd := NewJobData()
+	jd.InitStatus(RUNNING, time.Now())
+	SetCancelledToJobData(jd)
+	SetFailureWithErrorToJobData(jd, errors.New("aborted"))
+	assert.Equal(t, CANCELLED, jd.Status)
+	assert.Equal(t, ErrorJobCancelled.Error(), jd.Result.Message)
+}
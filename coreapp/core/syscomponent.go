@@ -1,8 +1,12 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/go-faster/jx"
 
@@ -27,28 +31,30 @@ func DefaultTranspilerConfigJson() map[string]jx.Raw {
 	return defaultTranspilerConfigJson
 }
 
-type DBChan chan Job
+// DEFAULT_EVENT_BUFFER_SIZE is the number of the events buffered for a subscriber
+const DEFAULT_EVENT_BUFFER_SIZE = 1024
+
+// DEFAULT_FLUSH_TIMEOUT is the time to write the pending events to the DB at the exit
+const DEFAULT_FLUSH_TIMEOUT = 10 * time.Second
 
 type Channels struct {
-	DBChan
-	// when more channel is needed, add here
-	// e.g. sseChan SSEChan
-	// would use map[string]chan Job
+	// the lifecycle events of the jobs are published to the DB and the other subscribers
+	*EventBus
 }
 
 func NewChannels() *Channels {
 	return &Channels{
-		DBChan: make(DBChan),
+		EventBus: NewEventBus(),
 	}
 }
 
 func (c *Channels) Close() {
-	close(c.DBChan)
+	c.EventBus.Close()
 }
 
 func (c *Channels) Check() error {
-	if c.DBChan == nil {
-		return fmt.Errorf("DBChan is nil")
+	if c.EventBus == nil {
+		return fmt.Errorf("EventBus is nil")
 	}
 	return nil
 }
@@ -62,6 +68,8 @@ type DeviceInfo struct {
 	MaxShots           int          `json:"max_shots"`
 	DeviceInfoSpecJson string       `json:"device_info"` // memo: the same as "DeviceInfo"
 	CalibratedAt       string       `json:"calibrated_at"`
+	// AvailableAt is the time when the unavailable device is available again, if known
+	AvailableAt *time.Time `json:"available_at,omitempty"`
 }
 type DeviceInfoSpec struct {
 	DeviceID string  `json:"device_id"`
@@ -124,7 +132,7 @@ func (ds DeviceStatus) String() string {
 
 type QPUManager interface {
 	Setup(*Conf) error
-	Send(Job) error
+	Send(context.Context, Job) error
 	Validate(qasm string) error
 	GetDeviceInfo() *DeviceInfo
 }
@@ -153,21 +161,60 @@ type Transpiler interface {
 	IsAcceptableTranspilerLib(string) bool
 	Setup(*Conf) error
 	GetHealth() error
-	Transpile(Job) error
+	Transpile(context.Context, Job) error
 	TearDown()
 }
 
 type Scheduler interface {
 	Setup(*Conf) error
 	Start() error
-	HandleJob(Job)
+	// HandleJob returns an error without handling the job when the job is refused,
+	// e.g. ErrorQueueFull when there is no free slot in the queue
+	HandleJob(Job) error
+	CancelJob(jobID string) error
 	// Queue Data Access
 	GetCurrentQueueSize() int
+	GetQueueFreeSlots() int
 	IsOverRefillThreshold() bool
+	// ListJobs returns the jobs handled in the scheduler in the order of their estimated start
+	ListJobs() []ScheduledJob
+	// Drain refuses new jobs with ErrorDraining and waits for the jobs in flight until ctx is done.
+	// The queued jobs are left in READY to be recovered by the next run.
+	Drain(ctx context.Context) error
+	// ExpireJobs fails the queued jobs which have waited longer than their TTL and returns their IDs
+	ExpireJobs(now time.Time) []string
+}
+
+// the stages of a job in the scheduler
+const (
+	StagePreProcessing  = "pre-processing"
+	StageQueued         = "queued"
+	StageProcessing     = "processing"
+	StagePostProcessing = "post-processing"
+	// the parent job waits for its children handled in the scheduler
+	StageRunningChildren = "running-children"
+)
+
+// ScheduledJob is a job handled in the scheduler with its estimated start and end of the processing.
+// Position is the place in the queue from 1, and 0 for the jobs not in the queue.
+type ScheduledJob struct {
+	JobID            string     `json:"job_id"`
+	JobType          string     `json:"job_type"`
+	DeviceID         string     `json:"device_id,omitempty"`
+	Status           string     `json:"status"`
+	Stage            string     `json:"stage"`
+	Position         int        `json:"position"`
+	Shots            int        `json:"shots"`
+	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
+	EnqueuedAt       *time.Time `json:"enqueued_at,omitempty"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	EstimatedStartAt *time.Time `json:"estimated_start_at,omitempty"`
+	EstimatedEndAt   *time.Time `json:"estimated_end_at,omitempty"`
 }
 
 type DBManager interface {
-	Setup(DBChan, *Conf) error
+	// Setup subscribes to the events of the jobs to update the DB
+	Setup(*EventBus, *Conf) error
 	// TODO: make Start() for consistency
 	Insert(Job) error
 	Get(string) (Job, error)
@@ -175,6 +222,31 @@ type DBManager interface {
 	Delete(string) error
 }
 
+// DBFlusher is implemented by the DBManager which writes the events of the jobs asynchronously.
+type DBFlusher interface {
+	// Flush waits until the pending events are written after the EventBus has been closed.
+	Flush(context.Context) error
+}
+
+// Stopper is implemented by the Scheduler and the QPUManager running goroutines in the background.
+// Stop is called after the drain, because the jobs in flight use them until they have finished.
+type Stopper interface {
+	Stop()
+}
+
+// StopFunc is a Stopper of a function, e.g. the cancel function of a goroutine.
+type StopFunc func()
+
+func (f StopFunc) Stop() {
+	f()
+}
+
+// JobRecoverer is implemented by the DBManager which keeps the jobs across restarts.
+type JobRecoverer interface {
+	// RecoverJobs returns the jobs left in READY or RUNNING by the previous run.
+	RecoverJobs() ([]Job, error)
+}
+
 type SSEGatewayRouter interface {
 	Setup(*dig.Container) error
 	TearDown()
@@ -183,12 +255,15 @@ type SSEGatewayRouter interface {
 type SystemComponents struct {
 	*dig.Container
 	*Channels
+
+	mu       sync.Mutex
+	stoppers []Stopper
 }
 
 func NewSystemComponents(con *dig.Container) *SystemComponents {
 	return &SystemComponents{
-		con,
-		NewChannels(),
+		Container: con,
+		Channels:  NewChannels(),
 	}
 }
 
@@ -197,7 +272,7 @@ func GetSystemComponents() *SystemComponents {
 }
 
 func (s *SystemComponents) Setup(conf *Conf) error {
-	dbChan := s.DBChan
+	bus := s.EventBus
 
 	zap.L().Debug("Setting up transpiler")
 	var err error
@@ -209,6 +284,11 @@ func (s *SystemComponents) Setup(conf *Conf) error {
 		return err
 	}
 
+	zap.L().Debug("Loading reservations")
+	if err = LoadReservations(); err != nil {
+		return err
+	}
+
 	zap.L().Debug("Setting up scheduler")
 	err = s.Invoke(
 		func(s Scheduler) error {
@@ -221,7 +301,7 @@ func (s *SystemComponents) Setup(conf *Conf) error {
 	zap.L().Debug("Setting up DB")
 	err = s.Invoke(
 		func(d DBManager) error {
-			return d.Setup(dbChan, conf)
+			return d.Setup(bus, conf)
 		})
 	if err != nil {
 		return err
@@ -260,11 +340,113 @@ func (s *SystemComponents) TearDown() {
 	s.Channels.Close()
 }
 
+// Drain stops the scheduler within the timeout, and then flushes the events of the jobs to the DB.
+// The jobs still in flight after the timeout are interrupted by the exit.
+func (s *SystemComponents) Drain(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var drainErr error
+	s.Invoke(
+		func(sc Scheduler) {
+			drainErr = sc.Drain(ctx)
+		})
+	if drainErr != nil {
+		zap.L().Error(fmt.Sprintf("failed to drain the scheduler/reason:%s", drainErr))
+	}
+	// the subscribers receive the pending events before their subscriptions are closed
+	s.Channels.Close()
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), DEFAULT_FLUSH_TIMEOUT)
+	defer flushCancel()
+	var flushErr error
+	s.Invoke(
+		func(d DBManager) {
+			if f, ok := d.(DBFlusher); ok {
+				flushErr = f.Flush(flushCtx)
+			}
+		})
+	if flushErr != nil {
+		zap.L().Error(fmt.Sprintf("failed to flush the DB/reason:%s", flushErr))
+	}
+	return errors.Join(drainErr, flushErr)
+}
+
+// AddStopper adds the goroutine stopped with the scheduler and the QPU after the drain,
+// e.g. that of a runner which the jobs in flight depend on until they have finished.
+func (s *SystemComponents) AddStopper(st Stopper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stoppers = append(s.stoppers, st)
+}
+
+// Stop stops the goroutines of the scheduler, the QPU and the added stoppers after the drain.
+func (s *SystemComponents) Stop() {
+	s.Invoke(
+		func(sc Scheduler) {
+			if st, ok := sc.(Stopper); ok {
+				st.Stop()
+			}
+		})
+	s.Invoke(
+		func(q QPUManager) {
+			if st, ok := q.(Stopper); ok {
+				st.Stop()
+			}
+		})
+	s.mu.Lock()
+	stoppers := s.stoppers
+	s.stoppers = nil
+	s.mu.Unlock()
+	for _, st := range stoppers {
+		st.Stop()
+	}
+}
+
 func (s *SystemComponents) StartContainer() error {
-	return s.Container.Invoke(
+	err := s.Container.Invoke(
 		func(s Scheduler) error {
 			return s.Start()
 		})
+	if err != nil {
+		return err
+	}
+	return s.recoverJobs()
+}
+
+// recoverJobs re-queues the jobs left in READY and fails the jobs left in RUNNING,
+// because the result of the interrupted execution on the QPU is lost.
+func (s *SystemComponents) recoverJobs() error {
+	return s.Container.Invoke(
+		func(d DBManager, sc Scheduler) error {
+			r, ok := d.(JobRecoverer)
+			if !ok {
+				return nil
+			}
+			jobs, err := r.RecoverJobs()
+			if err != nil {
+				zap.L().Error(fmt.Sprintf("failed to recover jobs/reason:%s", err))
+				return err
+			}
+			zap.L().Info(fmt.Sprintf("recovering %d jobs", len(jobs)))
+			for _, j := range jobs {
+				jd := j.JobData()
+				switch jd.Status {
+				case READY:
+					zap.L().Info(fmt.Sprintf("re-queue the job(%s) left in ready", jd.ID))
+					if err := sc.HandleJob(j); err != nil {
+						zap.L().Error(fmt.Sprintf("failed to re-queue the job(%s)/reason:%s", jd.ID, err))
+						RejectJob(j, err)
+					}
+				case RUNNING:
+					zap.L().Info(fmt.Sprintf("fail the job(%s) left in running", jd.ID))
+					SetFailureWithErrorToJobData(jd, WithErrorKind(InternalError, ErrorJobInterrupted))
+					jd.UseJobInfoUpdate = true
+					j.JobContext().Publish(JobFailed, j)
+				default:
+					zap.L().Debug(fmt.Sprintf("not recover the job(%s) in %s", jd.ID, jd.Status))
+				}
+			}
+			return nil
+		})
 }
 
 func (s *SystemComponents) GetDeviceInfo() *DeviceInfo {
@@ -286,6 +468,24 @@ func (s *SystemComponents) GetCurrentQueueSize() int {
 	return size
 }
 
+func (s *SystemComponents) GetQueueFreeSlots() int {
+	var slots int
+	s.Invoke(
+		func(sc Scheduler) {
+			slots = sc.GetQueueFreeSlots()
+		})
+	return slots
+}
+
+func (s *SystemComponents) ListScheduledJobs() []ScheduledJob {
+	var jobs []ScheduledJob
+	s.Invoke(
+		func(sc Scheduler) {
+			jobs = sc.ListJobs()
+		})
+	return jobs
+}
+
 func (s *SystemComponents) IsQueueOverRefillThreshold() bool {
 	var over bool
 	s.Invoke(
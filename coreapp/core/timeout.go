@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const JOB_TIMEOUT_SETTING_KEY = "job_timeout"
+
+var ErrorJobTimeout = errors.New("job timed out")
+
+// JobTimeoutSetting is the deadline of a job from its admission to the end of the post-process.
+// In the setting file, the deadline of a job type is set with the job type as the key, e.g. sse = "1h",
+// and "default" is used for the job types not listed.
+// The values are duration strings. An empty duration means no deadline.
+type JobTimeoutSetting struct {
+	Default string `toml:"default"`
+}
+
+func NewJobTimeoutSetting() JobTimeoutSetting {
+	return JobTimeoutSetting{
+		Default: "",
+	}
+}
+
+// JobTimeout returns the deadline of a job of the job type. Zero means no deadline.
+func JobTimeout(jobType string) time.Duration {
+	return durationOfJobType(JOB_TIMEOUT_SETTING_KEY, jobType, func(s interface{}) (string, bool) {
+		setting, ok := s.(JobTimeoutSetting)
+		return setting.Default, ok
+	})
+}
+
+// durationOfJobType returns the duration of the job type in the setting keyed by the job types
+// with "default" for the job types not listed. defaultOf returns the default of the registered setting.
+// Zero is returned when the duration is not set.
+func durationOfJobType(key string, jobType string, defaultOf func(interface{}) (string, bool)) time.Duration {
+	s, ok := GetComponentSetting(key)
+	if !ok {
+		return 0
+	}
+	var str string
+	if setting, ok := s.(map[string]interface{}); ok {
+		v, ok := setting[jobType].(string)
+		if !ok {
+			v, _ = setting["default"].(string)
+		}
+		str = v
+	} else if v, ok := defaultOf(s); ok {
+		str = v
+	} else {
+		zap.L().Error(fmt.Sprintf("unexpected type of %s setting:%T", key, s))
+		return 0
+	}
+	if str == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to parse the %s of %s job:%s/reason:%s", key, jobType, str, err))
+		return 0
+	}
+	return d
+}
+
+// StartJobDeadline sets the deadline of the job from now, when the scheduler admits the job.
+// The deadline already set is kept, so that handling the job again never extends it.
+func StartJobDeadline(j Job, now time.Time) {
+	jc := j.JobContext()
+	if jc == nil || !jc.deadline.IsZero() {
+		return
+	}
+	d := JobTimeout(j.JobType())
+	if d <= 0 {
+		return
+	}
+	jc.deadline = now.Add(d)
+	jc.timeout = d
+}
+
+// NewStageContext returns the context for a stage of the job.
+// The context is done when the job is cancelled or the job overruns the deadline set by StartJobDeadline.
+func NewStageContext(j Job, stage string) (context.Context, context.CancelFunc) {
+	jc := j.JobContext()
+	parent := jc.Context()
+	if jc == nil || jc.deadline.IsZero() {
+		return context.WithCancel(parent)
+	}
+	cause := fmt.Errorf("%w: job(%s) exceeded the %s deadline of %s job in %s",
+		ErrorJobTimeout, j.JobData().ID, jc.timeout, j.JobType(), stage)
+	return context.WithDeadlineCause(parent, jc.deadline, cause)
+}
+
+// IsTimedOut reports whether the context made by NewStageContext has overrun its deadline.
+func IsTimedOut(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrorJobTimeout)
+}
+
+// SetTimedOut fails the job with the timeout message held by the context.
+func SetTimedOut(j Job, ctx context.Context) {
+	SetFailureWithError(j, context.Cause(ctx))
+}
@@ -0,0 +1,56 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTimeout(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(JOB_TIMEOUT_SETTING_KEY, NewJobTimeoutSetting())
+	assert.Equal(t, time.Duration(0), JobTimeout("sampling"))
+
+	err := globalSetting.parseSetting(`
+[com.job_timeout]
+default = "10m"
+sse = "1h"
+estimation = "invalid"
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, JobTimeout("sampling"))
+	assert.Equal(t, time.Hour, JobTimeout("sse"))
+	assert.Equal(t, time.Duration(0), JobTimeout("estimation"))
+}
+
+func TestStageContextSharesJobDeadline(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(JOB_TIMEOUT_SETTING_KEY, map[string]interface{}{
+		"default": "10m",
+	})
+	j := (&NormalJob{}).New(NewJobData(), &JobContext{})
+	admitted := time.Now().Add(-time.Minute)
+	StartJobDeadline(j, admitted)
+	// handling the job again never extends the deadline
+	StartJobDeadline(j, time.Now())
+
+	for _, stage := range []string{"pre-processing", "processing", "post-processing"} {
+		ctx, cancel := NewStageContext(j, stage)
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.Equal(t, admitted.Add(10*time.Minute), deadline)
+		cancel()
+	}
+
+	// no deadline without the admission
+	ctx, cancel := NewStageContext((&NormalJob{}).New(NewJobData(), &JobContext{}), "processing")
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const JOB_TTL_SETTING_KEY = "job_ttl"
+
+var ErrorJobExpired = errors.New("job expired")
+
+// JobTTLSetting is the time for a job to wait in the queue, measured from the creation of the job.
+// In the setting file, the TTL of a job type is set with the job type as the key, e.g. sse = "24h",
+// and "default" is used for the job types not listed. An empty duration means no TTL.
+type JobTTLSetting struct {
+	Default string `toml:"default"`
+}
+
+func NewJobTTLSetting() JobTTLSetting {
+	return JobTTLSetting{
+		Default: "",
+	}
+}
+
+// JobTTL returns the TTL of the job type. Zero means no TTL.
+func JobTTL(jobType string) time.Duration {
+	return durationOfJobType(JOB_TTL_SETTING_KEY, jobType, func(s interface{}) (string, bool) {
+		setting, ok := s.(JobTTLSetting)
+		return setting.Default, ok
+	})
+}
+
+// IsExpired reports whether the job has waited longer than the TTL of its job type at now.
+// The job without its creation time never expires.
+func IsExpired(jd *JobData, now time.Time) bool {
+	ttl := JobTTL(jd.JobType)
+	created := time.Time(jd.Created)
+	if ttl <= 0 || created.IsZero() {
+		return false
+	}
+	return now.Sub(created) > ttl
+}
+
+// SetExpired fails the job which has waited longer than the TTL of its job type.
+func SetExpired(j Job) {
+	jd := j.JobData()
+	err := fmt.Errorf("%w: %s job(%s) created at %s exceeded the TTL of %s",
+		ErrorJobExpired, jd.JobType, jd.ID, time.Time(jd.Created).Format(time.RFC3339), JobTTL(jd.JobType))
+	SetFailureWithError(j, NewDeviceUnavailableError(
+		fmt.Sprintf("the job expired after waiting longer than %s in the queue. please submit the job again", JobTTL(jd.JobType)), err))
+}
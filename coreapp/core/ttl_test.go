@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTTL(t *testing.T) {
+	ResetSetting()
+	defer ResetSetting()
+	RegisterSetting(JOB_TTL_SETTING_KEY, NewJobTTLSetting())
+	now := time.Now()
+	jd := NewJobData()
+	jd.JobType = "sampling"
+	jd.Created = strfmt.DateTime(now.Add(-48 * time.Hour))
+	assert.Equal(t, time.Duration(0), JobTTL("sampling"))
+	assert.False(t, IsExpired(jd, now))
+
+	err := globalSetting.parseSetting(`
+[com.job_ttl]
+default = "72h"
+sse = "24h"
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, 72*time.Hour, JobTTL("sampling"))
+	assert.False(t, IsExpired(jd, now))
+	jd.JobType = "sse"
+	assert.True(t, IsExpired(jd, now))
+	// the job without its creation time
+	jd.Created = strfmt.DateTime{}
+	assert.False(t, IsExpired(jd, now))
+}
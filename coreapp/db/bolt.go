@@ -0,0 +1,285 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+var jobsBucket = []byte("jobs")
+
+// the interval to prune the finished jobs older than the retention
+const BOLT_PRUNE_INTERVAL = time.Hour
+
+// BoltDB is a DBManager which persists the jobs in an embedded bbolt file,
+// so that the jobs not finished are recovered after the restart of the engine.
+// A job is inserted when the scheduler admits it, and kept for the retention after it finishes.
+type BoltDB struct {
+	path      string
+	retention time.Duration
+	pruned    time.Time
+	db        *bolt.DB
+	sub       *core.Subscription
+	done      chan struct{}
+}
+
+func (b *BoltDB) Setup(bus *core.EventBus, c *core.Conf) error {
+	zap.L().Debug(fmt.Sprintf("Setting up Bolt DB in %s", c.BoltDBPath))
+	b.path = c.BoltDBPath
+	b.retention = c.BoltDBRetention
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to make the directory of %s/reason:%s", b.path, err))
+		return err
+	}
+	db, err := bolt.Open(b.path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to open %s/reason:%s", b.path, err))
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to create the jobs bucket/reason:%s", err))
+		db.Close()
+		return err
+	}
+	b.db = db
+	b.prune(time.Now())
+	b.sub = bus.Subscribe("BoltDB", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	b.done = make(chan struct{})
+	go func() {
+		defer close(b.done)
+		defer b.close() // when the bus is closed
+		for e := range b.sub.Events() {
+			job := e.Job
+			switch {
+			case e.Type == core.JobPreprocessing:
+				// admitted by the scheduler, so that the job in pre-processing is recovered
+				zap.L().Debug(fmt.Sprintf("[BoltDB] Received %s in admission", job.JobData().ID))
+				if err := b.Insert(job); err != nil {
+					zap.L().Error(fmt.Sprintf("failed to insert a job(%s). Reason:%s",
+						job.JobData().ID, err.Error()))
+				}
+			case e.Type.ChangesStatus():
+				zap.L().Debug(fmt.Sprintf("[BoltDB] Received %s", job.JobData().ID))
+				if err := b.Update(job); err != nil {
+					zap.L().Error(fmt.Sprintf("failed to update a job(%s). Reason:%s",
+						job.JobData().ID, err.Error()))
+				}
+				if core.IsTerminal(job.JobData().Status) && time.Since(b.pruned) >= BOLT_PRUNE_INTERVAL {
+					b.prune(time.Now())
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Flush waits until the pending events are written and the file is closed.
+func (b *BoltDB) Flush(ctx context.Context) error {
+	return core.WaitForFlush(ctx, "BoltDB", b.done)
+}
+
+// Insert refuses the job whose ID is used by another job not finished.
+func (b *BoltDB) Insert(j core.Job) error {
+	jd := j.JobData()
+	v, err := json.Marshal(jd)
+	if err != nil {
+		return err
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		if old := bucket.Get([]byte(jd.ID)); old != nil {
+			oldJD := &core.JobData{}
+			if err := json.Unmarshal(old, oldJD); err != nil {
+				return err
+			}
+			if core.IsAnotherActiveJob(oldJD, jd) {
+				return fmt.Errorf("%w: job(%s) is in %s", core.ErrorJobIDConflict, jd.ID, oldJD.Status)
+			}
+		}
+		return bucket.Put([]byte(jd.ID), v)
+	})
+	if errors.Is(err, core.ErrorJobIDConflict) {
+		core.CountDuplicateJob(jd.ID, err)
+	}
+	return err
+}
+
+func (b *BoltDB) Get(jobID string) (core.Job, error) {
+	jd, err := b.getJobData(jobID)
+	if err != nil {
+		zap.L().Info("[BoltDB]", zap.Error(err))
+		return &core.NormalJob{}, err
+	}
+	return newJob(jd)
+}
+
+func (b *BoltDB) Update(j core.Job) error {
+	return b.put(j.JobData())
+}
+
+func (b *BoltDB) Delete(jobID string) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		if bucket.Get([]byte(jobID)) == nil {
+			return fmt.Errorf("failed to find %s", jobID)
+		}
+		return bucket.Delete([]byte(jobID))
+	})
+	if err != nil {
+		zap.L().Info("[BoltDB]", zap.Error(err))
+		return err
+	}
+	zap.L().Info(fmt.Sprintf("[BoltDB] deleted %s from DB", jobID))
+	return nil
+}
+
+// RecoverJobs returns the jobs left in READY or RUNNING by the previous run of the engine.
+func (b *BoltDB) RecoverJobs() ([]core.Job, error) {
+	jds := []*core.JobData{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(k, v []byte) error {
+			jd := &core.JobData{}
+			if err := json.Unmarshal(v, jd); err != nil {
+				// a broken record must not block the recovery of the others
+				zap.L().Error(fmt.Sprintf("failed to unmarshal a job(%s)/reason:%s", string(k), err))
+				return nil
+			}
+			if jd.Status == core.READY || jd.Status == core.RUNNING {
+				jds = append(jds, jd)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return []core.Job{}, err
+	}
+	jobs := make([]core.Job, 0, len(jds))
+	for _, jd := range jds {
+		j, err := newJob(jd)
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to recover a job(%s)/reason:%s", jd.ID, err))
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// Prune deletes the finished jobs which ended before the retention from now.
+// No job is deleted when the retention is not positive.
+func (b *BoltDB) Prune(now time.Time) (int, error) {
+	if b.retention <= 0 {
+		return 0, nil
+	}
+	threshold := now.Add(-b.retention)
+	pruned := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		expired := [][]byte{}
+		err := bucket.ForEach(func(k, v []byte) error {
+			jd := &core.JobData{}
+			if err := json.Unmarshal(v, jd); err != nil {
+				zap.L().Error(fmt.Sprintf("failed to unmarshal a job(%s)/reason:%s", string(k), err))
+				return nil
+			}
+			if !core.IsTerminal(jd.Status) {
+				return nil
+			}
+			ended := time.Time(jd.Ended)
+			if ended.IsZero() {
+				ended = time.Time(jd.Created)
+			}
+			if ended.Before(threshold) {
+				// the keys are only valid in the transaction
+				expired = append(expired, append([]byte{}, k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// the bucket must not be modified in ForEach
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		pruned = len(expired)
+		return nil
+	})
+	return pruned, err
+}
+
+func (b *BoltDB) prune(now time.Time) {
+	b.pruned = now
+	n, err := b.Prune(now)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to prune the finished jobs/reason:%s", err))
+		return
+	}
+	if n > 0 {
+		zap.L().Info(fmt.Sprintf("[BoltDB] pruned %d finished jobs older than %s", n, b.retention))
+	}
+}
+
+func (b *BoltDB) put(jd *core.JobData) error {
+	v, err := json.Marshal(jd)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(jd.ID), v)
+	})
+}
+
+func (b *BoltDB) getJobData(jobID string) (*core.JobData, error) {
+	var v []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		// the value is only valid in the transaction
+		if tmp := tx.Bucket(jobsBucket).Get([]byte(jobID)); tmp != nil {
+			v = append([]byte{}, tmp...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, fmt.Errorf("not found %s", jobID)
+	}
+	jd := &core.JobData{}
+	if err := json.Unmarshal(v, jd); err != nil {
+		return nil, err
+	}
+	return jd, nil
+}
+
+func (b *BoltDB) close() {
+	if err := b.db.Close(); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to close %s/reason:%s", b.path, err))
+	}
+}
+
+func newJob(jd *core.JobData) (core.Job, error) {
+	jm := core.GetJobManager()
+	if jm == nil {
+		return nil, fmt.Errorf("job manager is not initialized")
+	}
+	jc, err := core.NewJobContext()
+	if err != nil {
+		return nil, err
+	}
+	return jm.NewJobFromJobData(jd, jc)
+}
@@ -0,0 +1,178 @@
+//go:build unit
+// +build unit
+
+package db
+
+import (
+	"context"
+	"github.com/go-openapi/strfmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoltDBRecoverJobs(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{})
+
+	path := filepath.Join(t.TempDir(), "edge.db")
+	b := &BoltDB{}
+	assert.Nil(t, b.Setup(core.NewEventBus(), &core.Conf{BoltDBPath: path}))
+
+	statuses := map[string]core.Status{
+		"ready_job":     core.READY,
+		"running_job":   core.RUNNING,
+		"succeeded_job": core.SUCCEEDED,
+		"failed_job":    core.FAILED,
+	}
+	for id, st := range statuses {
+		jd := core.NewJobData()
+		jd.ID = id
+		jd.Status = st
+		jd.JobType = core.NORMAL_JOB
+		jd.Result.Counts = core.Counts{"00": 10}
+		assert.Nil(t, b.Insert((&core.NormalJob{}).New(jd, nil)))
+	}
+
+	j, err := b.Get("running_job")
+	assert.Nil(t, err)
+	assert.Equal(t, core.RUNNING, j.JobData().Status)
+	assert.Equal(t, core.Counts{"00": 10}, j.JobData().Result.Counts)
+
+	// the recovered job itself can be inserted again, but another job with the active ID cannot
+	assert.Nil(t, b.Insert(j))
+	dup := core.NewJobData()
+	dup.ID = "running_job"
+	dup.JobType = core.NORMAL_JOB
+	dup.Created = strfmt.DateTime(time.Now().Add(time.Second))
+	assert.ErrorIs(t, b.Insert((&core.NormalJob{}).New(dup, nil)), core.ErrorJobIDConflict)
+	dup.ID = "succeeded_job"
+	assert.Nil(t, b.Insert((&core.NormalJob{}).New(dup, nil)))
+	dup.Status = core.SUCCEEDED
+	assert.Nil(t, b.Update((&core.NormalJob{}).New(dup, nil)))
+
+	jobs, err := b.RecoverJobs()
+	assert.Nil(t, err)
+	recovered := map[string]core.Status{}
+	for _, j := range jobs {
+		recovered[j.JobData().ID] = j.JobData().Status
+	}
+	assert.Equal(t, map[string]core.Status{
+		"ready_job":   core.READY,
+		"running_job": core.RUNNING,
+	}, recovered)
+
+	assert.Nil(t, b.Delete("ready_job"))
+	assert.NotNil(t, b.Delete("ready_job"))
+	_, err = b.Get("ready_job")
+	assert.NotNil(t, err)
+}
+
+func TestBoltDBFlush(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{})
+
+	path := filepath.Join(t.TempDir(), "edge.db")
+	bus := core.NewEventBus()
+	b := &BoltDB{}
+	assert.Nil(t, b.Setup(bus, &core.Conf{BoltDBPath: path}))
+	jd := core.NewJobData()
+	jd.ID = "flushed_job"
+	jd.Status = core.SUCCEEDED
+	jd.JobType = core.NORMAL_JOB
+	bus.Publish(core.JobSucceeded, (&core.NormalJob{}).New(jd, nil))
+	bus.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, b.Flush(ctx))
+
+	// the pending event has been written before the file is closed
+	reopened := &BoltDB{}
+	assert.Nil(t, reopened.Setup(core.NewEventBus(), &core.Conf{BoltDBPath: path}))
+	defer reopened.close()
+	j, err := reopened.Get("flushed_job")
+	assert.Nil(t, err)
+	assert.Equal(t, core.SUCCEEDED, j.JobData().Status)
+}
+
+func TestBoltDBInsertOnAdmission(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{})
+
+	path := filepath.Join(t.TempDir(), "edge.db")
+	bus := core.NewEventBus()
+	b := &BoltDB{}
+	assert.Nil(t, b.Setup(bus, &core.Conf{BoltDBPath: path}))
+	jd := core.NewJobData()
+	jd.ID = "preprocessing_job"
+	jd.Status = core.READY
+	jd.JobType = core.NORMAL_JOB
+	bus.Publish(core.JobPreprocessing, (&core.NormalJob{}).New(jd, nil))
+	bus.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, b.Flush(ctx))
+
+	// the job crashed in pre-processing is recovered
+	reopened := &BoltDB{}
+	assert.Nil(t, reopened.Setup(core.NewEventBus(), &core.Conf{BoltDBPath: path}))
+	defer reopened.close()
+	jobs, err := reopened.RecoverJobs()
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "preprocessing_job", jobs[0].JobData().ID)
+}
+
+func TestBoltDBPrune(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{})
+
+	path := filepath.Join(t.TempDir(), "edge.db")
+	b := &BoltDB{}
+	assert.Nil(t, b.Setup(core.NewEventBus(), &core.Conf{BoltDBPath: path, BoltDBRetention: time.Hour}))
+	now := time.Now()
+	jobs := []struct {
+		id     string
+		status core.Status
+		ended  time.Time
+	}{
+		{id: "old_succeeded_job", status: core.SUCCEEDED, ended: now.Add(-2 * time.Hour)},
+		{id: "old_failed_job", status: core.FAILED, ended: now.Add(-2 * time.Hour)},
+		{id: "new_succeeded_job", status: core.SUCCEEDED, ended: now.Add(-time.Minute)},
+		{id: "old_running_job", status: core.RUNNING},
+	}
+	for _, j := range jobs {
+		jd := core.NewJobData()
+		jd.ID = j.id
+		jd.Status = j.status
+		jd.JobType = core.NORMAL_JOB
+		jd.Created = strfmt.DateTime(now.Add(-3 * time.Hour))
+		jd.Ended = strfmt.DateTime(j.ended)
+		assert.Nil(t, b.Insert((&core.NormalJob{}).New(jd, nil)))
+	}
+
+	n, err := b.Prune(now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	for _, id := range []string{"old_succeeded_job", "old_failed_job"} {
+		_, err := b.Get(id)
+		assert.NotNil(t, err)
+	}
+	for _, id := range []string{"new_succeeded_job", "old_running_job"} {
+		_, err := b.Get(id)
+		assert.Nil(t, err)
+	}
+
+	// no retention keeps the finished jobs
+	b.retention = 0
+	n, err = b.Prune(now.Add(time.Hour * 24))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/oas"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+// FileDB is a DBManager which writes each job to <dir>/<job_id>.json in the JobsJobDef schema
+// of the provider API, for the engine without a route to the cloud. The results are read from the files.
+type FileDB struct {
+	dir  string
+	mu   sync.Mutex
+	sub  *core.Subscription
+	done chan struct{}
+}
+
+func (f *FileDB) Setup(bus *core.EventBus, c *core.Conf) error {
+	zap.L().Debug(fmt.Sprintf("Setting up File DB in %s", c.FileDBDir))
+	f.dir = c.FileDBDir
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to make %s/reason:%s", f.dir, err))
+		return err
+	}
+	f.sub = bus.Subscribe("FileDB", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	f.done = make(chan struct{})
+	go func() {
+		defer close(f.done)
+		for e := range f.sub.Events() {
+			if !e.Type.ChangesStatus() {
+				continue
+			}
+			job := e.Job
+			zap.L().Debug(fmt.Sprintf("[FileDB] Received %s", job.JobData().ID))
+			if err := f.Update(job); err != nil {
+				zap.L().Error(fmt.Sprintf("failed to update a job(%s). Reason:%s",
+					job.JobData().ID, err.Error()))
+			}
+		}
+	}()
+	return nil
+}
+
+// Flush waits until the pending events are written.
+func (f *FileDB) Flush(ctx context.Context) error {
+	return core.WaitForFlush(ctx, "FileDB", f.done)
+}
+
+// Insert refuses the job whose ID is used by another job not finished.
+func (f *FileDB) Insert(j core.Job) error {
+	jd := j.JobData()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if old, err := f.read(jd.ID); err == nil {
+		oldJD := oas.ConvertFromCloudJob(old)
+		if core.IsAnotherActiveJob(oldJD, jd) {
+			err := fmt.Errorf("%w: job(%s) is in %s", core.ErrorJobIDConflict, jd.ID, oldJD.Status)
+			core.CountDuplicateJob(jd.ID, err)
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return f.write(jd)
+}
+
+func (f *FileDB) Get(jobID string) (core.Job, error) {
+	f.mu.Lock()
+	def, err := f.read(jobID)
+	f.mu.Unlock()
+	if err != nil {
+		zap.L().Info("[FileDB]", zap.Error(err))
+		return &core.NormalJob{}, err
+	}
+	return newJob(oas.ConvertFromCloudJob(def))
+}
+
+func (f *FileDB) Update(j core.Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.write(j.JobData())
+}
+
+func (f *FileDB) Delete(jobID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.Remove(f.path(jobID)); err != nil {
+		zap.L().Info("[FileDB]", zap.Error(err))
+		return err
+	}
+	zap.L().Info(fmt.Sprintf("[FileDB] deleted %s from DB", jobID))
+	return nil
+}
+
+func (f *FileDB) path(jobID string) string {
+	// the job ID must not escape the directory
+	return filepath.Join(f.dir, filepath.Base(jobID)+".json")
+}
+
+func (f *FileDB) read(jobID string) (*api.JobsJobDef, error) {
+	b, err := os.ReadFile(f.path(jobID))
+	if err != nil {
+		return nil, err
+	}
+	def := &api.JobsJobDef{}
+	if err := def.UnmarshalJSON(b); err != nil {
+		return nil, fmt.Errorf("failed to decode the file of job(%s): %w", jobID, err)
+	}
+	return def, nil
+}
+
+// write replaces the file at once, so that the readers of the directory never see a partial file.
+func (f *FileDB) write(jd *core.JobData) error {
+	b, err := oas.ConvertToCloudJob(jd).MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	// readable by the other users of the directory, like a file written by os.WriteFile
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(jd.ID))
+}
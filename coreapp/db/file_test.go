@@ -0,0 +1,81 @@
+//go:build unit
+// +build unit
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSamplingJobForFileDB(t *testing.T, jobID string) core.Job {
+	jd := core.NewJobData()
+	jd.ID = jobID
+	jd.JobType = sampling.SAMPLING_JOB
+	jd.Shots = 1000
+	jd.QASM = "OPENQASM 3;qubit[2] q;h q[1];"
+	jd.Transpiler = core.DEFAULT_TRANSPILER_CONFIG()
+	jd.Created = strfmt.DateTime(time.Now())
+	jd.InitStatus(core.READY, time.Now())
+	jc, err := core.NewJobContext()
+	assert.Nil(t, err)
+	return (&sampling.SamplingJob{}).New(jd, jc)
+}
+
+func TestFileDB(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&sampling.SamplingJob{})
+
+	dir := filepath.Join(t.TempDir(), "out")
+	f := &FileDB{}
+	bus := core.NewEventBus()
+	assert.Nil(t, f.Setup(bus, &core.Conf{FileDBDir: dir}))
+
+	j := newSamplingJobForFileDB(t, "job1")
+	assert.Nil(t, f.Insert(j))
+	got, err := f.Get("job1")
+	assert.Nil(t, err)
+	assert.Equal(t, core.READY, got.JobData().Status)
+	assert.Equal(t, 1000, got.JobData().Shots)
+	assert.Equal(t, sampling.SAMPLING_JOB, got.JobData().JobType)
+
+	// another job with the active ID is refused
+	dup := newSamplingJobForFileDB(t, "job1")
+	dup.JobData().Created = strfmt.DateTime(time.Now().Add(time.Second))
+	assert.ErrorIs(t, f.Insert(dup), core.ErrorJobIDConflict)
+
+	// the result is written in the schema of the provider API
+	j.JobData().Result.Counts = core.Counts{"00": 600, "01": 400}
+	assert.Nil(t, j.JobData().SetStatus(core.RUNNING))
+	assert.Nil(t, j.JobData().SetStatus(core.SUCCEEDED))
+	bus.Publish(core.JobSucceeded, j)
+	bus.Close()
+	assert.Nil(t, f.Flush(context.Background()))
+	b, err := os.ReadFile(filepath.Join(dir, "job1.json"))
+	assert.Nil(t, err)
+	written := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(b, &written))
+	assert.Equal(t, "job1", written["job_id"])
+	assert.Equal(t, "succeeded", written["status"])
+	result := written["job_info"].(map[string]interface{})["result"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"00": 600.0, "01": 400.0},
+		result["sampling"].(map[string]interface{})["counts"])
+
+	assert.Nil(t, f.Delete("job1"))
+	assert.NotNil(t, f.Delete("job1"))
+	_, err = f.Get("job1")
+	assert.NotNil(t, err)
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
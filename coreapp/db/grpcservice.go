@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	jint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/job/job_interface/v1"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/jobservice"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/oas"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+// GRPCServiceDB updates the jobs in the cloud through the JobService instead of the provider API.
+type GRPCServiceDB struct {
+	client *jobservice.Client
+	sub    *core.Subscription
+	done   chan struct{}
+}
+
+func (s *GRPCServiceDB) Setup(bus *core.EventBus, c *core.Conf) error {
+	zap.L().Debug("Setting up gRPC Service DB")
+	cli, err := jobservice.NewClient(jobservice.GetJobServiceSetting())
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to create a job service client/reason:%s", err))
+		return err
+	}
+	s.client = cli
+	s.sub = bus.Subscribe("GRPCServiceDB", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		defer s.client.Close()
+		for e := range s.sub.Events() {
+			if !e.Type.ChangesStatus() {
+				continue
+			}
+			job := e.Job
+			zap.L().Debug(fmt.Sprintf("[GRPCServiceDB] Received %s", job.JobData().ID))
+			s.Update(job)
+		}
+	}()
+	return nil
+}
+
+func (s *GRPCServiceDB) Flush(ctx context.Context) error {
+	return core.WaitForFlush(ctx, "GRPCServiceDB", s.done)
+}
+
+func (s *GRPCServiceDB) Insert(j core.Job) error {
+	zap.L().Debug("[GRPCServiceDB] Does not insert " + j.JobData().ID)
+	return nil
+}
+
+func (s *GRPCServiceDB) Get(jobID string) (core.Job, error) {
+	zap.L().Debug("[GRPCServiceDB] Do not get " + jobID)
+	return &core.NormalJob{}, fmt.Errorf("not found %s", jobID)
+}
+
+func (s *GRPCServiceDB) Update(j core.Job) error {
+	jd := j.JobData()
+	req := ToUpdateJobInfoRequest(jd)
+	// the same as ServiceDB, only the running status is reported before the job info is updated
+	if !jd.UseJobInfoUpdate {
+		if req.Status != string(api.JobsJobStatusRunning) {
+			zap.L().Debug(fmt.Sprintf("Job(%s) is %s. Not update DB", jd.ID, req.Status))
+			return nil
+		}
+		req.UpdateJobInfo = nil
+	}
+	zap.L().Debug(fmt.Sprintf("UpdateJobInfoRequest/JobID:%s/Status:%s", jd.ID, req.Status))
+	if err := s.client.UpdateJobInfo(req); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to update the job info of %s/reason:%s", jd.ID, err))
+		return err
+	}
+	return nil
+}
+
+func (s *GRPCServiceDB) Delete(jobID string) error {
+	zap.L().Debug("[GRPCServiceDB] Do not delete " + jobID)
+	return nil
+}
+
+// ToUpdateJobInfoRequest converts the job to the request of the JobService in the same way as the
+// provider API. The result is in JSON.
+func ToUpdateJobInfoRequest(jd *core.JobData) *jint.UpdateJobInfoRequest {
+	cJob := oas.ConvertToCloudJob(jd.Clone())
+	info := &jint.UpdateJobInfo{
+		CombinedProgram: cJob.JobInfo.CombinedProgram.Value,
+		Message:         cJob.JobInfo.Message.Value,
+	}
+	if jd.NeedTranspiling() {
+		info.TranspiledProgram = jd.TranspiledQASM
+	}
+	switch cJob.Status {
+	case api.JobsJobStatusFailed:
+		// only the message for the user is reported. the detail of the failure is in the log
+		info.Message = core.FailureMessage(jd)
+	case api.JobsJobStatusCancelled:
+	default:
+		if r, ok := cJob.JobInfo.Result.Get(); ok {
+			b, err := r.MarshalJSON()
+			if err != nil {
+				zap.L().Error(fmt.Sprintf("failed to marshal the result of %s/reason:%s", jd.ID, err))
+			} else {
+				info.Result = string(b)
+			}
+		}
+	}
+	req := &jint.UpdateJobInfoRequest{
+		JobId:         jd.ID,
+		UpdateJobInfo: info,
+		Status:        string(cJob.Status),
+		ExecutionTime: cJob.ExecutionTime.Value,
+	}
+	if t := jd.Created; !t.IsZero() {
+		req.SubmittedAt = jobservice.FormatTime(time.Time(t))
+	}
+	if t, ok := cJob.ReadyAt.Get(); ok {
+		req.ReadyAt = jobservice.FormatTime(t)
+	}
+	if t, ok := cJob.RunningAt.Get(); ok {
+		req.RunningAt = jobservice.FormatTime(t)
+	}
+	if t, ok := cJob.EndedAt.Get(); ok {
+		req.EndedAt = jobservice.FormatTime(t)
+	}
+	return req
+}
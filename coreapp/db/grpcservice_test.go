@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToUpdateJobInfoRequest(t *testing.T) {
+	created := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	jd := core.NewJobData()
+	jd.ID = "job1"
+	jd.JobType = "sampling"
+	jd.Created = strfmt.DateTime(created)
+	jd.Transpiler = &core.TranspilerConfig{}
+	jd.InitStatus(core.READY, created.Add(time.Second))
+	jd.SetStatus(core.RUNNING)
+	jd.SetStatus(core.SUCCEEDED)
+	jd.Result.Counts = core.Counts{"00": 10}
+	jd.Result.ExecutionTime = 2 * time.Second
+
+	req := ToUpdateJobInfoRequest(jd)
+	assert.Equal(t, "job1", req.JobId)
+	assert.Equal(t, "succeeded", req.Status)
+	assert.Equal(t, 2.0, req.ExecutionTime)
+	assert.Equal(t, "2024-04-01T09:00:00Z", req.SubmittedAt)
+	assert.Equal(t, "2024-04-01T09:00:01Z", req.ReadyAt)
+	assert.NotEmpty(t, req.RunningAt)
+	assert.NotEmpty(t, req.EndedAt)
+	assert.Contains(t, req.UpdateJobInfo.Result, `"counts":{"00":10}`)
+
+	jd = core.NewJobData()
+	jd.ID = "job2"
+	jd.JobType = "sampling"
+	jd.Transpiler = &core.TranspilerConfig{}
+	jd.InitStatus(core.RUNNING, created)
+	core.SetFailureWithErrorToJobData(jd, core.WithErrorKind(core.DeviceUnavailableError, core.ErrorJobInterrupted))
+	req = ToUpdateJobInfoRequest(jd)
+	assert.Equal(t, "failed", req.Status)
+	assert.Empty(t, req.UpdateJobInfo.Result)
+	assert.Equal(t, core.FailureMessage(jd), req.UpdateJobInfo.Message)
+}
@@ -18,7 +18,8 @@ type ServiceDB struct {
 	endpoint string
 	apiKey   string
 	client   *api.Client
-	dbc      core.DBChan
+	sub      *core.Subscription
+	done     chan struct{}
 }
 
 type dbSecuritySource struct {
@@ -31,7 +32,7 @@ func (s dbSecuritySource) ApiKeyAuth(ctx context.Context, name api.OperationName
 	return apiKeyAuth, nil
 }
 
-func (s *ServiceDB) Setup(dbc core.DBChan, c *core.Conf) error {
+func (s *ServiceDB) Setup(bus *core.EventBus, c *core.Conf) error {
 	zap.L().Debug("Setting up Service DB")
 	s.endpoint = c.ServiceDBEndpoint
 	s.apiKey = c.ServiceDBAPIKey
@@ -43,10 +44,17 @@ func (s *ServiceDB) Setup(dbc core.DBChan, c *core.Conf) error {
 		return err
 	}
 	s.client = cli
-	s.dbc = dbc
+	// the slow updates of the service are coalesced into the latest status of each job
+	// instead of blocking the scheduler
+	s.sub = bus.Subscribe("ServiceDB", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	s.done = make(chan struct{})
 	go func() {
-		for {
-			job := <-s.dbc
+		defer close(s.done)
+		for e := range s.sub.Events() {
+			if !e.Type.ChangesStatus() {
+				continue
+			}
+			job := e.Job
 			zap.L().Debug(fmt.Sprintf("[ServiceDB] Received %s", job.JobData().ID))
 			s.Update(job)
 		}
@@ -55,6 +63,10 @@ func (s *ServiceDB) Setup(dbc core.DBChan, c *core.Conf) error {
 	return nil
 }
 
+func (s *ServiceDB) Flush(ctx context.Context) error {
+	return core.WaitForFlush(ctx, "ServiceDB", s.done)
+}
+
 func (s *ServiceDB) Insert(j core.Job) error {
 	// ad hoc impl
 	zap.L().Debug("[ServiceDB] Does not insert " + j.JobData().ID)
@@ -101,6 +113,8 @@ func (s *ServiceDB) Update(j core.Job) error {
 			zap.L().Debug(fmt.Sprintf("Job(%s) is succeeded", jid))
 		case api.JobsJobStatusFailed:
 			zap.L().Debug(fmt.Sprintf("Job(%s) is failed", jid))
+		case api.JobsJobStatusCancelled:
+			zap.L().Debug(fmt.Sprintf("Job(%s) is cancelled", jid))
 		case api.JobsJobStatusReady:
 			zap.L().Debug(fmt.Sprintf("Job(%s) is ready. Not update DB", jid))
 		default:
@@ -113,8 +127,8 @@ func (s *ServiceDB) Update(j core.Job) error {
 
 	res := api.NewOptNilJobsJobResult(api.JobsJobResult{})
 	switch cJob.Status {
-	case api.JobsJobStatusFailed:
-		zap.L().Debug("failed/setting result to null")
+	case api.JobsJobStatusFailed, api.JobsJobStatusCancelled:
+		zap.L().Debug(fmt.Sprintf("%s/setting result to null", cJob.Status))
 		res.Reset()
 	default:
 		res = cJob.JobInfo.Result
@@ -161,6 +175,11 @@ func (s *ServiceDB) Update(j core.Job) error {
 		tr = api.NewOptNilJobsTranspileResult(api.JobsTranspileResult{})
 		tr.SetToNull()
 	}
+	// only the message for the user is reported. the detail of the failure is in the log
+	message := cJob.JobInfo.Message.Value
+	if cJob.Status == api.JobsJobStatusFailed {
+		message = core.FailureMessage(jd)
+	}
 	req := api.NewOptJobsUpdateJobInfoRequest(
 		api.JobsUpdateJobInfoRequest{
 			OverwriteStatus: api.NewOptJobsJobStatus(cJob.Status),
@@ -170,13 +189,13 @@ func (s *ServiceDB) Update(j core.Job) error {
 					CombinedProgram: cJob.JobInfo.CombinedProgram,
 					TranspileResult: tr,
 					Result:          res,
-					Message:         api.NewOptNilString(cJob.JobInfo.Message.Value),
+					Message:         api.NewOptNilString(message),
 				}),
 		})
 	vpmStr := string(j.JobData().Result.TranspilerInfo.VirtualPhysicalMappingRaw)
 	zap.L().Debug(fmt.Sprintf(
 		"JobsUpdateJobInfoRequest/JobID:%s/Status:%s/Message:%s/StatsRaw:%v/TranspiledQASM:%s/VirtualPhysicalMappingDecoded:%s",
-		jid, cJob.Status, cJob.JobInfo.Message.Value, stats, j.JobData().TranspiledQASM,
+		jid, cJob.Status, message, stats, j.JobData().TranspiledQASM,
 		vpmStr))
 	params := api.PatchJobInfoParams{JobID: jid}
 	patchRes, patchErr := s.client.PatchJobInfo(ctx, req, params)
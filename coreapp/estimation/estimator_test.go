@@ -4,6 +4,7 @@
 package estimation
 
 import (
+	"context"
 	"testing"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
@@ -38,7 +39,7 @@ func TestPreProcess(t *testing.T) {
 	ej.jobData.TranspiledQASM = "OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\ncx q[0], q[1];\n"
 	//ej.origOperators = "[[\"X 0 X 1\", 1.5], [\"Y 0 Z 1\", 1.2]]"
 
-	ej.PreProcess()
+	ej.PreProcess(context.Background())
 	actualQasms := ej.preprocessedQASMs
 	actualGroupedOperators := ej.groupedOperators
 
@@ -76,7 +77,7 @@ func TestProcess(t *testing.T) {
 		"OPENQASM 3.0;\ninclude \"stdgates.inc\";\nbit[2] __c_XX;\nqubit[2] q;\nh q[0];\ncx q[0], q[1];\nrz(pi/2) q[0];\nsx q[0];\nrz(pi/2) q[0];\nrz(pi/2) q[1];\nsx q[1];\nrz(pi/2) q[1];\n__c_XX[0] = measure q[0];\n__c_XX[1] = measure q[1];\n",
 		"OPENQASM 3.0;\ninclude \"stdgates.inc\";\nbit[2] __c_ZY;\nqubit[2] q;\nh q[0];\ncx q[0], q[1];\nsx q[0];\nrz(pi/2) q[0];\n__c_ZY[0] = measure q[0];\n__c_ZY[1] = measure q[1];\n",
 	}
-	ej.Process()
+	ej.Process(context.Background())
 
 	assert.Equal(t, len(ej.countsList), 2)
 }
@@ -109,7 +110,7 @@ func TestEstimationPostProcess(t *testing.T) {
 	// Input groupedOperators
 	ej.groupedOperators = "[[[\"XX\"], [\"ZY\"]], [[1.5], [1.2]]]"
 
-	actual_expval, actual_stds, err := EstimationPostProcess(ej, countsList)
+	actual_expval, actual_stds, err := EstimationPostProcess(context.Background(), ej, countsList)
 	assert.Nil(t, err)
 
 	clone := core.Estimation{}
@@ -152,7 +153,7 @@ func TestEstimationPostProcessMitigation(t *testing.T) {
 	// Input groupedOperators
 	ej.groupedOperators = "[[[\"XX\"], [\"ZY\"]], [[1.5], [1.2]]]"
 
-	ej.PostProcess()
+	ej.PostProcess(context.Background())
 
 	actual_expval := ej.JobData().Result.Estimation.Exp_value
 	actual_stds := ej.JobData().Result.Estimation.Stds
@@ -3,7 +3,6 @@ package estimation
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"runtime"
 	"sort"
@@ -29,7 +28,7 @@ func DEFAULT_BASIS_GATES() []string {
 	return []string{"sx", "rz", "cx"}
 }
 
-var ErrorJobIDConflict = errors.New("jobID is already used")
+var ErrorJobIDConflict = core.ErrorJobIDConflict
 
 type EstimationSetting struct {
 	Host       string   `toml:"host"`
@@ -106,8 +105,29 @@ func (j *EstimationJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
 	}
 }
 
-func (j *EstimationJob) PreProcess() {
-	if err := j.preProcessImpl(); err != nil {
+func (j *EstimationJob) Validate() error {
+	jd := j.JobData()
+	if err := core.ValidateShots(jd); err != nil {
+		return err
+	}
+	operators := []operator{}
+	if err := json.Unmarshal([]byte(jd.Info), &operators); err != nil {
+		zap.L().Info(fmt.Sprintf("invalid operator of job(%s)/reason:%s", jd.ID, err))
+		return fmt.Errorf("operator is not valid JSON: %w", err)
+	}
+	if len(operators) == 0 {
+		return fmt.Errorf("operator is empty")
+	}
+	for _, op := range operators {
+		if op.Pauli == "" {
+			return fmt.Errorf("pauli of operator is empty")
+		}
+	}
+	return nil
+}
+
+func (j *EstimationJob) PreProcess(ctx context.Context) {
+	if err := j.preProcessImpl(ctx); err != nil {
 		zap.L().Error(fmt.Sprintf("failed to pre-process a job(%s). Reason:%s",
 			j.JobData().ID, err.Error()))
 		core.SetFailureWithError(j, err)
@@ -117,7 +137,7 @@ func (j *EstimationJob) PreProcess() {
 	return
 }
 
-func (j *EstimationJob) preProcessImpl() (err error) {
+func (j *EstimationJob) preProcessImpl(ctx context.Context) (err error) {
 	err = nil
 	jd := j.JobData()
 	container := core.GetSystemComponents().Container
@@ -127,6 +147,7 @@ func (j *EstimationJob) preProcessImpl() (err error) {
 		})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to insert a job(%s). Reason:%s", jd.ID, err.Error()))
+		err = core.NewInternalError("failed to store the job", err)
 		return
 	}
 	zap.L().Debug(fmt.Sprintf("QASM:%s", jd.QASM))
@@ -134,10 +155,13 @@ func (j *EstimationJob) preProcessImpl() (err error) {
 		j.useTranspiler = true
 		err = container.Invoke(
 			func(t core.Transpiler) error {
-				return t.Transpile(j)
+				return core.Retry(ctx, j.JobData(), "transpiler", func(ctx context.Context) error {
+					return t.Transpile(ctx, j)
+				})
 			})
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("failed to transpile a job(%s). Reason:%s", jd.ID, err.Error()))
+			err = core.WithErrorKind(core.DependencyFailureError, err)
 			return
 		}
 		j.usedQASM = jd.TranspiledQASM
@@ -149,21 +173,26 @@ func (j *EstimationJob) preProcessImpl() (err error) {
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to serialize operators from :%s/reason:%s",
 			jd.Info, err.Error()))
-		return err
+		return core.NewUserInputError("operator is invalid", err)
 	}
 	zap.L().Debug(fmt.Sprintf("serialized operators:%s", sj))
 	j.origOperators = sj
 
-	qasmCodes, groupedOperators, err := estimationPreProcess(j)
+	qasmCodes, groupedOperators, err := estimationPreProcess(ctx, j)
 	j.preprocessedQASMs = qasmCodes
 	j.groupedOperators = groupedOperators
 
 	return
 }
 
-func (j *EstimationJob) Process() {
+func (j *EstimationJob) Process(ctx context.Context) {
 	c := core.GetSystemComponents().Container
 	for i := range j.preprocessedQASMs {
+		if ctx.Err() != nil {
+			zap.L().Info(fmt.Sprintf("stop sending circuits of job(%s) to QPU. Reason:%s", j.JobData().ID, context.Cause(ctx)))
+			j.finished = true
+			return
+		}
 		if j.useTranspiler {
 			j.jobData.TranspiledQASM = j.preprocessedQASMs[i]
 		} else {
@@ -171,11 +200,13 @@ func (j *EstimationJob) Process() {
 		}
 		err := c.Invoke(
 			func(q core.QPUManager) error {
-				return q.Send(j)
+				return core.Retry(ctx, j.JobData(), "qpu", func(ctx context.Context) error {
+					return q.Send(ctx, j)
+				})
 			})
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("failed to send a job(%s) to QPU. Reason:%s", j.JobData().ID, err.Error()))
-			j.JobData().Status = core.FAILED
+			core.SetFailureWithError(j, core.WithErrorKind(core.DeviceUnavailableError, err))
 			j.finished = true
 			return
 		}
@@ -189,7 +220,7 @@ func (j *EstimationJob) Process() {
 	zap.L().Debug(fmt.Sprintf("PostProcess goroutine for job(%s) is started", j.JobData().ID))
 }
 
-func (j *EstimationJob) PostProcess() {
+func (j *EstimationJob) PostProcess(ctx context.Context) {
 	j.finished = true
 	countsList := []*pb.Counts{}
 	m := mitig.NewMitigationInfoFromJobData(j.JobData())
@@ -215,7 +246,7 @@ func (j *EstimationJob) PostProcess() {
 			zap.L().Debug(fmt.Sprintf("JobID:%s - Applying pseudo inverse mitigation in Estimation PostProcess for counts index %d", j.JobData().ID, i))
 			tempJobData := j.jobData.Clone()
 			tempJobData.Result.Counts = j.countsList[i]
-			mitig.PseudoInverseMitigation(tempJobData)
+			mitig.PseudoInverseMitigation(ctx, tempJobData)
 			counts = pb.Counts{Counts: tempJobData.Result.Counts}
 		} else {
 			zap.L().Debug(fmt.Sprintf("JobID:%s - Skipping pseudo inverse mitigation in Estimation PostProcess for counts index %d", j.JobData().ID, i))
@@ -223,7 +254,7 @@ func (j *EstimationJob) PostProcess() {
 		}
 		countsList = append(countsList, &counts)
 	}
-	exp_value, stds, err := EstimationPostProcess(j, countsList)
+	exp_value, stds, err := EstimationPostProcess(ctx, j, countsList)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to post-process a job(%s). Reason:%s",
 			j.JobData().ID, err.Error()))
@@ -235,12 +266,12 @@ func (j *EstimationJob) PostProcess() {
 	j.jobData.Result.Estimation.Exp_value = exp_value
 	j.jobData.Result.Estimation.Stds = stds
 	zap.L().Debug(fmt.Sprintf("exp_value:%f, stds:%f\n", j.jobData.Result.Estimation.Exp_value, j.jobData.Result.Estimation.Stds))
-	j.JobData().Status = core.SUCCEEDED
+	j.JobData().SetStatus(core.SUCCEEDED)
 	return
 }
 
 func (j *EstimationJob) IsFinished() bool {
-	return j.finished
+	return j.finished || j.JobData().Status == core.FAILED || j.JobData().Status == core.CANCELLED
 }
 
 func (j *EstimationJob) JobData() *core.JobData {
@@ -267,7 +298,7 @@ func (j *EstimationJob) Clone() core.Job {
 	return cloned
 }
 
-func estimationPreProcess(j *EstimationJob) (preprocessedQASMs []string, groupedOperators string, err error) {
+func estimationPreProcess(ctx context.Context, j *EstimationJob) (preprocessedQASMs []string, groupedOperators string, err error) {
 	zap.L().Debug(fmt.Sprintf("start EstimationJob PreProcessing for %s", j.JobData().ID))
 
 	opts := grpc.WithTransportCredentials(insecure.NewCredentials())
@@ -279,26 +310,9 @@ func estimationPreProcess(j *EstimationJob) (preprocessedQASMs []string, grouped
 
 	client := pb.NewEstimationJobServiceClient(conn)
 
-	mappingList := []uint32{}
-	mapping := map[uint32]uint32{}
-	if j.JobData().NeedTranspiling() {
-		mapping, err = j.JobData().Result.TranspilerInfo.VirtualPhysicalMappingRaw.ToMap()
-		if err != nil {
-			zap.L().Error(fmt.Sprintf("failed to convert VirtualPhysicalMappingRaw to map/reason:%s", err))
-			return nil, "", err
-		}
-	}
-
-	keys := []uint32{}
-	for k := range mapping {
-		keys = append(keys, k)
-	}
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] < keys[j]
-	})
-
-	for _, key := range keys {
-		mappingList = append(mappingList, mapping[key])
+	mappingList, err := toMappingList(j.JobData())
+	if err != nil {
+		return nil, "", err
 	}
 	zap.L().Debug(fmt.Sprintf("mappingList:%v", mappingList))
 	// Log VirtualPhysicalMapping as a map for better readability
@@ -317,20 +331,49 @@ func estimationPreProcess(j *EstimationJob) (preprocessedQASMs []string, grouped
 		MappingList: mappingList,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	res, err := client.ReqEstimationPreProcess(ctx, req)
+	var res *pb.ReqEstimationPreProcessResponse
+	err = core.Retry(ctx, j.JobData(), "estimator", func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		var reqErr error
+		res, reqErr = client.ReqEstimationPreProcess(ctx, req)
+		return reqErr
+	})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("could not request: %v/host:%s/port:%s",
 			err, j.setting.Host, j.setting.Port))
-		j.JobData().Status = core.FAILED
+		err = core.WithErrorKind(core.DependencyFailureError, err)
 		return
 	}
 	return res.QasmCodes, res.GroupedOperators, err
 }
 
-func EstimationPostProcess(j *EstimationJob, countsList []*pb.Counts) (exp_value float32, stds float32, err error) {
+// toMappingList returns the physical qubits of the virtual qubits in order. The mapping is given by
+// the transpiler, or by the step of a workflow whose transpiled program is used without transpiling.
+func toMappingList(jd *core.JobData) ([]uint32, error) {
+	mappingList := []uint32{}
+	if !jd.NeedTranspiling() && len(jd.Result.TranspilerInfo.VirtualPhysicalMappingRaw) == 0 {
+		return mappingList, nil
+	}
+	mapping, err := jd.Result.TranspilerInfo.VirtualPhysicalMappingRaw.ToMap()
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to convert VirtualPhysicalMappingRaw to map/reason:%s", err))
+		return nil, core.NewInternalError("failed to get the qubit mapping", err)
+	}
+	keys := []uint32{}
+	for k := range mapping {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		mappingList = append(mappingList, mapping[key])
+	}
+	return mappingList, nil
+}
+
+func EstimationPostProcess(ctx context.Context, j *EstimationJob, countsList []*pb.Counts) (exp_value float32, stds float32, err error) {
 	zap.L().Debug(fmt.Sprintf("start EstimationJob PostProcessing for %s", j.JobData().ID))
 
 	opts := grpc.WithTransportCredentials(insecure.NewCredentials())
@@ -347,15 +390,20 @@ func EstimationPostProcess(j *EstimationJob, countsList []*pb.Counts) (exp_value
 		GroupedOperators: j.groupedOperators,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	res, err := client.ReqEstimationPostProcess(ctx, req)
+	var res *pb.ReqEstimationPostProcessResponse
+	err = core.Retry(ctx, j.JobData(), "estimator", func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		var reqErr error
+		res, reqErr = client.ReqEstimationPostProcess(ctx, req)
+		return reqErr
+	})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("could not request: %v", err))
 		buf := make([]byte, 1024)
 		runtime.Stack(buf, false)
 		zap.L().Error(fmt.Sprintf("stack trace: %s", string(buf)))
+		err = core.WithErrorKind(core.DependencyFailureError, err)
 		return
 	}
 
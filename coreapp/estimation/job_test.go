@@ -0,0 +1,49 @@
+//go:build unit
+// +build unit
+
+package estimation
+
+import (
+	"testing"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMappingList(t *testing.T) {
+	lib := "qiskit"
+	tests := []struct {
+		name       string
+		transpiler *core.TranspilerConfig
+		mapping    core.VirtualPhysicalMappingRaw
+		want       []uint32
+	}{
+		{
+			name:       "transpiled",
+			transpiler: &core.TranspilerConfig{TranspilerLib: &lib},
+			mapping:    core.VirtualPhysicalMappingRaw(`{"1":2,"0":3}`),
+			want:       []uint32{3, 2},
+		},
+		{
+			name:       "mapped without transpiling",
+			transpiler: &core.TranspilerConfig{TranspilerLib: nil},
+			mapping:    core.VirtualPhysicalMappingRaw(`{"0":3,"1":2}`),
+			want:       []uint32{3, 2},
+		},
+		{
+			name:       "not mapped",
+			transpiler: &core.TranspilerConfig{TranspilerLib: nil},
+			want:       []uint32{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := core.NewJobData()
+			jd.Transpiler = tt.transpiler
+			jd.Result.TranspilerInfo.VirtualPhysicalMappingRaw = tt.mapping
+			got, err := toMappingList(jd)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package filedrop
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/oas"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+const FileDropTaskName = "file_drop"
+
+const (
+	DEFAULT_INBOX_DIR     = "./shares/jobs/in"
+	DEFAULT_SETTLE_PERIOD = time.Second
+	// the files which have not become jobs are moved to this directory in the inbox
+	FAILED_DIR = "failed"
+)
+
+// FileDropTaskImpl periodically submits the jobs dropped in the inbox directory, for the engine
+// without a route to the cloud. Each file has a submitted job in the JobsJobDef schema of the provider
+// API, which is accepted in READY like a job of the REST server. The file of a job handled by the
+// scheduler is removed, and the job is written by the DB, e.g. the file DB writing the results in
+// the same schema.
+//
+// A job file should be written under another name, e.g. job1.json.tmp, and renamed to *.json, so that
+// it appears at once. A *.json file is read after it has not been modified for the settle period,
+// so that a file being written in place is not read partially.
+type FileDropTaskImpl struct {
+	InboxDir     string        `toml:"inbox_dir"`
+	SettlePeriod time.Duration `toml:"settle_period"`
+
+	core.DefaultTaskImpl
+}
+
+func (f *FileDropTaskImpl) GetEmptyParams() interface{} {
+	return f
+}
+
+func (f *FileDropTaskImpl) SetParams(p interface{}) error {
+	f.InboxDir = DEFAULT_INBOX_DIR
+	f.SettlePeriod = DEFAULT_SETTLE_PERIOD
+	if p == nil {
+		zap.L().Debug("no params for file drop task")
+		return nil
+	}
+	mp, ok := p.(map[string]interface{})
+	if !ok {
+		msg := fmt.Errorf("failed to set params for file drop task/params: %s", p)
+		zap.L().Error(msg.Error())
+		return msg
+	}
+	if inboxDir, ok := mp["inbox_dir"].(string); ok && inboxDir != "" {
+		f.InboxDir = inboxDir
+	}
+	if settlePeriod, ok := mp["settle_period"].(string); ok && settlePeriod != "" {
+		d, err := time.ParseDuration(settlePeriod)
+		if err != nil {
+			msg := fmt.Errorf("failed to parse settle_period for file drop task/reason:%s", err)
+			zap.L().Error(msg.Error())
+			return msg
+		}
+		f.SettlePeriod = d
+	}
+	return nil
+}
+
+func (f *FileDropTaskImpl) Setup() error {
+	if f.InboxDir == "" {
+		f.InboxDir = DEFAULT_INBOX_DIR
+	}
+	if err := os.MkdirAll(filepath.Join(f.InboxDir, FAILED_DIR), 0755); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to make %s/reason:%s", f.InboxDir, err))
+		return err
+	}
+	zap.L().Info(fmt.Sprintf("watching %s for job files", f.InboxDir))
+	return nil
+}
+
+// Task submits the job files in the order of their names while the queues of their devices have
+// free slots. The files left are submitted in the next period.
+func (f *FileDropTaskImpl) Task() {
+	entries, err := os.ReadDir(f.InboxDir)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to read %s/reason:%s", f.InboxDir, err))
+		return
+	}
+	s := core.GetSystemComponents()
+	now := time.Now()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			// e.g. removed after being listed
+			zap.L().Debug(fmt.Sprintf("failed to stat %s/reason:%s", e.Name(), err))
+			continue
+		}
+		if now.Sub(info.ModTime()) < f.SettlePeriod {
+			zap.L().Debug(fmt.Sprintf("%s may be being written. read in the next period", e.Name()))
+			continue
+		}
+		if !f.submit(s, filepath.Join(f.InboxDir, e.Name())) {
+			return
+		}
+	}
+}
+
+// submit returns false when the engine accepts no job, so that the files left are submitted in the next
+// period. The file of the job refused for the state of the engine, e.g. the full queue of its device,
+// is left in the inbox to be submitted again.
+func (f *FileDropTaskImpl) submit(s *core.SystemComponents, path string) bool {
+	j, err := readJob(path)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("invalid job file %s/reason:%s", path, err))
+		f.moveToFailed(path)
+		return true
+	}
+	jd := j.JobData()
+	// the job for the device not hosted is refused by the scheduler and moved to the failed jobs
+	if s.HostsDevice(jd.DeviceID) && s.GetQueueFreeSlotsOf(jd.DeviceID) <= 0 {
+		zap.L().Debug(fmt.Sprintf("no free slot in the queue of %s. %s is read in the next period. current queue size:%d",
+			jd.DeviceID, path, s.GetCurrentQueueSizeOf(jd.DeviceID)))
+		return true
+	}
+	if err := s.Invoke(func(d core.DBManager) error { return d.Insert(j) }); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to insert job(%s) of %s/reason:%s", jd.ID, path, err))
+		f.moveToFailed(path)
+		return true
+	}
+	if err := s.Invoke(func(sc core.Scheduler) error { return sc.HandleJob(j) }); err != nil {
+		zap.L().Info(fmt.Sprintf("the job(%s) of %s is refused. Reason:%s", jd.ID, path, err))
+		s.Invoke(func(d core.DBManager) error { return d.Delete(jd.ID) })
+		if core.IsReofferedRefusal(err) {
+			// submitted in the next period, or by the next run of the engine when draining
+			return !errors.Is(err, core.ErrorDraining)
+		}
+		f.moveToFailed(path)
+		return true
+	}
+	zap.L().Info(fmt.Sprintf("submitted job(%s) of %s", jd.ID, path))
+	if err := os.Remove(path); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to remove %s/reason:%s", path, err))
+	}
+	return true
+}
+
+func (f *FileDropTaskImpl) moveToFailed(path string) {
+	to := filepath.Join(f.InboxDir, FAILED_DIR, filepath.Base(path))
+	if err := os.Rename(path, to); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to move %s to %s/reason:%s", path, to, err))
+	}
+}
+
+func readJob(path string) (core.Job, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	def := &api.JobsJobDef{}
+	if err := def.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	if len(def.JobInfo.Program) == 0 {
+		return nil, fmt.Errorf("no program in job_info")
+	}
+	// the job is submitted to the engine by the file, like a job submitted to the REST server
+	if def.Status != api.JobsJobStatusSubmitted && def.Status != api.JobsJobStatusReady {
+		return nil, fmt.Errorf("the job(%s) in %s is not submitted", def.JobID, def.Status)
+	}
+	now := time.Now()
+	if _, ok := def.SubmittedAt.Get(); !ok {
+		def.SubmittedAt.SetTo(now)
+	}
+	def.Status = api.JobsJobStatusReady
+	def.ReadyAt.SetTo(now)
+	jd := oas.ConvertFromCloudJob(def)
+	jc, err := core.NewJobContext()
+	if err != nil {
+		return nil, err
+	}
+	return core.GetJobManager().NewJobFromJobDataWithValidation(jd, jc)
+}
@@ -0,0 +1,190 @@
+//go:build unit
+// +build unit
+
+package filedrop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/db"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func droppedJob(jobID string, status string) string {
+	return fmt.Sprintf(`{"job_id":"%s","device_id":"device1","shots":1000,"job_type":"sampling",`+
+		`"job_info":{"program":["OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\n"]},`+
+		`"transpiler_info":{"transpiler_lib":null},"status":"%s","submitted_at":"2024-04-01T09:00:00Z"}`,
+		jobID, status)
+}
+
+func TestFileDropTask(t *testing.T) {
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+	dbDir := filepath.Join(t.TempDir(), "db")
+	s := core.SCWithSchedulerAndDB(&scheduler.NormalScheduler{}, &db.FileDB{},
+		&core.Conf{QueueMaxSize: 1000, FileDBDir: dbDir})
+	defer s.TearDown()
+	defer s.Stop()
+	// the handlers of the jobs read the job manager, which is replaced by the next run of the test
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		s.Invoke(func(sc core.Scheduler) {
+			assert.Nil(t, sc.Drain(ctx))
+		})
+	}()
+	assert.Nil(t, s.StartContainer())
+
+	inbox := filepath.Join(t.TempDir(), "in")
+	f := &FileDropTaskImpl{}
+	assert.Nil(t, f.SetParams(map[string]interface{}{"inbox_dir": inbox, "settle_period": "1m"}))
+	assert.Nil(t, f.Setup())
+	settled := map[string]string{
+		"a.json":     droppedJob("job1", "submitted"),
+		"b.json":     "{broken",
+		"c.json":     `{"job_id":"job3","shots":1000,"job_type":"sampling","job_info":{"program":[]},"status":"submitted"}`,
+		"d.json.tmp": droppedJob("job4", "submitted"),
+		"e.json":     droppedJob("job5", "succeeded"),
+	}
+	past := time.Now().Add(-time.Hour)
+	for name, content := range settled {
+		path := filepath.Join(inbox, name)
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+		assert.Nil(t, os.Chtimes(path, past, past))
+	}
+	// the file being written is read in the next period
+	assert.Nil(t, os.WriteFile(filepath.Join(inbox, "f.json"), []byte(`{"job_id":"job6",`), 0644))
+	f.Task()
+
+	// the dropped job is processed and written to the file DB
+	assert.Eventually(t, func() bool {
+		var j core.Job
+		err := s.Invoke(func(d core.DBManager) (err error) {
+			j, err = d.Get("job1")
+			return
+		})
+		return err == nil && j.JobData().Status == core.SUCCEEDED
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.FileExists(t, filepath.Join(dbDir, "job1.json"))
+
+	// the submitted file is removed and the invalid files are kept for the investigation
+	left, err := filepath.Glob(filepath.Join(inbox, "*"))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(inbox, FAILED_DIR),
+		filepath.Join(inbox, "d.json.tmp"),
+		filepath.Join(inbox, "f.json"),
+	}, left)
+	failed, err := filepath.Glob(filepath.Join(inbox, FAILED_DIR, "*"))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(inbox, FAILED_DIR, "b.json"),
+		filepath.Join(inbox, FAILED_DIR, "c.json"),
+		filepath.Join(inbox, FAILED_DIR, "e.json"),
+	}, failed)
+}
+
+// refusingScheduler refuses every job with err while it has free slots
+type refusingScheduler struct {
+	core.Scheduler
+	free    int
+	err     error
+	handled int
+}
+
+func (r *refusingScheduler) Setup(*core.Conf) error   { return nil }
+func (r *refusingScheduler) GetQueueFreeSlots() int   { return r.free }
+func (r *refusingScheduler) GetCurrentQueueSize() int { return 0 }
+func (r *refusingScheduler) HandleJob(core.Job) error {
+	r.handled++
+	return r.err
+}
+
+func TestFileDropTaskRefusedJob(t *testing.T) {
+	tests := []struct {
+		name        string
+		free        int
+		err         error
+		wantHandled int
+		wantFailed  bool
+	}{
+		{
+			name:        "full queue of the device",
+			free:        0,
+			wantHandled: 0,
+		},
+		{
+			name:        "refused for the full queue",
+			free:        1,
+			err:         core.NewDeviceUnavailableError("the queue is full", core.ErrorQueueFull),
+			wantHandled: 2,
+		},
+		{
+			name:        "refused for the active job",
+			free:        1,
+			err:         core.ErrorJobIDConflict,
+			wantHandled: 2,
+		},
+		{
+			name:        "refused while draining",
+			free:        1,
+			err:         core.NewDeviceUnavailableError("the engine is draining", core.ErrorDraining),
+			wantHandled: 1,
+		},
+		{
+			name:        "device not hosted",
+			free:        1,
+			err:         core.NewUserInputError("the device is not hosted", core.ErrorUnknownDevice),
+			wantHandled: 2,
+			wantFailed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &refusingScheduler{free: tt.free, err: tt.err}
+			s := core.SCWithSchedulerAndDB(sc, &core.MemoryDB{}, &core.Conf{QueueMaxSize: 1000})
+			defer s.TearDown()
+			_, err := core.NewJobManager(&sampling.SamplingJob{})
+			assert.Nil(t, err)
+
+			inbox := filepath.Join(t.TempDir(), "in")
+			f := &FileDropTaskImpl{InboxDir: inbox}
+			assert.Nil(t, f.Setup())
+			past := time.Now().Add(-time.Hour)
+			for name, jobID := range map[string]string{"a.json": "job1", "b.json": "job2"} {
+				path := filepath.Join(inbox, name)
+				assert.Nil(t, os.WriteFile(path, []byte(droppedJob(jobID, "submitted")), 0644))
+				assert.Nil(t, os.Chtimes(path, past, past))
+			}
+			f.Task()
+
+			// the refused job is not left in the DB
+			assert.Equal(t, tt.wantHandled, sc.handled)
+			err = s.Invoke(func(d core.DBManager) error {
+				_, err := d.Get("job1")
+				return err
+			})
+			assert.NotNil(t, err)
+			left, err := filepath.Glob(filepath.Join(inbox, "*.json"))
+			assert.Nil(t, err)
+			failed, err := filepath.Glob(filepath.Join(inbox, FAILED_DIR, "*.json"))
+			assert.Nil(t, err)
+			if tt.wantFailed {
+				assert.Empty(t, left)
+				assert.Len(t, failed, 2)
+			} else {
+				// the files are submitted again in the next period
+				assert.Len(t, left, 2)
+				assert.Empty(t, failed)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ require (
 	github.com/opencontainers/image-spec v1.1.0
 	github.com/stretchr/testify v1.10.0
 	github.com/tidwall/pretty v1.2.1
+	go.etcd.io/bbolt v1.3.11
 	go.opentelemetry.io/otel v1.33.0
 	go.opentelemetry.io/otel/metric v1.33.0
 	go.opentelemetry.io/otel/trace v1.33.0
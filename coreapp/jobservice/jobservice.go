@@ -0,0 +1,192 @@
+package jobservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/common"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	jint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/job/job_interface/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const JOB_SERVICE_SETTING_KEY = "job_service"
+
+const (
+	// the provider API of the cloud
+	PROTOCOL_REST = "rest"
+	// the JobService of spec/proto/job_interface
+	PROTOCOL_GRPC = "grpc"
+)
+
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+// JobServiceSetting is the protocol to get the jobs from the cloud and to update the jobs and the device
+// in [com.job_service]. The poller, the service DB and the gateway use the JobService at host:port
+// instead of the provider API when the protocol is grpc.
+type JobServiceSetting struct {
+	Protocol string        `toml:"protocol"`
+	Host     string        `toml:"host"`
+	Port     string        `toml:"port"`
+	Timeout  time.Duration `toml:"timeout"`
+}
+
+func NewJobServiceSetting() JobServiceSetting {
+	return JobServiceSetting{
+		Protocol: PROTOCOL_REST,
+		Host:     "localhost",
+		Port:     "50053",
+		Timeout:  DEFAULT_TIMEOUT,
+	}
+}
+
+func GetJobServiceSetting() JobServiceSetting {
+	return core.GetSetting(JOB_SERVICE_SETTING_KEY, NewJobServiceSetting())
+}
+
+// UseGRPC is true when the JobService is used instead of the provider API.
+func UseGRPC() bool {
+	return GetJobServiceSetting().Protocol == PROTOCOL_GRPC
+}
+
+// Client calls the JobService of the cloud.
+type Client struct {
+	address string
+	timeout time.Duration
+	conn    *grpc.ClientConn
+	client  jint.JobServiceClient
+}
+
+func NewClient(s JobServiceSetting) (*Client, error) {
+	address, err := common.ValidAddress(s.Host, s.Port)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to make connection to %s/reason:%s", address, err))
+		return nil, err
+	}
+	zap.L().Info(fmt.Sprintf("job service client is ready to use %s", address))
+	return NewClientWithConn(address, s.Timeout, conn), nil
+}
+
+func NewClientWithConn(address string, timeout time.Duration, conn *grpc.ClientConn) *Client {
+	return &Client{
+		address: address,
+		timeout: timeout,
+		conn:    conn,
+		client:  jint.NewJobServiceClient(conn),
+	}
+}
+
+func (c *Client) Close() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+func (c *Client) GetJobs(deviceID string, status string, maxResults int) ([]*jint.Job, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	res, err := c.client.GetJobs(ctx, &jint.GetJobsRequest{
+		DeviceId:   deviceID,
+		Status:     status,
+		MaxResults: uint32(maxResults),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs from %s: %w", c.address, err)
+	}
+	if err := checkReturnCode("GetJobs", res.GetReturnCode(), res.GetMessage()); err != nil {
+		return nil, err
+	}
+	return res.GetJob(), nil
+}
+
+// GetJobsByID gets the jobs of the IDs in the status, e.g. to find the cancelled jobs among the jobs in flight.
+func (c *Client) GetJobsByID(deviceID string, status string, jobIDs []string) ([]*jint.Job, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	res, err := c.client.GetJobs(ctx, &jint.GetJobsRequest{
+		DeviceId:   deviceID,
+		Status:     status,
+		MaxResults: uint32(len(jobIDs)),
+		JobIds:     jobIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs from %s: %w", c.address, err)
+	}
+	if err := checkReturnCode("GetJobs", res.GetReturnCode(), res.GetMessage()); err != nil {
+		return nil, err
+	}
+	return res.GetJob(), nil
+}
+
+func (c *Client) UpdateJobInfo(req *jint.UpdateJobInfoRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	res, err := c.client.UpdateJobInfo(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update the job info of %s: %w", req.GetJobId(), err)
+	}
+	return checkReturnCode("UpdateJobInfo", res.GetReturnCode(), res.GetMessage())
+}
+
+func (c *Client) UpdateDeviceStatus(deviceID string, status string, availableAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	req := &jint.UpdateDeviceStatusRequest{
+		DeviceId: deviceID,
+		Status:   status,
+	}
+	if availableAt != nil {
+		req.AvailableAt = FormatTime(*availableAt)
+	}
+	res, err := c.client.UpdateDeviceStatus(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update the status of %s: %w", deviceID, err)
+	}
+	return checkReturnCode("UpdateDeviceStatus", res.GetReturnCode(), res.GetMessage())
+}
+
+func (c *Client) UpdateDeviceInfo(deviceID string, deviceInfo string, calibratedAt string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	res, err := c.client.UpdateDeviceInfo(ctx, &jint.UpdateDeviceInfoRequest{
+		DeviceId:     deviceID,
+		DeviceInfo:   deviceInfo,
+		CalibratedAt: calibratedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the info of %s: %w", deviceID, err)
+	}
+	return checkReturnCode("UpdateDeviceInfo", res.GetReturnCode(), res.GetMessage())
+}
+
+// checkReturnCode returns an error when the return code is not zero.
+func checkReturnCode(rpc string, code uint32, msg string) error {
+	if code != 0 {
+		return fmt.Errorf("%s returned %d/message:%s", rpc, code, msg)
+	}
+	return nil
+}
+
+// FormatTime formats the time in RFC 3339, which the times of the JobService are in.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ParseTime parses the time of the JobService. ok is false when the time is empty.
+func ParseTime(s string) (t time.Time, ok bool, err error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	t, err = time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
@@ -0,0 +1,111 @@
+//go:build unit
+// +build unit
+
+package jobservice
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	jint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/job/job_interface/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type jobServiceForTest struct {
+	jint.UnimplementedJobServiceServer
+	getJobs      *jint.GetJobsRequest
+	deviceStatus *jint.UpdateDeviceStatusRequest
+}
+
+func (s *jobServiceForTest) GetJobs(_ context.Context, req *jint.GetJobsRequest) (*jint.GetJobsResponse, error) {
+	s.getJobs = req
+	return &jint.GetJobsResponse{
+		Job: []*jint.Job{{JobId: "job1", Status: req.Status, DeviceId: req.DeviceId}},
+	}, nil
+}
+
+func (s *jobServiceForTest) UpdateJobInfo(_ context.Context, req *jint.UpdateJobInfoRequest) (*jint.UpdateJobInfoResponse, error) {
+	return &jint.UpdateJobInfoResponse{ReturnCode: 1, Message: "unknown job " + req.JobId}, nil
+}
+
+func (s *jobServiceForTest) UpdateDeviceStatus(_ context.Context, req *jint.UpdateDeviceStatusRequest) (*jint.UpdateDeviceStatusResponse, error) {
+	s.deviceStatus = req
+	return &jint.UpdateDeviceStatusResponse{}, nil
+}
+
+func newClientForTest(t *testing.T, srv jint.JobServiceServer) *Client {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	jint.RegisterJobServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	c := NewClientWithConn("bufnet", time.Second, conn)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestClient(t *testing.T) {
+	srv := &jobServiceForTest{}
+	c := newClientForTest(t, srv)
+
+	jobs, err := c.GetJobs("device1", "submitted", 10)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "job1", jobs[0].GetJobId())
+	assert.Equal(t, uint32(10), srv.getJobs.GetMaxResults())
+
+	err = c.UpdateJobInfo(&jint.UpdateJobInfoRequest{JobId: "job2"})
+	assert.EqualError(t, err, "UpdateJobInfo returned 1/message:unknown job job2")
+
+	availableAt := time.Date(2099, 1, 5, 12, 0, 0, 0, time.UTC)
+	assert.Nil(t, c.UpdateDeviceStatus("device1", "unavailable", &availableAt))
+	assert.Equal(t, "2099-01-05T12:00:00Z", srv.deviceStatus.GetAvailableAt())
+
+	// not implemented in the server
+	assert.NotNil(t, c.UpdateDeviceInfo("device1", "{}", ""))
+}
+
+func TestJobServiceSetting(t *testing.T) {
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(JOB_SERVICE_SETTING_KEY, NewJobServiceSetting())
+	assert.False(t, UseGRPC())
+
+	core.RegisterSetting(JOB_SERVICE_SETTING_KEY, map[string]interface{}{
+		"protocol": "grpc",
+		"port":     "50100",
+		"timeout":  "3s",
+	})
+	s := GetJobServiceSetting()
+	assert.True(t, UseGRPC())
+	assert.Equal(t, "localhost", s.Host)
+	assert.Equal(t, "50100", s.Port)
+	assert.Equal(t, 3*time.Second, s.Timeout)
+}
+
+func TestParseTime(t *testing.T) {
+	_, ok, err := ParseTime("")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	at := time.Date(2024, 4, 1, 9, 30, 0, 500, time.UTC)
+	parsed, ok, err := ParseTime(FormatTime(at))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, at.Equal(parsed))
+
+	_, _, err = ParseTime("yesterday")
+	assert.NotNil(t, err)
+}
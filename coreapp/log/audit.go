@@ -0,0 +1,122 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/common"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const AuditLogServerName = "audit_log"
+
+// AuditLogServerImpl writes every lifecycle event of the jobs to the daily audit log files
+// as JSON lines.
+type AuditLogServerImpl struct {
+	FileDir string `toml:"file_dir"`
+
+	dl   *dailyLogger
+	sub  *core.Subscription
+	stop chan struct{}
+	done chan struct{}
+}
+
+type auditRecord struct {
+	Time      time.Time      `json:"time"`
+	Event     core.EventType `json:"event"`
+	JobID     string         `json:"job_id"`
+	JobType   string         `json:"job_type"`
+	Status    string         `json:"status"`
+	ErrorKind core.ErrorKind `json:"error_kind,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+func (a *AuditLogServerImpl) Setup() error {
+	if err := common.IsDirWritable(a.FileDir); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to write to %s/reason:%s", a.FileDir, err))
+		return err
+	}
+	a.dl = newDailyLogger(a.FileDir, "audit")
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	// when the writing is too slow, the pending events of a job are coalesced
+	// so that at least the latest state of every job is written
+	a.sub = core.GetSystemComponents().EventBus.Subscribe(
+		AuditLogServerName, core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	return nil
+}
+
+func (a *AuditLogServerImpl) GetEmptyParams() interface{} {
+	return a
+}
+
+func (a *AuditLogServerImpl) SetParams(p interface{}) error {
+	if p == nil {
+		zap.L().Debug("no params for audit log server")
+		return nil
+	}
+	mp, ok := p.(map[string]interface{})
+	if !ok {
+		msg := fmt.Errorf("failed to set params for audit log server/params: %s", p)
+		zap.L().Error(msg.Error())
+		return msg
+	}
+	if fileDir, ok := mp["file_dir"].(string); ok {
+		a.FileDir = fileDir
+	}
+	return nil
+}
+
+func (a *AuditLogServerImpl) Start() error {
+	go func() {
+		defer close(a.done)
+		for {
+			select {
+			case e, ok := <-a.sub.Events():
+				if !ok {
+					return
+				}
+				a.write(e)
+			case <-a.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (a *AuditLogServerImpl) Cleanup() {
+	close(a.stop)
+	<-a.done
+	a.dl.Close()
+}
+
+// Handle is not used, because the audit log receives the jobs as events.
+func (a *AuditLogServerImpl) Handle(core.Job) error {
+	return nil
+}
+
+func (a *AuditLogServerImpl) write(e core.JobEvent) {
+	jd := e.Job.JobData()
+	r := auditRecord{
+		Time:    e.Time,
+		Event:   e.Type,
+		JobID:   jd.ID,
+		JobType: jd.JobType,
+		Status:  jd.Status.String(),
+	}
+	if jd.Status == core.FAILED {
+		r.ErrorKind = jd.Result.ErrorKind
+		r.Message = core.FailureMessage(jd)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to marshal the audit record of job(%s)/reason:%s", jd.ID, err))
+		return
+	}
+	if _, err := a.dl.Write(append(b, '\n')); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to write the audit record of job(%s)/reason:%s", jd.ID, err))
+	}
+}
@@ -0,0 +1,87 @@
+//go:build unit
+// +build unit
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAuditRecords(t *testing.T, dir string) []auditRecord {
+	paths, err := filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	assert.Nil(t, err)
+	records := []auditRecord{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		assert.Nil(t, err)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			r := auditRecord{}
+			assert.Nil(t, json.Unmarshal(scanner.Bytes(), &r))
+			records = append(records, r)
+		}
+		f.Close()
+	}
+	return records
+}
+
+func TestAuditLog(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	dir := t.TempDir()
+	a := &AuditLogServerImpl{}
+	assert.Nil(t, a.SetParams(map[string]interface{}{"file_dir": dir}))
+	assert.Nil(t, a.Setup())
+	assert.Nil(t, a.Start())
+	defer a.Cleanup()
+
+	jd := core.NewJobData()
+	jd.ID = "job1"
+	jd.JobType = "sampling"
+	jd.Status = core.READY
+	jc, err := core.NewJobContext()
+	assert.Nil(t, err)
+	j := (&core.NormalJob{}).New(jd, jc)
+	publish := func(et core.EventType, st core.Status, n int) {
+		jd.Status = st
+		s.EventBus.Publish(et, j)
+		// the pending events of a job are coalesced, so that each event is written before the next one
+		assert.Eventually(t, func() bool { return len(readAuditRecords(t, dir)) == n },
+			time.Second, 10*time.Millisecond)
+	}
+	publish(core.JobQueued, core.READY, 1)
+	publish(core.JobRunning, core.RUNNING, 2)
+	jd.Result.ErrorKind = core.DeviceUnavailableError
+	publish(core.JobFailed, core.FAILED, 3)
+
+	records := readAuditRecords(t, dir)
+	assert.Equal(t, []core.EventType{core.JobQueued, core.JobRunning, core.JobFailed},
+		[]core.EventType{records[0].Event, records[1].Event, records[2].Event})
+	for _, r := range records {
+		assert.Equal(t, "job1", r.JobID)
+		assert.Equal(t, "sampling", r.JobType)
+		assert.False(t, r.Time.IsZero())
+	}
+	assert.Equal(t, core.READY.String(), records[0].Status)
+	assert.Equal(t, core.RUNNING.String(), records[1].Status)
+	assert.Empty(t, records[1].ErrorKind)
+	assert.Empty(t, records[1].Message)
+	// the failure is recorded with its kind and the message for the user
+	assert.Equal(t, core.FAILED.String(), records[2].Status)
+	assert.Equal(t, core.DeviceUnavailableError, records[2].ErrorKind)
+	assert.Equal(t, core.FailureMessage(jd), records[2].Message)
+	assert.NotEmpty(t, records[2].Message)
+}
+
+func TestAuditLogSetupWithUnwritableDir(t *testing.T) {
+	a := &AuditLogServerImpl{FileDir: filepath.Join(t.TempDir(), "missing")}
+	assert.NotNil(t, a.Setup())
+}
@@ -15,12 +15,21 @@ import (
 
 const MetricsLogTaskName = "metrics_log"
 const queueLengthKeyInMetrics = "queue_length"
+const failuresKeyInMetrics = "failures"
+const eventsKeyInMetrics = "events"
+const droppedEventsKeyInMetrics = "dropped_events"
+const duplicateJobsKeyInMetrics = "duplicate_jobs"
+const workerPoolsKeyInMetrics = "worker_pools"
 
 type MetricsLogTaskImpl struct {
 	FileDir string `toml:"file_dir"`
 
-	dl *dailyLogger
-	sc *core.SystemComponents
+	dl  *dailyLogger
+	sc  *core.SystemComponents
+	sub *core.Subscription
+
+	mu          sync.Mutex
+	eventCounts map[core.EventType]uint64
 
 	core.DefaultTaskImpl
 }
@@ -29,7 +38,7 @@ func setupMetricsLogTask(fileDir string) (*dailyLogger, error) {
 	if err := common.IsDirWritable(fileDir); err != nil {
 		return nil, fmt.Errorf("failed to write to %s: %w", fileDir, err)
 	}
-	newDailyLogger := newDailyLogger(fileDir)
+	newDailyLogger := newDailyLogger(fileDir, "metrics")
 	slog.SetDefault(slog.New(slog.NewJSONHandler(newDailyLogger, nil)))
 	return newDailyLogger, nil
 }
@@ -43,6 +52,16 @@ func (m *MetricsLogTaskImpl) Setup() error {
 	sc := core.GetSystemComponents()
 	m.dl = dl
 	m.sc = sc
+	m.eventCounts = make(map[core.EventType]uint64)
+	// the metrics can lose events rather than delay the other subscribers
+	m.sub = sc.EventBus.Subscribe("metrics_log", core.DEFAULT_EVENT_BUFFER_SIZE, core.DropOldest)
+	go func() {
+		for e := range m.sub.Events() {
+			m.mu.Lock()
+			m.eventCounts[e.Type]++
+			m.mu.Unlock()
+		}
+	}()
 	return nil
 }
 
@@ -74,9 +93,38 @@ func (m *MetricsLogTaskImpl) Task() {
 		slog.Int(
 			queueLengthKeyInMetrics,
 			m.sc.GetCurrentQueueSize()),
+		// the number of the failed jobs labeled by the error kind
+		slog.Any(
+			failuresKeyInMetrics,
+			core.FailureCounts()),
+		// the number of the lifecycle events of the jobs labeled by the event type
+		slog.Any(
+			eventsKeyInMetrics,
+			m.getEventCounts()),
+		slog.Uint64(
+			droppedEventsKeyInMetrics,
+			m.sub.Dropped()),
+		// the number of the jobs refused because their IDs were already active
+		slog.Uint64(
+			duplicateJobsKeyInMetrics,
+			core.DuplicateJobCount()),
+		// the usage of the worker pools of the stages and the services labeled by the pool
+		slog.Any(
+			workerPoolsKeyInMetrics,
+			core.WorkerPoolStatsOfAll()),
 	)
 }
 
+func (m *MetricsLogTaskImpl) getEventCounts() map[core.EventType]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	counts := make(map[core.EventType]uint64, len(m.eventCounts))
+	for k, v := range m.eventCounts {
+		counts[k] = v
+	}
+	return counts
+}
+
 func (m *MetricsLogTaskImpl) Cleanup() {
 	m.dl.Close()
 }
@@ -84,13 +132,15 @@ func (m *MetricsLogTaskImpl) Cleanup() {
 type dailyLogger struct {
 	mu              sync.Mutex
 	fileDir         string
+	prefix          string
 	currentFileName string
 	file            *os.File
 }
 
-func newDailyLogger(fileDir string) *dailyLogger {
+func newDailyLogger(fileDir string, prefix string) *dailyLogger {
 	return &dailyLogger{
 		fileDir: fileDir,
+		prefix:  prefix,
 	}
 }
 
@@ -98,7 +148,7 @@ func (dl *dailyLogger) Write(p []byte) (n int, err error) {
 	dl.mu.Lock()
 	defer dl.mu.Unlock()
 
-	fileName := fmt.Sprintf("metrics-%s.log", time.Now().Format("2006-01-02"))
+	fileName := fmt.Sprintf("%s-%s.log", dl.prefix, time.Now().Format("2006-01-02"))
 	filePath := filepath.Join(dl.fileDir, fileName)
 	currentFilePath := filepath.Join(dl.fileDir, dl.currentFileName)
 
@@ -56,11 +56,11 @@ func NewMitigationInfoFromJobData(jd *core.JobData) *MitigationInfo {
 	return &m
 }
 
-func PseudoInverseMitigation(jd *core.JobData) {
+func PseudoInverseMitigation(ctx context.Context, jd *core.JobData) {
 	numOfQubits, err := getNumOfQubits(jd.Result.Counts)
 	if err != nil {
 		zap.L().Error("failed to get number of qubits/reason: ", zap.Error(err))
-		jd.Status = core.FAILED
+		core.SetFailureWithErrorToJobData(jd, core.NewInternalError("failed to get the number of qubits to mitigate", err))
 		return
 	}
 
@@ -93,7 +93,7 @@ func PseudoInverseMitigation(jd *core.JobData) {
 	target, err := common.ValidAddress(mitigatorSetting.Host, mitigatorSetting.Port)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("Invalid mitigator address: Host=%s, Port=%s, Error=%v", mitigatorSetting.Host, mitigatorSetting.Port, err))
-		jd.Status = core.FAILED
+		core.SetFailureWithErrorToJobData(jd, core.NewInternalError("the mitigator is not configured correctly", err))
 		return
 	}
 
@@ -114,10 +114,10 @@ func PseudoInverseMitigation(jd *core.JobData) {
 		cts[k] = int32(v)
 	}
 	zap.L().Debug(fmt.Sprintf("pre-mitigation counts: %v", cts))
-	dt, err := deviceTopology()
+	dt, err := deviceTopology(jd.DeviceID)
 	if err != nil {
 		zap.L().Error("failed to get device topology/reason: ", zap.Error(err))
-		jd.Status = core.FAILED
+		core.SetFailureWithErrorToJobData(jd, core.NewDeviceUnavailableError("failed to get the device topology", err))
 		return
 	}
 
@@ -136,13 +136,17 @@ func PseudoInverseMitigation(jd *core.JobData) {
 	}
 	zap.L().Debug(fmt.Sprintf("MitigationJob Request: %v", mreq))
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-
-	res, err := client.ReqMitigation(ctx, mreq)
+	var res *pb.ReqMitigationResponse
+	err = core.Retry(ctx, jd, "mitigator", func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		var reqErr error
+		res, reqErr = client.ReqMitigation(ctx, mreq)
+		return reqErr
+	})
 	if err != nil {
 		zap.L().Error("Failed to request mitigation", zap.Error(err))
-		jd.Status = core.FAILED
+		core.SetFailureWithErrorToJobData(jd, core.WithErrorKind(core.DependencyFailureError, err))
 		return
 	}
 
@@ -154,7 +158,7 @@ func PseudoInverseMitigation(jd *core.JobData) {
 	}
 	zap.L().Debug(fmt.Sprintf("get lower bits of MitigationJob Result Counts: %v", lbcts))
 	jd.Result.Counts = lbcts
-	jd.Status = core.SUCCEEDED
+	jd.SetStatus(core.SUCCEEDED)
 	zap.L().Debug(fmt.Sprintf("MitigationJob Result: %v", jd.Result))
 }
 
@@ -175,9 +179,12 @@ func getNumOfQubits(counts core.Counts) (int, error) {
 	return candidateNum, nil
 }
 
-func deviceTopology() (*pb.DeviceTopology, error) {
-	s := core.GetSystemComponents()
-	disj := s.GetDeviceInfo().DeviceInfoSpecJson
+func deviceTopology(deviceID string) (*pb.DeviceTopology, error) {
+	di, err := core.GetSystemComponents().GetDeviceInfoOf(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	disj := di.DeviceInfoSpecJson
 	zap.L().Debug(fmt.Sprintf("device info spec json: %v", disj))
 
 	var dis core.DeviceInfoSpec
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/go-openapi/strfmt"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	mpgmconf "github.com/oqtopus-team/oqtopus-engine/coreapp/multiprog/manual/conf"
 	pb "github.com/oqtopus-team/oqtopus-engine/coreapp/multiprog/manual/multiprog_interface/v1"
@@ -19,7 +18,7 @@ import (
 )
 
 var (
-	ErrorJobIDConflict      = errors.New("jobID is already used")
+	ErrorJobIDConflict      = core.ErrorJobIDConflict
 	ErrorCircuitCombineFail = errors.New("circuit combine failed")
 	ErrorBadRequest         = errors.New("bad request")
 )
@@ -64,31 +63,39 @@ func (j *ManualJob) JobContext() *core.JobContext {
 	return j.jobContext
 }
 
-func (j *ManualJob) UpdateStatus(st core.Status) {
-	j.jobData.Status = st
+func (j *ManualJob) UpdateStatus(st core.Status) error {
+	return j.jobData.SetStatus(st)
 }
 
 func (j *ManualJob) IsFinished() bool {
-	return j.postProcessed || j.JobData().Status == core.FAILED
+	return j.postProcessed || j.JobData().Status == core.FAILED || j.JobData().Status == core.CANCELLED
 }
 
-func (j *ManualJob) PreProcess() {
+func (j *ManualJob) Validate() error {
 	jd := j.JobData()
-	p := &core.JobParam{
-		JobID:      jd.ID,
-		QASM:       jd.QASM,
-		Shots:      jd.Shots,
-		Transpiler: jd.Transpiler,
-		JobType:    jd.JobType,
+	if err := core.ValidateShots(jd); err != nil {
+		return err
 	}
-	if err := validateJobParam(p); err != nil {
-		zap.L().Info(fmt.Sprintf("failed to validate job data. Reason:%s", err.Error()))
-		core.SetFailureWithError(j, err)
-		return
+	var qasms []string
+	if err := json.Unmarshal([]byte(jd.QASM), &qasms); err != nil {
+		zap.L().Info(fmt.Sprintf("invalid QASM array of job(%s)/reason:%s", jd.ID, err))
+		return fmt.Errorf("program is not an array of QASM: %w", err)
+	}
+	if len(qasms) == 0 {
+		return fmt.Errorf("program is empty")
 	}
+	for i, q := range qasms {
+		if q == "" {
+			return fmt.Errorf("program[%d] is empty", i)
+		}
+	}
+	return nil
+}
 
+func (j *ManualJob) PreProcess(ctx context.Context) {
+	jd := j.JobData()
 	j.originalQASMs = jd.QASM
-	if err := j.preProcessImpl(); err != nil {
+	if err := j.preProcessImpl(ctx); err != nil {
 		zap.L().Error(fmt.Sprintf("failed to pre-process a job(%s). Reason:%s",
 			jd.ID, err.Error()))
 		j.rollbackQASM()
@@ -98,7 +105,7 @@ func (j *ManualJob) PreProcess() {
 	}
 }
 
-func (j *ManualJob) preProcessImpl() (err error) {
+func (j *ManualJob) preProcessImpl(ctx context.Context) (err error) {
 	err = nil
 	jd := j.JobData()
 	container := core.GetSystemComponents().Container
@@ -113,7 +120,7 @@ func (j *ManualJob) preProcessImpl() (err error) {
 	// send Job to circuit_combiner
 	mpgmconf := mpgmconf.GetMPGMConf()
 
-	combined_qasm, combined_qubits_list, err := sendJobdata(j, mpgmconf)
+	combined_qasm, combined_qubits_list, err := sendJobdata(ctx, j, mpgmconf)
 	if err != nil {
 		jd.Result.Message = err.Error()
 		zap.L().Error(fmt.Sprintf("failed to combine a job(%s). Reason:%s", jd.ID, err.Error()))
@@ -125,7 +132,9 @@ func (j *ManualJob) preProcessImpl() (err error) {
 
 	err = container.Invoke(
 		func(t core.Transpiler) error {
-			return t.Transpile(j)
+			return core.Retry(ctx, j.JobData(), "transpiler", func(ctx context.Context) error {
+				return t.Transpile(ctx, j)
+			})
 		})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to transpile a job(%s). Reason:%s", jd.ID, err.Error()))
@@ -135,22 +144,24 @@ func (j *ManualJob) preProcessImpl() (err error) {
 	return
 }
 
-func (j *ManualJob) Process() {
+func (j *ManualJob) Process(ctx context.Context) {
 	c := core.GetSystemComponents().Container
 	err := c.Invoke(
 		func(q core.QPUManager) error {
-			return q.Send(j)
+			return core.Retry(ctx, j.JobData(), "qpu", func(ctx context.Context) error {
+				return q.Send(ctx, j)
+			})
 		})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to send a job(%s) to QPU. Reason:%s", j.JobData().ID, err.Error()))
-		j.jobData.Status = core.FAILED
+		core.SetFailureWithError(j, err)
 		j.rollbackQASM()
 		return
 	}
 	zap.L().Debug(fmt.Sprintf("PostProcess goroutine for job(%s) is started", j.JobData().ID))
 }
 
-func (j *ManualJob) PostProcess() {
+func (j *ManualJob) PostProcess(ctx context.Context) {
 	jd := j.JobData()
 	j.postProcessed = true
 
@@ -168,8 +179,7 @@ func (j *ManualJob) PostProcess() {
 		return
 	}
 	// Update DB
-	jd.Status = core.SUCCEEDED
-	jd.Ended = strfmt.DateTime(time.Now())
+	jd.SetStatus(core.SUCCEEDED)
 }
 
 func (j *ManualJob) setQASMJson() (err error) {
@@ -217,41 +227,7 @@ func (j *ManualJob) Clone() core.Job {
 	return cloned
 }
 
-func validateJobParam(p *core.JobParam) (err error) {
-	err = nil
-	if p.Shots <= 0 {
-		msg := fmt.Sprintf("shots(%d) must be greater than 0", p.Shots)
-		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", p.JobID))
-		return fmt.Errorf(msg)
-	}
-	maxShots := core.GetSystemComponents().GetDeviceInfo().MaxShots
-	if p.Shots > maxShots {
-		msg := fmt.Sprintf("shots(%d) is over the limit(%d)",
-			p.Shots, maxShots)
-		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", p.JobID))
-		return fmt.Errorf(msg)
-	}
-
-	container := core.GetSystemComponents().Container
-	err = container.Invoke(
-		func(t core.Transpiler) error {
-			if p.Transpiler.TranspilerLib == nil {
-				return nil // use no transpiler
-			}
-			if t.IsAcceptableTranspilerLib(*p.Transpiler.TranspilerLib) {
-				return nil
-			}
-			return fmt.Errorf("transpiler lib %s is not acceptable", *p.Transpiler.TranspilerLib)
-		})
-	if err != nil {
-		zap.L().Info(fmt.Sprintf("failed to validate transpiler lib/JobID:%s/reason:%s", p.JobID, err.Error()))
-		return err
-	}
-
-	return err
-}
-
-func sendJobdata(inputJob core.Job, mpgmconf *mpgmconf.MPGMConf) (combinedQASM string, combinedQubitsList []int32, err error) {
+func sendJobdata(ctx context.Context, inputJob core.Job, mpgmconf *mpgmconf.MPGMConf) (combinedQASM string, combinedQubitsList []int32, err error) {
 	// Send Job information to python-hosted-gRPC server
 	combinedQASM = ""
 	combinedQubitsList = []int32{}
@@ -281,7 +257,11 @@ func sendJobdata(inputJob core.Job, mpgmconf *mpgmconf.MPGMConf) (combinedQASM s
 	}
 	defer conn.Close()
 
-	device_info := core.GetSystemComponents().GetDeviceInfo()
+	device_info, err := core.GetSystemComponents().GetDeviceInfoOf(inputJob.JobData().DeviceID)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to get the device info/reason:%s", err))
+		return
+	}
 	// create gRPC client
 	client := pb.NewCircuitCombinerServiceClient(conn)
 
@@ -292,8 +272,15 @@ func sendJobdata(inputJob core.Job, mpgmconf *mpgmconf.MPGMConf) (combinedQASM s
 		QasmArray: qasm_str_processed,
 		MaxQubits: int32(device_info.MaxQubits),
 	}
+	// the combiner is called by a limited number of the jobs at the same time
+	release, err := core.ServicePool("combiner").Acquire(ctx)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("could not request: %v", err))
+		return
+	}
+	defer release()
 	// send request to gRPC server
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
 	res, err := client.Combine(ctx, req)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/go-faster/jx"
 	"strconv"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
@@ -14,6 +15,7 @@ import (
 	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sse"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/workflow"
 
 	"go.uber.org/zap"
 )
@@ -79,6 +81,19 @@ func ConvertToCloudJob(j *core.JobData) *api.JobsJobDef {
 		jjr = api.JobsJobResult{
 			Estimation: nilJer,
 		}
+	case workflow.WORKFLOW_JOB:
+		// the result of the last step with the results of all the steps
+		jobType = api.JobsJobTypeWorkflow
+		jjr = convertToAPIStepResult(j.Result)
+		stepResults := make(map[string]api.JobsJobResult, len(j.Result.StepResults))
+		for name, r := range j.Result.StepResults {
+			stepResults[name] = convertToAPIStepResult(r)
+		}
+		if b, err := json.Marshal(stepResults); err != nil {
+			zap.L().Error(fmt.Sprintf("failed to marshal step results/reason:%s", err))
+		} else {
+			jjr.AdditionalProps = api.JobsJobResultAdditional{"step_results": b}
+		}
 	default:
 		zap.L().Error(fmt.Sprintf("unknown job type %s", j.JobType))
 		jobType = api.JobsJobTypeSampling
@@ -151,14 +166,58 @@ func ConvertToCloudJob(j *core.JobData) *api.JobsJobDef {
 		Status:         st,
 		JobInfo:        ji,
 		ExecutionTime:  ext,
+		ReadyAt:        convertToAPIStatusTime(j, core.READY),
+		RunningAt:      convertToAPIStatusTime(j, core.RUNNING),
+		EndedAt:        convertToAPIEndedTime(j),
 		// TODO: more
 	}
 }
 
+// convertToAPIStepResult converts the result of a step of a workflow job, which is either
+// a sampling or an estimation.
+func convertToAPIStepResult(r *core.Result) api.JobsJobResult {
+	samplingResult := api.NewOptNilJobsSamplingResult(api.JobsSamplingResult{})
+	if r != nil && len(r.Counts) != 0 {
+		samplingResult.Value.Counts = convertToAPICounts(r.Counts)
+	} else {
+		samplingResult.SetToNull()
+	}
+	estimationResult := api.NewOptNilJobsEstimationResult(api.JobsEstimationResult{})
+	if r != nil && r.Estimation != nil {
+		estimationResult.Value.SetExpValue(float64(r.Estimation.Exp_value))
+		estimationResult.Value.SetStds(float64(r.Estimation.Stds))
+	} else {
+		estimationResult.SetToNull()
+	}
+	return api.JobsJobResult{
+		Sampling:   samplingResult,
+		Estimation: estimationResult,
+	}
+}
+
+func convertToAPIStatusTime(j *core.JobData, st core.Status) api.OptNilDateTime {
+	t, ok := j.StatusTime(st)
+	if !ok {
+		return api.OptNilDateTime{}
+	}
+	return api.NewOptNilDateTime(t)
+}
+
+func convertToAPIEndedTime(j *core.JobData) api.OptNilDateTime {
+	t, ok := j.EndedTime()
+	if !ok {
+		return api.OptNilDateTime{}
+	}
+	return api.NewOptNilDateTime(t)
+}
+
 func ConvertFromCloudJob(j *api.JobsJobDef) *core.JobData {
 	jd := core.NewJobData()
 	jd.ID = string(j.JobID)
 	jd.Shots = j.Shots
+	jd.DeviceID = j.DeviceID
+	jd.Priority = j.Priority.Or("")
+	jd.Owner = j.Owner.Or("")
 
 	if useTranspiler(j.TranspilerInfo) {
 		if useDefaultTranspiler(j.TranspilerInfo) {
@@ -180,7 +239,12 @@ func ConvertFromCloudJob(j *api.JobsJobDef) *core.JobData {
 	jd.MitigationInfo = ConvertToMitigationInfo(j.MitigationInfo)
 	zap.L().Debug(fmt.Sprintf("jd.MitigationInfo:%s", jd.MitigationInfo))
 
-	jd.Status = convertFromCloudStatus(j.Status)
+	// the job has entered the status in the cloud before it is fetched
+	at := time.Now()
+	if readyAt, ok := j.ReadyAt.Get(); ok {
+		at = readyAt
+	}
+	jd.InitStatus(convertFromCloudStatus(j.Status), at)
 	if gdt, ok := j.SubmittedAt.Get(); ok {
 		jd.Created = strfmt.DateTime(gdt)
 	} else {
@@ -205,6 +269,8 @@ func ConvertFromCloudJob(j *api.JobsJobDef) *core.JobData {
 		jd.QASM = string(programArray)
 	case api.JobsJobTypeSse:
 		jd.JobType = sse.SSE_JOB
+	case api.JobsJobTypeWorkflow:
+		jd.JobType = workflow.WORKFLOW_JOB
 	default:
 		zap.L().Error(fmt.Sprintf("unknown job type %s", j.JobType))
 		jd.JobType = core.NORMAL_JOB
@@ -284,6 +350,8 @@ func convertFromCloudStatus(st api.JobsJobStatus) core.Status {
 		r = core.SUCCEEDED
 	case api.JobsJobStatusFailed:
 		r = core.FAILED
+	case api.JobsJobStatusCancelled:
+		r = core.CANCELLED
 	default:
 		zap.L().Error("unknown status", zap.Any("unknown status", st))
 		r = core.FAILED
@@ -370,6 +438,8 @@ func convertToAPIStatus(s core.Status) api.JobsJobStatus {
 		st = api.JobsJobStatusSucceeded
 	case core.FAILED:
 		st = api.JobsJobStatusFailed
+	case core.CANCELLED:
+		st = api.JobsJobStatusCancelled
 	default:
 		zap.L().Error(fmt.Sprintf("unknown status %d", s))
 		st = api.JobsJobStatusFailed
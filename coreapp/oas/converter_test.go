@@ -4,7 +4,12 @@
 package oas
 
 import (
+	"encoding/json"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/workflow"
 	"testing"
+	"time"
 
 	"github.com/go-faster/jx"
 	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
@@ -106,3 +111,92 @@ func TestUseMitigationInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertToCloudJobStatusTimes(t *testing.T) {
+	jd := core.NewJobData()
+	jd.ID = "job"
+	jd.JobType = sampling.SAMPLING_JOB
+	readyAt := time.Now().Add(-time.Minute)
+	jd.InitStatus(core.READY, readyAt)
+
+	cj := ConvertToCloudJob(jd)
+	v, ok := cj.ReadyAt.Get()
+	assert.True(t, ok)
+	assert.Equal(t, readyAt, v)
+	assert.False(t, cj.RunningAt.IsSet())
+	assert.False(t, cj.EndedAt.IsSet())
+
+	assert.Nil(t, jd.SetStatus(core.RUNNING))
+	assert.Nil(t, jd.SetStatus(core.SUCCEEDED))
+	cj = ConvertToCloudJob(jd)
+	runningAt, ok := cj.RunningAt.Get()
+	assert.True(t, ok)
+	endedAt, ok := cj.EndedAt.Get()
+	assert.True(t, ok)
+	assert.False(t, endedAt.Before(runningAt))
+}
+
+func TestConvertWorkflowJob(t *testing.T) {
+	jd := core.NewJobData()
+	jd.ID = "workflow_job"
+	jd.JobType = workflow.WORKFLOW_JOB
+	jd.Status = core.SUCCEEDED
+	jd.Result.Counts = core.Counts{"00": 10}
+	jd.Result.StepResults = map[string]*core.Result{
+		"first":  {Counts: core.Counts{"00": 10}},
+		"second": {Estimation: &core.Estimation{Exp_value: 0.5, Stds: 0.1}},
+	}
+
+	cj := ConvertToCloudJob(jd)
+	assert.Equal(t, api.JobsJobTypeWorkflow, cj.JobType)
+	r, ok := cj.JobInfo.Result.Get()
+	assert.True(t, ok)
+	assert.Equal(t, []byte("10"), []byte(r.Sampling.Value.Counts["00"]))
+	stepResults := map[string]api.JobsJobResult{}
+	assert.Nil(t, json.Unmarshal(r.AdditionalProps["step_results"], &stepResults))
+	assert.Equal(t, []byte("10"), []byte(stepResults["first"].Sampling.Value.Counts["00"]))
+	assert.True(t, stepResults["first"].Estimation.IsNull())
+	assert.Equal(t, 0.5, stepResults["second"].Estimation.Value.ExpValue)
+	assert.True(t, stepResults["second"].Sampling.IsNull())
+
+	def := &api.JobsJobDef{
+		JobID:   "workflow_job",
+		JobType: api.JobsJobTypeWorkflow,
+		JobInfo: api.JobsJobInfo{Program: []string{`{"steps":[]}`}},
+		Status:  api.JobsJobStatusReady,
+	}
+	assert.Equal(t, workflow.WORKFLOW_JOB, ConvertFromCloudJob(def).JobType)
+}
+
+func TestConvertFromCloudJobPriority(t *testing.T) {
+	def := &api.JobsJobDef{}
+	err := def.UnmarshalJSON([]byte(`{
+		"job_id": "priority_job",
+		"device_id": "Kawasaki",
+		"shots": 1000,
+		"job_type": "sampling",
+		"job_info": {"program": ["OPENQASM 3;"]},
+		"status": "ready",
+		"priority": "high"
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "high", ConvertFromCloudJob(def).Priority)
+
+	def.Priority.Reset()
+	assert.Equal(t, "", ConvertFromCloudJob(def).Priority)
+}
+
+func TestConvertFromCloudJobOwner(t *testing.T) {
+	def := &api.JobsJobDef{}
+	err := def.UnmarshalJSON([]byte(`{
+		"job_id": "owned_job",
+		"device_id": "Kawasaki",
+		"shots": 1000,
+		"job_type": "sampling",
+		"job_info": {"program": ["OPENQASM 3;"]},
+		"status": "ready",
+		"owner": "user1"
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "user1", ConvertFromCloudJob(def).Owner)
+}
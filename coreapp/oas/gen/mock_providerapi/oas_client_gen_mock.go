@@ -35,6 +35,21 @@ func (m *MockInvoker) EXPECT() *MockInvokerMockRecorder {
 	return m.recorder
 }
 
+// ClaimJobs mocks base method.
+func (m *MockInvoker) ClaimJobs(ctx context.Context, request providerapi.OptJobsClaimJobsRequest) (providerapi.ClaimJobsRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, request)
+	ret0, _ := ret[0].(providerapi.ClaimJobsRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockInvokerMockRecorder) ClaimJobs(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockInvoker)(nil).ClaimJobs), ctx, request)
+}
+
 // GetJob mocks base method.
 func (m *MockInvoker) GetJob(ctx context.Context, params providerapi.GetJobParams) (providerapi.GetJobRes, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +185,36 @@ func (mr *MockInvokerMockRecorder) PatchSselog(ctx, request, params interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSselog", reflect.TypeOf((*MockInvoker)(nil).PatchSselog), ctx, request, params)
 }
 
+// RenewLeases mocks base method.
+func (m *MockInvoker) RenewLeases(ctx context.Context, request providerapi.OptJobsRenewLeasesRequest) (providerapi.RenewLeasesRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewLeases", ctx, request)
+	ret0, _ := ret[0].(providerapi.RenewLeasesRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewLeases indicates an expected call of RenewLeases.
+func (mr *MockInvokerMockRecorder) RenewLeases(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewLeases", reflect.TypeOf((*MockInvoker)(nil).RenewLeases), ctx, request)
+}
+
+// StreamJobs mocks base method.
+func (m *MockInvoker) StreamJobs(ctx context.Context, params providerapi.StreamJobsParams) (providerapi.StreamJobsRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamJobs", ctx, params)
+	ret0, _ := ret[0].(providerapi.StreamJobsRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamJobs indicates an expected call of StreamJobs.
+func (mr *MockInvokerMockRecorder) StreamJobs(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamJobs", reflect.TypeOf((*MockInvoker)(nil).StreamJobs), ctx, params)
+}
+
 // UpdateJobTranspilerInfo mocks base method.
 func (m *MockInvoker) UpdateJobTranspilerInfo(ctx context.Context, request providerapi.OptJobsUpdateJobTranspilerInfoRequest, params providerapi.UpdateJobTranspilerInfoParams) (providerapi.UpdateJobTranspilerInfoRes, error) {
 	m.ctrl.T.Helper()
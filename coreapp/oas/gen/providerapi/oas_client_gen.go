@@ -24,6 +24,16 @@ import (
 
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
+	// ClaimJobs invokes claim_jobs operation.
+	//
+	// Atomically lease submitted jobs of a device to an engine for the TTL, so that the engines sharing
+	// a device never run the same job.
+	// The jobs whose leases have expired are claimed again.
+	// This endpoint is optional. The engine uses it only when leasing is enabled in its setting, and
+	// gets the jobs by GET /jobs otherwise.
+	//
+	// POST /jobs/claim
+	ClaimJobs(ctx context.Context, request OptJobsClaimJobsRequest) (ClaimJobsRes, error)
 	// GetJob invokes get_job operation.
 	//
 	// Get a job by ID.
@@ -81,6 +91,25 @@ type Invoker interface {
 	//
 	// PATCH /jobs/{job_id}/sselog
 	PatchSselog(ctx context.Context, request OptJobsUploadSselogRequestMultipart, params PatchSselogParams) (PatchSselogRes, error)
+	// RenewLeases invokes renew_leases operation.
+	//
+	// Extend the leases of the jobs claimed by an engine. The leases held by another engine are returned
+	// as lost.
+	// This endpoint is optional. The engine uses it only when leasing is enabled in its setting,
+	// together with POST /jobs/claim.
+	//
+	// POST /jobs/leases/renew
+	RenewLeases(ctx context.Context, request OptJobsRenewLeasesRequest) (RenewLeasesRes, error)
+	// StreamJobs invokes stream_jobs operation.
+	//
+	// Stream the submitted jobs of the devices as server-sent events. The stream starts with the jobs
+	// submitted before the connection.
+	// Each event is named "job" and its data is a job in the schema of jobs.JobDef.
+	// This endpoint is optional. The engine uses it only when the stream is enabled in its setting, and
+	// polls GET /jobs otherwise and while the stream is down.
+	//
+	// GET /jobs/stream
+	StreamJobs(ctx context.Context, params StreamJobsParams) (StreamJobsRes, error)
 	// UpdateJobTranspilerInfo invokes update_job_transpiler_info operation.
 	//
 	// Overwrite selected quantum job's transpiler_info.
@@ -139,6 +168,118 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 	return u
 }
 
+// ClaimJobs invokes claim_jobs operation.
+//
+// Atomically lease submitted jobs of a device to an engine for the TTL, so that the engines sharing
+// a device never run the same job.
+// The jobs whose leases have expired are claimed again.
+// This endpoint is optional. The engine uses it only when leasing is enabled in its setting, and
+// gets the jobs by GET /jobs otherwise.
+//
+// POST /jobs/claim
+func (c *Client) ClaimJobs(ctx context.Context, request OptJobsClaimJobsRequest) (ClaimJobsRes, error) {
+	res, err := c.sendClaimJobs(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendClaimJobs(ctx context.Context, request OptJobsClaimJobsRequest) (res ClaimJobsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("claim_jobs"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/jobs/claim"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ClaimJobsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/jobs/claim"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeClaimJobsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:ApiKeyAuth"
+			switch err := c.securityApiKeyAuth(ctx, ClaimJobsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"ApiKeyAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeClaimJobsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // GetJob invokes get_job operation.
 //
 // Get a job by ID.
@@ -379,6 +520,32 @@ func (c *Client) sendGetJobs(ctx context.Context, params GetJobsParams) (res Get
 			return res, errors.Wrap(err, "encode query")
 		}
 	}
+	{
+		// Encode "job_id" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "job_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.JobID != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.JobID {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
 	u.RawQuery = q.Values().Encode()
 
 	stage = "EncodeRequest"
@@ -1324,6 +1491,253 @@ func (c *Client) sendPatchSselog(ctx context.Context, request OptJobsUploadSselo
 	return result, nil
 }
 
+// RenewLeases invokes renew_leases operation.
+//
+// Extend the leases of the jobs claimed by an engine. The leases held by another engine are returned
+// as lost.
+// This endpoint is optional. The engine uses it only when leasing is enabled in its setting,
+// together with POST /jobs/claim.
+//
+// POST /jobs/leases/renew
+func (c *Client) RenewLeases(ctx context.Context, request OptJobsRenewLeasesRequest) (RenewLeasesRes, error) {
+	res, err := c.sendRenewLeases(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendRenewLeases(ctx context.Context, request OptJobsRenewLeasesRequest) (res RenewLeasesRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("renew_leases"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/jobs/leases/renew"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, RenewLeasesOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/jobs/leases/renew"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRenewLeasesRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:ApiKeyAuth"
+			switch err := c.securityApiKeyAuth(ctx, RenewLeasesOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"ApiKeyAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeRenewLeasesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StreamJobs invokes stream_jobs operation.
+//
+// Stream the submitted jobs of the devices as server-sent events. The stream starts with the jobs
+// submitted before the connection.
+// Each event is named "job" and its data is a job in the schema of jobs.JobDef.
+// This endpoint is optional. The engine uses it only when the stream is enabled in its setting, and
+// polls GET /jobs otherwise and while the stream is down.
+//
+// GET /jobs/stream
+func (c *Client) StreamJobs(ctx context.Context, params StreamJobsParams) (StreamJobsRes, error) {
+	res, err := c.sendStreamJobs(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendStreamJobs(ctx context.Context, params StreamJobsParams) (res StreamJobsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("stream_jobs"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/jobs/stream"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, StreamJobsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/jobs/stream"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_id" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeArray(func(e uri.Encoder) error {
+				for i, item := range params.DeviceID {
+					if err := func() error {
+						return e.EncodeValue(conv.StringToString(item))
+					}(); err != nil {
+						return errors.Wrapf(err, "[%d]", i)
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:ApiKeyAuth"
+			switch err := c.securityApiKeyAuth(ctx, StreamJobsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"ApiKeyAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeStreamJobsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // UpdateJobTranspilerInfo invokes update_job_transpiler_info operation.
 //
 // Overwrite selected quantum job's transpiler_info.
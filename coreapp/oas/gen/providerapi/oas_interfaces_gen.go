@@ -1,6 +1,10 @@
 // Code generated by ogen, DO NOT EDIT.
 package providerapi
 
+type ClaimJobsRes interface {
+	claimJobsRes()
+}
+
 type GetJobRes interface {
 	getJobRes()
 }
@@ -37,6 +41,14 @@ type PatchSselogRes interface {
 	patchSselogRes()
 }
 
+type RenewLeasesRes interface {
+	renewLeasesRes()
+}
+
+type StreamJobsRes interface {
+	streamJobsRes()
+}
+
 type UpdateJobTranspilerInfoRes interface {
 	updateJobTranspilerInfoRes()
 }
@@ -14,6 +14,56 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// Encode encodes ClaimJobsOKApplicationJSON as json.
+func (s ClaimJobsOKApplicationJSON) Encode(e *jx.Encoder) {
+	unwrapped := []JobsJobDef(s)
+
+	e.ArrStart()
+	for _, elem := range unwrapped {
+		elem.Encode(e)
+	}
+	e.ArrEnd()
+}
+
+// Decode decodes ClaimJobsOKApplicationJSON from json.
+func (s *ClaimJobsOKApplicationJSON) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode ClaimJobsOKApplicationJSON to nil")
+	}
+	var unwrapped []JobsJobDef
+	if err := func() error {
+		unwrapped = make([]JobsJobDef, 0)
+		if err := d.Arr(func(d *jx.Decoder) error {
+			var elem JobsJobDef
+			if err := elem.Decode(d); err != nil {
+				return err
+			}
+			unwrapped = append(unwrapped, elem)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return errors.Wrap(err, "alias")
+	}
+	*s = ClaimJobsOKApplicationJSON(unwrapped)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s ClaimJobsOKApplicationJSON) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *ClaimJobsOKApplicationJSON) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode implements json.Marshaler.
 func (s *DevicesDeviceDataUpdateResponse) Encode(e *jx.Encoder) {
 	e.ObjStart()
@@ -235,10 +285,17 @@ func (s *DevicesDeviceStatusUpdate) encodeFields(e *jx.Encoder) {
 		e.FieldStart("status")
 		s.Status.Encode(e)
 	}
+	{
+		if s.AvailableAt.Set {
+			e.FieldStart("available_at")
+			s.AvailableAt.Encode(e, json.EncodeDateTime)
+		}
+	}
 }
 
-var jsonFieldsNameOfDevicesDeviceStatusUpdate = [1]string{
+var jsonFieldsNameOfDevicesDeviceStatusUpdate = [2]string{
 	0: "status",
+	1: "available_at",
 }
 
 // Decode decodes DevicesDeviceStatusUpdate from json.
@@ -260,6 +317,16 @@ func (s *DevicesDeviceStatusUpdate) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"status\"")
 			}
+		case "available_at":
+			if err := func() error {
+				s.AvailableAt.Reset()
+				if err := s.AvailableAt.Decode(d, json.DecodeDateTime); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"available_at\"")
+			}
 		default:
 			return d.Skip()
 		}
@@ -950,6 +1017,153 @@ func (s *GetJobsOKApplicationJSON) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode implements json.Marshaler.
+func (s *JobsClaimJobsRequest) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *JobsClaimJobsRequest) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("engine_id")
+		e.Str(s.EngineID)
+	}
+	{
+		e.FieldStart("device_id")
+		e.Str(s.DeviceID)
+	}
+	{
+		e.FieldStart("max_results")
+		e.Int(s.MaxResults)
+	}
+	{
+		e.FieldStart("lease_ttl_sec")
+		e.Float64(s.LeaseTTLSec)
+	}
+}
+
+var jsonFieldsNameOfJobsClaimJobsRequest = [4]string{
+	0: "engine_id",
+	1: "device_id",
+	2: "max_results",
+	3: "lease_ttl_sec",
+}
+
+// Decode decodes JobsClaimJobsRequest from json.
+func (s *JobsClaimJobsRequest) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode JobsClaimJobsRequest to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "engine_id":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.EngineID = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"engine_id\"")
+			}
+		case "device_id":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.DeviceID = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"device_id\"")
+			}
+		case "max_results":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Int()
+				s.MaxResults = int(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"max_results\"")
+			}
+		case "lease_ttl_sec":
+			requiredBitSet[0] |= 1 << 3
+			if err := func() error {
+				v, err := d.Float64()
+				s.LeaseTTLSec = float64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"lease_ttl_sec\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode JobsClaimJobsRequest")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00001111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfJobsClaimJobsRequest) {
+					name = jsonFieldsNameOfJobsClaimJobsRequest[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *JobsClaimJobsRequest) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *JobsClaimJobsRequest) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode implements json.Marshaler.
 func (s *JobsEstimationResult) Encode(e *jx.Encoder) {
 	e.ObjStart()
@@ -1156,9 +1370,21 @@ func (s *JobsJobDef) encodeFields(e *jx.Encoder) {
 			s.EndedAt.Encode(e, json.EncodeDateTime)
 		}
 	}
+	{
+		if s.Priority.Set {
+			e.FieldStart("priority")
+			s.Priority.Encode(e)
+		}
+	}
+	{
+		if s.Owner.Set {
+			e.FieldStart("owner")
+			s.Owner.Encode(e)
+		}
+	}
 }
 
-var jsonFieldsNameOfJobsJobDef = [16]string{
+var jsonFieldsNameOfJobsJobDef = [18]string{
 	0:  "job_id",
 	1:  "name",
 	2:  "description",
@@ -1175,6 +1401,8 @@ var jsonFieldsNameOfJobsJobDef = [16]string{
 	13: "ready_at",
 	14: "running_at",
 	15: "ended_at",
+	16: "priority",
+	17: "owner",
 }
 
 // Decode decodes JobsJobDef from json.
@@ -1182,7 +1410,7 @@ func (s *JobsJobDef) Decode(d *jx.Decoder) error {
 	if s == nil {
 		return errors.New("invalid: unable to decode JobsJobDef to nil")
 	}
-	var requiredBitSet [2]uint8
+	var requiredBitSet [3]uint8
 	s.setDefaults()
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
@@ -1351,6 +1579,26 @@ func (s *JobsJobDef) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"ended_at\"")
 			}
+		case "priority":
+			if err := func() error {
+				s.Priority.Reset()
+				if err := s.Priority.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"priority\"")
+			}
+		case "owner":
+			if err := func() error {
+				s.Owner.Reset()
+				if err := s.Owner.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"owner\"")
+			}
 		default:
 			return d.Skip()
 		}
@@ -1360,9 +1608,10 @@ func (s *JobsJobDef) Decode(d *jx.Decoder) error {
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
-	for i, mask := range [2]uint8{
+	for i, mask := range [3]uint8{
 		0b01111001,
 		0b00000100,
+		0b00000000,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -2273,6 +2522,8 @@ func (s *JobsJobType) Decode(d *jx.Decoder) error {
 		*s = JobsJobTypeMultiManual
 	case JobsJobTypeSse:
 		*s = JobsJobTypeSse
+	case JobsJobTypeWorkflow:
+		*s = JobsJobTypeWorkflow
 	default:
 		*s = JobsJobType(v)
 	}
@@ -2406,6 +2657,279 @@ func (s *JobsOperatorItem) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode implements json.Marshaler.
+func (s *JobsRenewLeasesRequest) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *JobsRenewLeasesRequest) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("engine_id")
+		e.Str(s.EngineID)
+	}
+	{
+		e.FieldStart("job_ids")
+		e.ArrStart()
+		for _, elem := range s.JobIds {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+	{
+		e.FieldStart("lease_ttl_sec")
+		e.Float64(s.LeaseTTLSec)
+	}
+}
+
+var jsonFieldsNameOfJobsRenewLeasesRequest = [3]string{
+	0: "engine_id",
+	1: "job_ids",
+	2: "lease_ttl_sec",
+}
+
+// Decode decodes JobsRenewLeasesRequest from json.
+func (s *JobsRenewLeasesRequest) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode JobsRenewLeasesRequest to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "engine_id":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.EngineID = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"engine_id\"")
+			}
+		case "job_ids":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				s.JobIds = make([]JobsJobId, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem JobsJobId
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.JobIds = append(s.JobIds, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"job_ids\"")
+			}
+		case "lease_ttl_sec":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Float64()
+				s.LeaseTTLSec = float64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"lease_ttl_sec\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode JobsRenewLeasesRequest")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfJobsRenewLeasesRequest) {
+					name = jsonFieldsNameOfJobsRenewLeasesRequest[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *JobsRenewLeasesRequest) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *JobsRenewLeasesRequest) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *JobsRenewLeasesResponse) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *JobsRenewLeasesResponse) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("renewed")
+		e.ArrStart()
+		for _, elem := range s.Renewed {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+	{
+		e.FieldStart("lost")
+		e.ArrStart()
+		for _, elem := range s.Lost {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+}
+
+var jsonFieldsNameOfJobsRenewLeasesResponse = [2]string{
+	0: "renewed",
+	1: "lost",
+}
+
+// Decode decodes JobsRenewLeasesResponse from json.
+func (s *JobsRenewLeasesResponse) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode JobsRenewLeasesResponse to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "renewed":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				s.Renewed = make([]JobsJobId, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem JobsJobId
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Renewed = append(s.Renewed, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"renewed\"")
+			}
+		case "lost":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				s.Lost = make([]JobsJobId, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem JobsJobId
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Lost = append(s.Lost, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"lost\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode JobsRenewLeasesResponse")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000011,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfJobsRenewLeasesResponse) {
+					name = jsonFieldsNameOfJobsRenewLeasesResponse[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *JobsRenewLeasesResponse) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *JobsRenewLeasesResponse) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode implements json.Marshaler.
 func (s *JobsSamplingResult) Encode(e *jx.Encoder) {
 	e.ObjStart()
@@ -3700,6 +4224,39 @@ func (s *OptFloat64) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode encodes JobsClaimJobsRequest as json.
+func (o OptJobsClaimJobsRequest) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	o.Value.Encode(e)
+}
+
+// Decode decodes JobsClaimJobsRequest from json.
+func (o *OptJobsClaimJobsRequest) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptJobsClaimJobsRequest to nil")
+	}
+	o.Set = true
+	if err := o.Value.Decode(d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptJobsClaimJobsRequest) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptJobsClaimJobsRequest) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode encodes JobsJobStatus as json.
 func (o OptJobsJobStatus) Encode(e *jx.Encoder) {
 	if !o.Set {
@@ -3766,6 +4323,39 @@ func (s *OptJobsJobStatusUpdate) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode encodes JobsRenewLeasesRequest as json.
+func (o OptJobsRenewLeasesRequest) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	o.Value.Encode(e)
+}
+
+// Decode decodes JobsRenewLeasesRequest from json.
+func (o *OptJobsRenewLeasesRequest) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptJobsRenewLeasesRequest to nil")
+	}
+	o.Set = true
+	if err := o.Value.Decode(d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptJobsRenewLeasesRequest) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptJobsRenewLeasesRequest) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode encodes JobsUpdateJobInfo as json.
 func (o OptJobsUpdateJobInfo) Encode(e *jx.Encoder) {
 	if !o.Set {
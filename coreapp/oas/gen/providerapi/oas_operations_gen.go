@@ -6,6 +6,7 @@ package providerapi
 type OperationName = string
 
 const (
+	ClaimJobsOperation               OperationName = "ClaimJobs"
 	GetJobOperation                  OperationName = "GetJob"
 	GetJobsOperation                 OperationName = "GetJobs"
 	GetSsesrcOperation               OperationName = "GetSsesrc"
@@ -15,5 +16,7 @@ const (
 	PatchJobOperation                OperationName = "PatchJob"
 	PatchJobInfoOperation            OperationName = "PatchJobInfo"
 	PatchSselogOperation             OperationName = "PatchSselog"
+	RenewLeasesOperation             OperationName = "RenewLeases"
+	StreamJobsOperation              OperationName = "StreamJobs"
 	UpdateJobTranspilerInfoOperation OperationName = "UpdateJobTranspilerInfo"
 )
@@ -91,6 +91,9 @@ type GetJobsParams struct {
 	MaxResults OptInt
 	// Additional search parameter:<br/> Jobs created after the specified timetsamp.
 	Timestamp OptString
+	// Additional search parameter:<br/> Search the specified jobs only. An extension of the engine which
+	// the server has to support, or the other jobs are returned too.
+	JobID []string
 }
 
 func unpackGetJobsParams(packed middleware.Parameters) (params GetJobsParams) {
@@ -128,6 +131,15 @@ func unpackGetJobsParams(packed middleware.Parameters) (params GetJobsParams) {
 			params.Timestamp = v.(OptString)
 		}
 	}
+	{
+		key := middleware.ParameterKey{
+			Name: "job_id",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.JobID = v.([]string)
+		}
+	}
 	return params
 }
 
@@ -307,6 +319,49 @@ func decodeGetJobsParams(args [0]string, argsEscaped bool, r *http.Request) (par
 			Err:  err,
 		}
 	}
+	// Decode query: job_id.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "job_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotJobIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotJobIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.JobID = append(params.JobID, paramsDotJobIDVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "job_id",
+			In:   "query",
+			Err:  err,
+		}
+	}
 	return params, nil
 }
 
@@ -772,6 +827,81 @@ func decodePatchSselogParams(args [1]string, argsEscaped bool, r *http.Request)
 	return params, nil
 }
 
+// StreamJobsParams is parameters of stream_jobs operation.
+type StreamJobsParams struct {
+	// Device identifiers.
+	DeviceID []string
+}
+
+func unpackStreamJobsParams(packed middleware.Parameters) (params StreamJobsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "device_id",
+			In:   "query",
+		}
+		params.DeviceID = packed[key].([]string)
+	}
+	return params
+}
+
+func decodeStreamJobsParams(args [0]string, argsEscaped bool, r *http.Request) (params StreamJobsParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: device_id.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "device_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotDeviceIDVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotDeviceIDVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.DeviceID = append(params.DeviceID, paramsDotDeviceIDVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.DeviceID == nil {
+					return errors.New("nil is invalid value")
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "device_id",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // UpdateJobTranspilerInfoParams is parameters of update_job_transpiler_info operation.
 type UpdateJobTranspilerInfoParams struct {
 	// Job identifier.
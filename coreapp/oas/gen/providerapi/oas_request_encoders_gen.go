@@ -15,6 +15,26 @@ import (
 	"github.com/ogen-go/ogen/uri"
 )
 
+func encodeClaimJobsRequest(
+	req OptJobsClaimJobsRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	if !req.Set {
+		// Keep request with empty body if value is not set.
+		return nil
+	}
+	e := new(jx.Encoder)
+	{
+		if req.Set {
+			req.Encode(e)
+		}
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodePatchDeviceRequest(
 	req OptDevicesUpdateDeviceRequest,
 	r *http.Request,
@@ -140,6 +160,26 @@ func encodePatchSselogRequest(
 	return nil
 }
 
+func encodeRenewLeasesRequest(
+	req OptJobsRenewLeasesRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	if !req.Set {
+		// Keep request with empty body if value is not set.
+		return nil
+	}
+	e := new(jx.Encoder)
+	{
+		if req.Set {
+			req.Encode(e)
+		}
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeUpdateJobTranspilerInfoRequest(
 	req OptJobsUpdateJobTranspilerInfoRequest,
 	r *http.Request,
@@ -182,6 +182,74 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "claim"
+						origElem := elem
+						if l := len("claim"); len(elem) >= l && elem[0:l] == "claim" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleClaimJobsRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 'l': // Prefix: "leases/renew"
+						origElem := elem
+						if l := len("leases/renew"); len(elem) >= l && elem[0:l] == "leases/renew" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleRenewLeasesRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 's': // Prefix: "stream"
+						origElem := elem
+						if l := len("stream"); len(elem) >= l && elem[0:l] == "stream" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleStreamJobsRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
+					}
 					// Param: "job_id"
 					// Match until "/"
 					idx := strings.IndexByte(elem, '/')
@@ -596,6 +664,86 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						break
 					}
 
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "claim"
+						origElem := elem
+						if l := len("claim"); len(elem) >= l && elem[0:l] == "claim" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ClaimJobsOperation
+								r.summary = "Claim submitted jobs for a device"
+								r.operationID = "claim_jobs"
+								r.pathPattern = "/jobs/claim"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					case 'l': // Prefix: "leases/renew"
+						origElem := elem
+						if l := len("leases/renew"); len(elem) >= l && elem[0:l] == "leases/renew" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = RenewLeasesOperation
+								r.summary = "Renew leases of claimed jobs"
+								r.operationID = "renew_leases"
+								r.pathPattern = "/jobs/leases/renew"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					case 's': // Prefix: "stream"
+						origElem := elem
+						if l := len("stream"); len(elem) >= l && elem[0:l] == "stream" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = StreamJobsOperation
+								r.summary = "Stream submitted jobs for devices"
+								r.operationID = "stream_jobs"
+								r.pathPattern = "/jobs/stream"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					}
 					// Param: "job_id"
 					// Match until "/"
 					idx := strings.IndexByte(elem, '/')
@@ -26,6 +26,10 @@ func (s *ApiKeyAuth) SetAPIKey(val string) {
 	s.APIKey = val
 }
 
+type ClaimJobsOKApplicationJSON []JobsJobDef
+
+func (*ClaimJobsOKApplicationJSON) claimJobsRes() {}
+
 // Ref: #/components/schemas/devices.DeviceDataUpdateResponse
 type DevicesDeviceDataUpdateResponse struct {
 	Message string `json:"message"`
@@ -75,6 +79,8 @@ func (s *DevicesDeviceInfoUpdate) SetCalibratedAt(val OptNilDateTime) {
 // Ref: #/components/schemas/devices.DeviceStatusUpdate
 type DevicesDeviceStatusUpdate struct {
 	Status DevicesDeviceStatusUpdateStatus `json:"status"`
+	// Parameter valid only if status is unavailable. The time when the device is available again.
+	AvailableAt OptNilDateTime `json:"available_at"`
 }
 
 // GetStatus returns the value of Status.
@@ -82,11 +88,21 @@ func (s *DevicesDeviceStatusUpdate) GetStatus() DevicesDeviceStatusUpdateStatus
 	return s.Status
 }
 
+// GetAvailableAt returns the value of AvailableAt.
+func (s *DevicesDeviceStatusUpdate) GetAvailableAt() OptNilDateTime {
+	return s.AvailableAt
+}
+
 // SetStatus sets the value of Status.
 func (s *DevicesDeviceStatusUpdate) SetStatus(val DevicesDeviceStatusUpdateStatus) {
 	s.Status = val
 }
 
+// SetAvailableAt sets the value of AvailableAt.
+func (s *DevicesDeviceStatusUpdate) SetAvailableAt(val OptNilDateTime) {
+	s.AvailableAt = val
+}
+
 type DevicesDeviceStatusUpdateStatus string
 
 const (
@@ -175,6 +191,7 @@ func (s *ErrorBadRequest) SetMessage(val string) {
 	s.Message = val
 }
 
+func (*ErrorBadRequest) claimJobsRes()               {}
 func (*ErrorBadRequest) getJobRes()                  {}
 func (*ErrorBadRequest) getJobsRes()                 {}
 func (*ErrorBadRequest) getSsesrcRes()               {}
@@ -183,6 +200,8 @@ func (*ErrorBadRequest) patchDeviceRes()             {}
 func (*ErrorBadRequest) patchDeviceStatusRes()       {}
 func (*ErrorBadRequest) patchJobInfoRes()            {}
 func (*ErrorBadRequest) patchSselogRes()             {}
+func (*ErrorBadRequest) renewLeasesRes()             {}
+func (*ErrorBadRequest) streamJobsRes()              {}
 func (*ErrorBadRequest) updateJobTranspilerInfoRes() {}
 
 // Ref: #/components/schemas/error.ConflictError
@@ -217,12 +236,15 @@ func (s *ErrorInternalServerError) SetMessage(val string) {
 	s.Message = val
 }
 
+func (*ErrorInternalServerError) claimJobsRes()         {}
 func (*ErrorInternalServerError) getJobsRes()           {}
 func (*ErrorInternalServerError) getSsesrcRes()         {}
 func (*ErrorInternalServerError) patchDeviceInfoRes()   {}
 func (*ErrorInternalServerError) patchDeviceRes()       {}
 func (*ErrorInternalServerError) patchDeviceStatusRes() {}
 func (*ErrorInternalServerError) patchSselogRes()       {}
+func (*ErrorInternalServerError) renewLeasesRes()       {}
+func (*ErrorInternalServerError) streamJobsRes()        {}
 
 // Ref: #/components/schemas/error.NotFoundError
 type ErrorNotFoundError struct {
@@ -268,6 +290,57 @@ func (s GetSsesrcOK) Read(p []byte) (n int, err error) {
 
 func (*GetSsesrcOK) getSsesrcRes() {}
 
+// Ref: #/components/schemas/jobs.ClaimJobsRequest
+type JobsClaimJobsRequest struct {
+	// Identifier of the engine leasing the jobs.
+	EngineID string `json:"engine_id"`
+	DeviceID string `json:"device_id"`
+	// Max number of the jobs to claim.
+	MaxResults int `json:"max_results"`
+	// Lease TTL in seconds. The jobs whose leases have expired are claimed again.
+	LeaseTTLSec float64 `json:"lease_ttl_sec"`
+}
+
+// GetEngineID returns the value of EngineID.
+func (s *JobsClaimJobsRequest) GetEngineID() string {
+	return s.EngineID
+}
+
+// GetDeviceID returns the value of DeviceID.
+func (s *JobsClaimJobsRequest) GetDeviceID() string {
+	return s.DeviceID
+}
+
+// GetMaxResults returns the value of MaxResults.
+func (s *JobsClaimJobsRequest) GetMaxResults() int {
+	return s.MaxResults
+}
+
+// GetLeaseTTLSec returns the value of LeaseTTLSec.
+func (s *JobsClaimJobsRequest) GetLeaseTTLSec() float64 {
+	return s.LeaseTTLSec
+}
+
+// SetEngineID sets the value of EngineID.
+func (s *JobsClaimJobsRequest) SetEngineID(val string) {
+	s.EngineID = val
+}
+
+// SetDeviceID sets the value of DeviceID.
+func (s *JobsClaimJobsRequest) SetDeviceID(val string) {
+	s.DeviceID = val
+}
+
+// SetMaxResults sets the value of MaxResults.
+func (s *JobsClaimJobsRequest) SetMaxResults(val int) {
+	s.MaxResults = val
+}
+
+// SetLeaseTTLSec sets the value of LeaseTTLSec.
+func (s *JobsClaimJobsRequest) SetLeaseTTLSec(val float64) {
+	s.LeaseTTLSec = val
+}
+
 // *(Only for estimation jobs)* The estimated expectation value and the standard deviation
 // of the operators specified in `job_info.operator` field which is intended to be provided for
 // estimation jobs.
@@ -317,6 +390,12 @@ type JobsJobDef struct {
 	ReadyAt        OptNilDateTime                 `json:"ready_at"`
 	RunningAt      OptNilDateTime                 `json:"running_at"`
 	EndedAt        OptNilDateTime                 `json:"ended_at"`
+	// The priority class: high, normal or low; the default of the job type when not set. An extension of
+	// the engine which the cloud has to provide; every job gets the default of its job type until then.
+	Priority OptString `json:"priority"`
+	// The user or the project of the job for the fair-share scheduling. An extension of the engine which
+	// the cloud has to provide; every job shares the empty owner until then.
+	Owner OptString `json:"owner"`
 }
 
 // GetJobID returns the value of JobID.
@@ -399,6 +478,16 @@ func (s *JobsJobDef) GetEndedAt() OptNilDateTime {
 	return s.EndedAt
 }
 
+// GetPriority returns the value of Priority.
+func (s *JobsJobDef) GetPriority() OptString {
+	return s.Priority
+}
+
+// GetOwner returns the value of Owner.
+func (s *JobsJobDef) GetOwner() OptString {
+	return s.Owner
+}
+
 // SetJobID sets the value of JobID.
 func (s *JobsJobDef) SetJobID(val JobsJobId) {
 	s.JobID = val
@@ -479,6 +568,16 @@ func (s *JobsJobDef) SetEndedAt(val OptNilDateTime) {
 	s.EndedAt = val
 }
 
+// SetPriority sets the value of Priority.
+func (s *JobsJobDef) SetPriority(val OptString) {
+	s.Priority = val
+}
+
+// SetOwner sets the value of Owner.
+func (s *JobsJobDef) SetOwner(val OptString) {
+	s.Owner = val
+}
+
 func (*JobsJobDef) getJobRes() {}
 
 type JobsJobDefMitigationInfo map[string]jx.Raw
@@ -593,6 +692,8 @@ func (s *JobsJobInfo) SetMessage(val OptNilString) {
 	s.Message = val
 }
 
+// The result of a workflow job is that of its last step, and the results of all the steps are given
+// in step_results keyed by the step name.
 // Ref: #/components/schemas/jobs.JobResult
 type JobsJobResult struct {
 	Sampling        OptNilJobsSamplingResult   `json:"sampling"`
@@ -785,6 +886,7 @@ const (
 	JobsJobTypeEstimation  JobsJobType = "estimation"
 	JobsJobTypeMultiManual JobsJobType = "multi_manual"
 	JobsJobTypeSse         JobsJobType = "sse"
+	JobsJobTypeWorkflow    JobsJobType = "workflow"
 )
 
 // AllValues returns all JobsJobType values.
@@ -794,6 +896,7 @@ func (JobsJobType) AllValues() []JobsJobType {
 		JobsJobTypeEstimation,
 		JobsJobTypeMultiManual,
 		JobsJobTypeSse,
+		JobsJobTypeWorkflow,
 	}
 }
 
@@ -808,6 +911,8 @@ func (s JobsJobType) MarshalText() ([]byte, error) {
 		return []byte(s), nil
 	case JobsJobTypeSse:
 		return []byte(s), nil
+	case JobsJobTypeWorkflow:
+		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
 	}
@@ -828,6 +933,9 @@ func (s *JobsJobType) UnmarshalText(data []byte) error {
 	case JobsJobTypeSse:
 		*s = JobsJobTypeSse
 		return nil
+	case JobsJobTypeWorkflow:
+		*s = JobsJobTypeWorkflow
+		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
@@ -861,6 +969,75 @@ func (s *JobsOperatorItem) SetCoeff(val OptFloat64) {
 	s.Coeff = val
 }
 
+// Ref: #/components/schemas/jobs.RenewLeasesRequest
+type JobsRenewLeasesRequest struct {
+	// Identifier of the engine leasing the jobs.
+	EngineID string      `json:"engine_id"`
+	JobIds   []JobsJobId `json:"job_ids"`
+	// Lease TTL in seconds.
+	LeaseTTLSec float64 `json:"lease_ttl_sec"`
+}
+
+// GetEngineID returns the value of EngineID.
+func (s *JobsRenewLeasesRequest) GetEngineID() string {
+	return s.EngineID
+}
+
+// GetJobIds returns the value of JobIds.
+func (s *JobsRenewLeasesRequest) GetJobIds() []JobsJobId {
+	return s.JobIds
+}
+
+// GetLeaseTTLSec returns the value of LeaseTTLSec.
+func (s *JobsRenewLeasesRequest) GetLeaseTTLSec() float64 {
+	return s.LeaseTTLSec
+}
+
+// SetEngineID sets the value of EngineID.
+func (s *JobsRenewLeasesRequest) SetEngineID(val string) {
+	s.EngineID = val
+}
+
+// SetJobIds sets the value of JobIds.
+func (s *JobsRenewLeasesRequest) SetJobIds(val []JobsJobId) {
+	s.JobIds = val
+}
+
+// SetLeaseTTLSec sets the value of LeaseTTLSec.
+func (s *JobsRenewLeasesRequest) SetLeaseTTLSec(val float64) {
+	s.LeaseTTLSec = val
+}
+
+// Ref: #/components/schemas/jobs.RenewLeasesResponse
+type JobsRenewLeasesResponse struct {
+	// Jobs whose leases are extended.
+	Renewed []JobsJobId `json:"renewed"`
+	// Jobs whose leases are held by another engine or released.
+	Lost []JobsJobId `json:"lost"`
+}
+
+// GetRenewed returns the value of Renewed.
+func (s *JobsRenewLeasesResponse) GetRenewed() []JobsJobId {
+	return s.Renewed
+}
+
+// GetLost returns the value of Lost.
+func (s *JobsRenewLeasesResponse) GetLost() []JobsJobId {
+	return s.Lost
+}
+
+// SetRenewed sets the value of Renewed.
+func (s *JobsRenewLeasesResponse) SetRenewed(val []JobsJobId) {
+	s.Renewed = val
+}
+
+// SetLost sets the value of Lost.
+func (s *JobsRenewLeasesResponse) SetLost(val []JobsJobId) {
+	s.Lost = val
+}
+
+func (*JobsRenewLeasesResponse) renewLeasesRes() {}
+
 // *(Only for sampling jobs)* JSON string representing the sampling result.
 // Ref: #/components/schemas/jobs.SamplingResult
 type JobsSamplingResult struct {
@@ -1499,6 +1676,52 @@ func (o OptInt) Or(d int) int {
 	return d
 }
 
+// NewOptJobsClaimJobsRequest returns new OptJobsClaimJobsRequest with value set to v.
+func NewOptJobsClaimJobsRequest(v JobsClaimJobsRequest) OptJobsClaimJobsRequest {
+	return OptJobsClaimJobsRequest{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptJobsClaimJobsRequest is optional JobsClaimJobsRequest.
+type OptJobsClaimJobsRequest struct {
+	Value JobsClaimJobsRequest
+	Set   bool
+}
+
+// IsSet returns true if OptJobsClaimJobsRequest was set.
+func (o OptJobsClaimJobsRequest) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptJobsClaimJobsRequest) Reset() {
+	var v JobsClaimJobsRequest
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptJobsClaimJobsRequest) SetTo(v JobsClaimJobsRequest) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptJobsClaimJobsRequest) Get() (v JobsClaimJobsRequest, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptJobsClaimJobsRequest) Or(d JobsClaimJobsRequest) JobsClaimJobsRequest {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptJobsJobStatus returns new OptJobsJobStatus with value set to v.
 func NewOptJobsJobStatus(v JobsJobStatus) OptJobsJobStatus {
 	return OptJobsJobStatus{
@@ -1591,6 +1814,52 @@ func (o OptJobsJobStatusUpdate) Or(d JobsJobStatusUpdate) JobsJobStatusUpdate {
 	return d
 }
 
+// NewOptJobsRenewLeasesRequest returns new OptJobsRenewLeasesRequest with value set to v.
+func NewOptJobsRenewLeasesRequest(v JobsRenewLeasesRequest) OptJobsRenewLeasesRequest {
+	return OptJobsRenewLeasesRequest{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptJobsRenewLeasesRequest is optional JobsRenewLeasesRequest.
+type OptJobsRenewLeasesRequest struct {
+	Value JobsRenewLeasesRequest
+	Set   bool
+}
+
+// IsSet returns true if OptJobsRenewLeasesRequest was set.
+func (o OptJobsRenewLeasesRequest) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptJobsRenewLeasesRequest) Reset() {
+	var v JobsRenewLeasesRequest
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptJobsRenewLeasesRequest) SetTo(v JobsRenewLeasesRequest) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptJobsRenewLeasesRequest) Get() (v JobsRenewLeasesRequest, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptJobsRenewLeasesRequest) Or(d JobsRenewLeasesRequest) JobsRenewLeasesRequest {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptJobsUpdateJobInfo returns new OptJobsUpdateJobInfo with value set to v.
 func NewOptJobsUpdateJobInfo(v JobsUpdateJobInfo) OptJobsUpdateJobInfo {
 	return OptJobsUpdateJobInfo{
@@ -2639,3 +2908,19 @@ func (o OptString) Or(d string) string {
 	}
 	return d
 }
+
+type StreamJobsOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s StreamJobsOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*StreamJobsOK) streamJobsRes() {}
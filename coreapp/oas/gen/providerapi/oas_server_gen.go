@@ -8,6 +8,16 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// ClaimJobs implements claim_jobs operation.
+	//
+	// Atomically lease submitted jobs of a device to an engine for the TTL, so that the engines sharing
+	// a device never run the same job.
+	// The jobs whose leases have expired are claimed again.
+	// This endpoint is optional. The engine uses it only when leasing is enabled in its setting, and
+	// gets the jobs by GET /jobs otherwise.
+	//
+	// POST /jobs/claim
+	ClaimJobs(ctx context.Context, req OptJobsClaimJobsRequest) (ClaimJobsRes, error)
 	// GetJob implements get_job operation.
 	//
 	// Get a job by ID.
@@ -65,6 +75,25 @@ type Handler interface {
 	//
 	// PATCH /jobs/{job_id}/sselog
 	PatchSselog(ctx context.Context, req OptJobsUploadSselogRequestMultipart, params PatchSselogParams) (PatchSselogRes, error)
+	// RenewLeases implements renew_leases operation.
+	//
+	// Extend the leases of the jobs claimed by an engine. The leases held by another engine are returned
+	// as lost.
+	// This endpoint is optional. The engine uses it only when leasing is enabled in its setting,
+	// together with POST /jobs/claim.
+	//
+	// POST /jobs/leases/renew
+	RenewLeases(ctx context.Context, req OptJobsRenewLeasesRequest) (RenewLeasesRes, error)
+	// StreamJobs implements stream_jobs operation.
+	//
+	// Stream the submitted jobs of the devices as server-sent events. The stream starts with the jobs
+	// submitted before the connection.
+	// Each event is named "job" and its data is a job in the schema of jobs.JobDef.
+	// This endpoint is optional. The engine uses it only when the stream is enabled in its setting, and
+	// polls GET /jobs otherwise and while the stream is down.
+	//
+	// GET /jobs/stream
+	StreamJobs(ctx context.Context, params StreamJobsParams) (StreamJobsRes, error)
 	// UpdateJobTranspilerInfo implements update_job_transpiler_info operation.
 	//
 	// Overwrite selected quantum job's transpiler_info.
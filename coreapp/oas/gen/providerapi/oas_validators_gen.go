@@ -10,6 +10,31 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+func (s ClaimJobsOKApplicationJSON) Validate() error {
+	alias := ([]JobsJobDef)(s)
+	if alias == nil {
+		return errors.New("nil is invalid value")
+	}
+	var failures []validate.FieldError
+	for i, elem := range alias {
+		if err := func() error {
+			if err := elem.Validate(); err != nil {
+				return err
+			}
+			return nil
+		}(); err != nil {
+			failures = append(failures, validate.FieldError{
+				Name:  fmt.Sprintf("[%d]", i),
+				Error: err,
+			})
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
 func (s *DevicesDeviceStatusUpdate) Validate() error {
 	if s == nil {
 		return validate.ErrNilPointer
@@ -69,6 +94,29 @@ func (s GetJobsOKApplicationJSON) Validate() error {
 	return nil
 }
 
+func (s *JobsClaimJobsRequest) Validate() error {
+	if s == nil {
+		return validate.ErrNilPointer
+	}
+
+	var failures []validate.FieldError
+	if err := func() error {
+		if err := (validate.Float{}).Validate(float64(s.LeaseTTLSec)); err != nil {
+			return errors.Wrap(err, "float")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "lease_ttl_sec",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
 func (s *JobsEstimationResult) Validate() error {
 	if s == nil {
 		return validate.ErrNilPointer
@@ -353,6 +401,8 @@ func (s JobsJobType) Validate() error {
 		return nil
 	case "sse":
 		return nil
+	case "workflow":
+		return nil
 	default:
 		return errors.Errorf("invalid value: %v", s)
 	}
@@ -388,6 +438,74 @@ func (s *JobsOperatorItem) Validate() error {
 	return nil
 }
 
+func (s *JobsRenewLeasesRequest) Validate() error {
+	if s == nil {
+		return validate.ErrNilPointer
+	}
+
+	var failures []validate.FieldError
+	if err := func() error {
+		if s.JobIds == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "job_ids",
+			Error: err,
+		})
+	}
+	if err := func() error {
+		if err := (validate.Float{}).Validate(float64(s.LeaseTTLSec)); err != nil {
+			return errors.Wrap(err, "float")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "lease_ttl_sec",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
+func (s *JobsRenewLeasesResponse) Validate() error {
+	if s == nil {
+		return validate.ErrNilPointer
+	}
+
+	var failures []validate.FieldError
+	if err := func() error {
+		if s.Renewed == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "renewed",
+			Error: err,
+		})
+	}
+	if err := func() error {
+		if s.Lost == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "lost",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
 func (s *JobsUpdateJobInfo) Validate() error {
 	if s == nil {
 		return validate.ErrNilPointer
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/estimation"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
@@ -14,28 +15,34 @@ import (
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	multiprog "github.com/oqtopus-team/oqtopus-engine/coreapp/multiprog/manual"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/sse"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/workflow"
 	"go.uber.org/zap"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
+// the layout of the timestamp searching the jobs submitted after it
+const CANCELLED_TIMESTAMP_LAYOUT = "2006-01-02 15:04:05"
+
+// the least max_results of the cancelled jobs, not to be capped by the default of the server
+// which ignores job_id and returns the other cancelled jobs too
+const CANCELLED_MAX_RESULTS = 1000
+
 // TODO: remove AWS from the name
 type awsPollClient struct {
 	client *api.Client
 
-	count      int
-	endpoint   string
-	edgeName   string // TODO edge id
-	deviceName string // TODO device id
+	count    int
+	endpoint string
+	edgeName string // TODO edge id
 }
 
 type awsPollClientParams struct {
-	cred       aws.Credentials
-	region     string
-	count      int
-	endPoint   string
-	edgeName   string
-	deviceName string
+	cred     aws.Credentials
+	region   string
+	count    int
+	endPoint string
+	edgeName string
 
 	apiKey string
 }
@@ -58,20 +65,22 @@ func newAWSPollClient(p *awsPollClientParams) (*awsPollClient, error) {
 		return nil, err
 	}
 	return &awsPollClient{
-		client:     cli,
-		count:      p.count,
-		endpoint:   p.endPoint,
-		edgeName:   p.edgeName,
-		deviceName: p.deviceName,
+		client:   cli,
+		count:    p.count,
+		endpoint: p.endPoint,
+		edgeName: p.edgeName,
 	}, nil
 }
 
-func (c *awsPollClient) request() ([]core.Job, error) {
+func (c *awsPollClient) request(deviceID string, limit int) ([]core.Job, error) {
 	zap.L().Debug(fmt.Sprintf("requesting get jobs to %s. EdgeName: %s, DeviceName: %s",
-		c.endpoint, c.edgeName, c.deviceName))
+		c.endpoint, c.edgeName, deviceID))
+	if limit > c.count {
+		limit = c.count
+	}
 	params := api.GetJobsParams{
-		DeviceID:   c.deviceName,
-		MaxResults: api.NewOptInt(c.count),
+		DeviceID:   deviceID,
+		MaxResults: api.NewOptInt(limit),
 		Status:     api.NewOptJobsJobStatus(api.JobsJobStatusSubmitted),
 	}
 	res0, err := c.client.GetJobs(context.TODO(), params)
@@ -96,6 +105,72 @@ func (c *awsPollClient) request() ([]core.Job, error) {
 	return jobs, err
 }
 
+// requestCancelled searches the cancelled jobs among the jobs handled in the engine by their IDs.
+// The jobs are also searched since the oldest job handled in the engine, for the server without job_id,
+// so that the jobs cancelled long ago never hide the jobs in flight.
+func (c *awsPollClient) requestCancelled(deviceID string, handled []core.ScheduledJob) ([]string, error) {
+	zap.L().Debug(fmt.Sprintf("requesting get cancelled jobs to %s. EdgeName: %s, DeviceName: %s",
+		c.endpoint, c.edgeName, deviceID))
+	handledIDs := make(map[string]struct{}, len(handled))
+	jobIDs := make([]string, 0, len(handled))
+	for _, sj := range handled {
+		handledIDs[sj.JobID] = struct{}{}
+		jobIDs = append(jobIDs, sj.JobID)
+	}
+	maxResults := max(len(handled), CANCELLED_MAX_RESULTS)
+	params := api.GetJobsParams{
+		DeviceID:   deviceID,
+		Status:     api.NewOptJobsJobStatus(api.JobsJobStatusCancelled),
+		MaxResults: api.NewOptInt(maxResults),
+		JobID:      jobIDs,
+	}
+	if since, ok := oldestSubmission(handled); ok {
+		params.Timestamp = api.NewOptString(since.UTC().Format(CANCELLED_TIMESTAMP_LAYOUT))
+	}
+	res0, err := c.client.GetJobs(context.TODO(), params)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get cancelled jobs/reason:%s", err)
+		return []string{}, fmt.Errorf(msg)
+	}
+	switch res := res0.(type) {
+	case *api.GetJobsOKApplicationJSON:
+		if len(*res) >= maxResults {
+			zap.L().Warn(fmt.Sprintf("got %d cancelled jobs of %s, which may miss the cancelled jobs in flight. "+
+				"the server has to support job_id", len(*res), deviceID))
+		}
+		cancelled := []string{}
+		for _, cJob := range *res {
+			// a server without job_id returns the other cancelled jobs too
+			if _, ok := handledIDs[string(cJob.JobID)]; ok {
+				cancelled = append(cancelled, string(cJob.JobID))
+			}
+		}
+		return cancelled, nil
+	default:
+		msg := fmt.Sprintf("unexpected response type %T", res0)
+		zap.L().Error(msg)
+		return []string{}, fmt.Errorf(msg)
+	}
+}
+
+// oldestSubmission returns the submission time of the oldest job, a second earlier for the timestamp
+// in seconds. No time is returned when a job has no submission time.
+func oldestSubmission(handled []core.ScheduledJob) (time.Time, bool) {
+	var oldest time.Time
+	for _, sj := range handled {
+		if sj.SubmittedAt == nil {
+			return time.Time{}, false
+		}
+		if oldest.IsZero() || sj.SubmittedAt.Before(oldest) {
+			oldest = *sj.SubmittedAt
+		}
+	}
+	if oldest.IsZero() {
+		return time.Time{}, false
+	}
+	return oldest.Add(-time.Second), true
+}
+
 // TODO: separate the validation
 func toJobSlice(jobDefs []api.JobsJobDef) (jobs []core.Job, err error) {
 	jobs = []core.Job{}
@@ -121,6 +196,8 @@ func toJobSlice(jobDefs []api.JobsJobDef) (jobs []core.Job, err error) {
 			jd.JobType = sse.SSE_JOB
 		case api.JobsJobTypeMultiManual:
 			jd.JobType = multiprog.MULTIPROG_MANUAL_JOB
+		case api.JobsJobTypeWorkflow:
+			jd.JobType = workflow.WORKFLOW_JOB
 		default:
 			msg := fmt.Sprintf("unknown job type %s", cJob.JobType)
 			zap.L().Error(msg)
@@ -131,9 +208,7 @@ func toJobSlice(jobDefs []api.JobsJobDef) (jobs []core.Job, err error) {
 
 		newJob, err = jm.NewJobFromJobDataWithValidation(jd, jc)
 		if err != nil {
-			msg := core.SetFailureWithErrorToJobData(jd, err)
-			zap.L().Error(fmt.Sprintf("Failed to validate a job. Reason:%s", msg))
-			newJob = (&core.UnknownJob{}).New(jd, jc)
+			newJob = newInvalidJob(jd, jc, err)
 		} else {
 			zap.L().Debug(fmt.Sprintf("Created a job. Job ID:%s created:%s, status:%s, transpiler:%v",
 				jd.ID, jd.Created, jd.Status, jd.Transpiler))
@@ -144,9 +219,17 @@ func toJobSlice(jobDefs []api.JobsJobDef) (jobs []core.Job, err error) {
 	return jobs, err
 }
 
+// newInvalidJob makes the failed job which reports the reason to the cloud instead of being processed,
+// so that the job is not fetched again.
+func newInvalidJob(jd *core.JobData, jc *core.JobContext, err error) core.Job {
+	msg := core.SetFailureWithErrorToJobData(jd, err)
+	zap.L().Error(fmt.Sprintf("Failed to validate a job. Reason:%s", msg))
+	return (&core.UnknownJob{}).New(jd, jc)
+}
+
 func (c *awsPollClient) downloadUserProgram(jobID string) (string, error) {
-	zap.L().Debug(fmt.Sprintf("requesting download userprogram to %s. EdgeName: %s, DeviceName: %s",
-		c.endpoint, c.edgeName, c.deviceName))
+	zap.L().Debug(fmt.Sprintf("requesting download userprogram to %s. EdgeName: %s",
+		c.endpoint, c.edgeName))
 	uri := fmt.Sprintf("%s/ssejobs/%s/download-src",
 		c.endpoint, jobID)
 
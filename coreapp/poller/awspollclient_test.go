@@ -0,0 +1,56 @@
+//go:build unit
+// +build unit
+
+package poller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "device1", q.Get("device_id"))
+		assert.Equal(t, "cancelled", q.Get("status"))
+		assert.Equal(t, "1000", q.Get("max_results"))
+		assert.Equal(t, []string{"job1", "job2"}, q["job_id"])
+		assert.Equal(t, "2024-01-02 03:04:04", q.Get("timestamp"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s,%s]", streamedJob("old"), streamedJob("job2"))
+	}))
+	defer srv.Close()
+
+	c, err := newAWSPollClient(&awsPollClientParams{count: 1, endPoint: srv.URL, apiKey: "test_key"})
+	assert.Nil(t, err)
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	second := first.Add(time.Minute)
+	handled := []core.ScheduledJob{
+		{JobID: "job1", DeviceID: "device1", SubmittedAt: &second},
+		{JobID: "job2", DeviceID: "device1", SubmittedAt: &first},
+	}
+	jobIDs, err := c.requestCancelled("device1", handled)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"job2"}, jobIDs)
+}
+
+func TestOldestSubmission(t *testing.T) {
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	second := first.Add(time.Minute)
+
+	oldest, ok := oldestSubmission([]core.ScheduledJob{{SubmittedAt: &second}, {SubmittedAt: &first}})
+	assert.True(t, ok)
+	assert.Equal(t, first.Add(-time.Second), oldest)
+
+	_, ok = oldestSubmission([]core.ScheduledJob{{SubmittedAt: &first}, {}})
+	assert.False(t, ok)
+
+	_, ok = oldestSubmission([]core.ScheduledJob{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,182 @@
+package poller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/job/job_interface/v1"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/jobservice"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+// grpcPollClient gets the jobs from the JobService of the cloud instead of the provider API.
+type grpcPollClient struct {
+	client *jobservice.Client
+	count  int
+}
+
+func newGRPCPollClient(s jobservice.JobServiceSetting, count int) (*grpcPollClient, error) {
+	cli, err := jobservice.NewClient(s)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to create a job service client/reason:%s", err))
+		return nil, err
+	}
+	return &grpcPollClient{
+		client: cli,
+		count:  count,
+	}, nil
+}
+
+func (c *grpcPollClient) request(deviceID string, limit int) ([]core.Job, error) {
+	if limit > c.count {
+		limit = c.count
+	}
+	jobs, err := c.client.GetJobs(deviceID, string(api.JobsJobStatusSubmitted), limit)
+	if err != nil {
+		return []core.Job{}, fmt.Errorf("failed to get jobs/reason:%s", err)
+	}
+	jobDefs := make([]api.JobsJobDef, 0, len(jobs))
+	invalidJobs := []core.Job{}
+	for _, j := range jobs {
+		jobDef, err := toJobDef(j)
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to convert job(%s)/reason:%s", j.GetJobId(), err))
+			invalid, err := newUnconvertedJob(j, err)
+			if err != nil {
+				return []core.Job{}, err
+			}
+			invalidJobs = append(invalidJobs, invalid)
+			continue
+		}
+		jobDefs = append(jobDefs, *jobDef)
+	}
+	converted, err := toJobSlice(jobDefs)
+	return append(converted, invalidJobs...), err
+}
+
+// newUnconvertedJob makes the failed job of the job which cannot be converted, so that the job is
+// reported as failed instead of being fetched again.
+func newUnconvertedJob(j *jint.Job, err error) (core.Job, error) {
+	jc, jcErr := core.NewJobContext()
+	if jcErr != nil {
+		zap.L().Error(fmt.Sprintf("Failed to create a job context. Reason:%s", jcErr))
+		return nil, jcErr
+	}
+	jd := core.NewJobData()
+	jd.ID = j.GetJobId()
+	jd.DeviceID = j.GetDeviceId()
+	jd.JobType = j.GetJobType()
+	jd.Shots = int(j.GetShots())
+	return newInvalidJob(jd, jc, core.WithErrorKind(core.UserInputError, err)), nil
+}
+
+func (c *grpcPollClient) requestCancelled(deviceID string, handled []core.ScheduledJob) ([]string, error) {
+	jobIDs := make([]string, 0, len(handled))
+	handledIDs := make(map[string]struct{}, len(handled))
+	for _, sj := range handled {
+		jobIDs = append(jobIDs, sj.JobID)
+		handledIDs[sj.JobID] = struct{}{}
+	}
+	jobs, err := c.client.GetJobsByID(deviceID, string(api.JobsJobStatusCancelled), jobIDs)
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to get cancelled jobs/reason:%s", err)
+	}
+	cancelled := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		// a job service without job_ids returns the other cancelled jobs too
+		if _, ok := handledIDs[j.GetJobId()]; ok {
+			cancelled = append(cancelled, j.GetJobId())
+		}
+	}
+	return cancelled, nil
+}
+
+func (c *grpcPollClient) close() {
+	c.client.Close()
+}
+
+// toJobDef converts the job of the JobService to the job of the provider API, whose conversion to
+// the job data is shared. The infos in the JobService are in JSON.
+func toJobDef(j *jint.Job) (*api.JobsJobDef, error) {
+	jobDef := &api.JobsJobDef{
+		JobID:    api.JobsJobId(j.GetJobId()),
+		DeviceID: j.GetDeviceId(),
+		Shots:    int(j.GetShots()),
+		JobType:  api.JobsJobType(j.GetJobType()),
+		Status:   api.JobsJobStatus(j.GetStatus()),
+	}
+	if err := jobDef.JobType.Validate(); err != nil {
+		return nil, err
+	}
+	if j.GetName() != "" {
+		jobDef.Name = api.NewOptNilString(j.GetName())
+	}
+	if j.GetDescription() != "" {
+		jobDef.Description = api.NewOptString(j.GetDescription())
+	}
+	if j.GetPriority() != "" {
+		jobDef.Priority = api.NewOptString(j.GetPriority())
+	}
+	if j.GetOwner() != "" {
+		jobDef.Owner = api.NewOptString(j.GetOwner())
+	}
+
+	info := j.GetJobInfo()
+	if jobDef.JobType == api.JobsJobTypeMultiManual {
+		// the programs of the multi-programming job are in a JSON array
+		if err := json.Unmarshal([]byte(info.GetProgram()), &jobDef.JobInfo.Program); err != nil {
+			return nil, fmt.Errorf("invalid programs: %w", err)
+		}
+	} else {
+		jobDef.JobInfo.Program = []string{info.GetProgram()}
+	}
+	if info.GetOperator() != "" {
+		if err := jobDef.JobInfo.Operator.UnmarshalJSON([]byte(info.GetOperator())); err != nil {
+			return nil, fmt.Errorf("invalid operator: %w", err)
+		}
+	}
+	if info.GetMessage() != "" {
+		jobDef.JobInfo.Message = api.NewOptNilString(info.GetMessage())
+	}
+	if j.GetTranspilerInfo() != "" {
+		if err := jobDef.TranspilerInfo.UnmarshalJSON([]byte(j.GetTranspilerInfo())); err != nil {
+			return nil, fmt.Errorf("invalid transpiler info: %w", err)
+		}
+	}
+	if j.GetSimulatorInfo() != "" {
+		if err := jobDef.SimulatorInfo.UnmarshalJSON([]byte(j.GetSimulatorInfo())); err != nil {
+			return nil, fmt.Errorf("invalid simulator info: %w", err)
+		}
+	}
+	if j.GetMitigationInfo() != "" {
+		if err := jobDef.MitigationInfo.UnmarshalJSON([]byte(j.GetMitigationInfo())); err != nil {
+			return nil, fmt.Errorf("invalid mitigation info: %w", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		value  string
+		target *api.OptNilDateTime
+	}{
+		{"submitted_at", j.GetSubmittedAt(), &jobDef.SubmittedAt},
+		{"ready_at", j.GetReadyAt(), &jobDef.ReadyAt},
+		{"running_at", j.GetRunningAt(), &jobDef.RunningAt},
+		{"ended_at", j.GetEndedAt(), &jobDef.EndedAt},
+	} {
+		t, ok, err := jobservice.ParseTime(tt.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", tt.name, err)
+		}
+		if ok {
+			*tt.target = api.NewOptNilDateTime(t)
+		}
+	}
+	if j.GetExecutionTime() != 0 {
+		jobDef.ExecutionTime = api.NewOptNilFloat64(j.GetExecutionTime())
+	}
+	return jobDef, nil
+}
@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package poller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	jint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/job/job_interface/v1"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToJobDef(t *testing.T) {
+	j := &jint.Job{
+		JobId:          "job1",
+		Name:           "bell",
+		Status:         "submitted",
+		JobType:        "sampling",
+		DeviceId:       "device1",
+		Shots:          1000,
+		JobInfo:        &jint.JobInfo{Program: "OPENQASM 3.0;"},
+		TranspilerInfo: `{"transpiler_lib":null}`,
+		SubmittedAt:    "2024-04-01T09:00:00Z",
+		ReadyAt:        "2024-04-01T09:00:01Z",
+		Priority:       "high",
+		Owner:          "project_a",
+	}
+	jobDef, err := toJobDef(j)
+	assert.Nil(t, err)
+	assert.Equal(t, api.JobsJobId("job1"), jobDef.JobID)
+	assert.Equal(t, api.JobsJobTypeSampling, jobDef.JobType)
+	assert.Equal(t, api.JobsJobStatusSubmitted, jobDef.Status)
+	assert.Equal(t, 1000, jobDef.Shots)
+	assert.Equal(t, []string{"OPENQASM 3.0;"}, jobDef.JobInfo.Program)
+	assert.Equal(t, "bell", jobDef.Name.Value)
+	submittedAt, ok := jobDef.SubmittedAt.Get()
+	assert.True(t, ok)
+	assert.True(t, time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC).Equal(submittedAt))
+	_, ok = jobDef.RunningAt.Get()
+	assert.False(t, ok)
+	assert.False(t, useTranspilerForTest(jobDef))
+	assert.Equal(t, api.NewOptString("high"), jobDef.Priority)
+	assert.Equal(t, api.NewOptString("project_a"), jobDef.Owner)
+
+	j.JobType = "multi_manual"
+	j.JobInfo.Program = `["OPENQASM 3.0;","OPENQASM 3.0;"]`
+	jobDef, err = toJobDef(j)
+	assert.Nil(t, err)
+	assert.Len(t, jobDef.JobInfo.Program, 2)
+
+	j.JobType = "unknown"
+	_, err = toJobDef(j)
+	assert.NotNil(t, err)
+
+	j.JobType = "sampling"
+	j.SubmittedAt = "yesterday"
+	_, err = toJobDef(j)
+	assert.NotNil(t, err)
+}
+
+func TestUnconvertedJobIsReported(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	sub := s.EventBus.Subscribe("test", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+
+	j, err := newUnconvertedJob(&jint.Job{JobId: "job1", JobType: "unknown", Shots: 10},
+		fmt.Errorf("invalid job type"))
+	assert.Nil(t, err)
+	assert.Equal(t, core.FAILED, j.JobData().Status)
+	assert.Equal(t, core.UserInputError, j.JobData().Result.ErrorKind)
+
+	// the failed job is reported instead of being handled, so that it is not fetched again
+	p := &Poller{sysCom: s}
+	assert.Equal(t, 0, p.handleJobs("device1", []core.Job{j}))
+	e := <-sub.Events()
+	assert.Equal(t, core.JobFailed, e.Type)
+	assert.Equal(t, "job1", e.Job.JobData().ID)
+	assert.Equal(t, "device1", e.Job.JobData().DeviceID)
+}
+
+func useTranspilerForTest(jobDef *api.JobsJobDef) bool {
+	ti, ok := jobDef.TranspilerInfo.Get()
+	if !ok {
+		return true
+	}
+	return string(ti["transpiler_lib"]) != "null"
+}
@@ -0,0 +1,258 @@
+package poller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+const (
+	// the paths of the job leasing in the provider API
+	CLAIM_PATH = "/jobs/claim"
+	RENEW_PATH = "/jobs/leases/renew"
+
+	DEFAULT_LEASE_REQUEST_TIMEOUT = time.Duration(10) * time.Second
+)
+
+// leaseClient claims the submitted jobs for the engine, so that the engines sharing a device never run
+// the same job. A claimed job is leased to the engine for the TTL, and the lease is renewed while the job
+// is in flight. The job whose lease has expired is claimed by another engine.
+type leaseClient interface {
+	claim(deviceID string, limit int) ([]core.Job, error)
+	// renew returns the jobs whose leases have been lost, e.g. taken over by another engine
+	renew(jobIDs []string) (lost []string, err error)
+}
+
+// restLeaseClient claims the jobs through the provider API. The cloud atomically turns the submitted
+// jobs into the jobs leased to the engine in POST {endpoint}/jobs/claim, which returns the jobs in the
+// schema of the provider API, and extends the leases in POST {endpoint}/jobs/leases/renew.
+// The bodies are in the schemas of the provider API. The claimed jobs are decoded one by one, so that
+// a broken job does not refuse the others. The endpoints are optional ones of the provider API,
+// which the cloud has to provide for Poller.LeaseTTL.
+type restLeaseClient struct {
+	endpoint string
+	apiKey   string
+	engineID string
+	ttl      time.Duration
+	client   *http.Client
+}
+
+func newRESTLeaseClient(endpoint string, apiKey string, engineID string, ttl time.Duration) *restLeaseClient {
+	return &restLeaseClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		apiKey:   apiKey,
+		engineID: engineID,
+		ttl:      ttl,
+		client:   &http.Client{Timeout: DEFAULT_LEASE_REQUEST_TIMEOUT},
+	}
+}
+
+func (c *restLeaseClient) claim(deviceID string, limit int) ([]core.Job, error) {
+	body, err := c.post(CLAIM_PATH, &api.JobsClaimJobsRequest{
+		EngineID:    c.engineID,
+		DeviceID:    deviceID,
+		MaxResults:  limit,
+		LeaseTTLSec: c.ttl.Seconds(),
+	})
+	if err != nil {
+		return []core.Job{}, fmt.Errorf("failed to claim jobs/reason:%s", err)
+	}
+	raws := []json.RawMessage{}
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return []core.Job{}, fmt.Errorf("failed to decode the claimed jobs/reason:%s", err)
+	}
+	jobDefs := make([]api.JobsJobDef, 0, len(raws))
+	for _, raw := range raws {
+		jobDef := api.JobsJobDef{}
+		if err := jobDef.UnmarshalJSON(raw); err != nil {
+			// the lease of the job expires and the job is claimed again
+			zap.L().Error(fmt.Sprintf("failed to decode a claimed job/reason:%s", err))
+			continue
+		}
+		jobDefs = append(jobDefs, jobDef)
+	}
+	return toJobSlice(jobDefs)
+}
+
+func (c *restLeaseClient) renew(jobIDs []string) ([]string, error) {
+	ids := make([]api.JobsJobId, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		ids = append(ids, api.JobsJobId(jobID))
+	}
+	body, err := c.post(RENEW_PATH, &api.JobsRenewLeasesRequest{
+		EngineID:    c.engineID,
+		JobIds:      ids,
+		LeaseTTLSec: c.ttl.Seconds(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew leases/reason:%s", err)
+	}
+	res := api.JobsRenewLeasesResponse{}
+	if err := res.UnmarshalJSON(body); err != nil {
+		return nil, fmt.Errorf("failed to decode the renewed leases/reason:%s", err)
+	}
+	lost := make([]string, 0, len(res.Lost))
+	for _, jobID := range res.Lost {
+		lost = append(lost, string(jobID))
+	}
+	return lost, nil
+}
+
+func (c *restLeaseClient) post(path string, v json.Marshaler) ([]byte, error) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.apiKey)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s/body:%s", path, res.Status, body)
+	}
+	return body, nil
+}
+
+// leasingPollClient gets the jobs by claiming them instead of getting the submitted jobs,
+// and keeps the leases of the claimed jobs until they finish.
+type leasingPollClient struct {
+	pollClient
+	leaseClient leaseClient
+	ttl         time.Duration
+
+	mu     sync.Mutex
+	leased map[string]struct{}
+}
+
+func newLeasingPollClient(p pollClient, l leaseClient, ttl time.Duration) *leasingPollClient {
+	return &leasingPollClient{
+		pollClient:  p,
+		leaseClient: l,
+		ttl:         ttl,
+		leased:      map[string]struct{}{},
+	}
+}
+
+func (c *leasingPollClient) request(deviceID string, limit int) ([]core.Job, error) {
+	jobs, err := c.leaseClient.claim(deviceID, limit)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, j := range jobs {
+		c.leased[j.JobData().ID] = struct{}{}
+	}
+	return jobs, err
+}
+
+func (c *leasingPollClient) leasedJobs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	jobIDs := make([]string, 0, len(c.leased))
+	for jobID := range c.leased {
+		jobIDs = append(jobIDs, jobID)
+	}
+	sort.Strings(jobIDs)
+	return jobIDs
+}
+
+func (c *leasingPollClient) release(jobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.leased, jobID)
+}
+
+// keepLeases renews the leases of the jobs in flight three times in the TTL until ctx is done.
+// The leases of the finished jobs are left to expire. A job whose lease is lost is cancelled,
+// because another engine runs it, and its status updates are refused by the cloud.
+func (c *leasingPollClient) keepLeases(ctx context.Context, bus *core.EventBus, cancel func(jobID string)) {
+	sub := bus.Subscribe("lease", core.DEFAULT_EVENT_BUFFER_SIZE, core.Coalesce)
+	go func() {
+		for e := range sub.Events() { // until the bus is closed
+			if core.IsTerminal(e.Job.JobData().Status) {
+				c.release(e.Job.JobData().ID)
+			}
+		}
+	}()
+	go func() {
+		ticker := time.NewTicker(c.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			c.renew(cancel)
+		}
+	}()
+}
+
+func (c *leasingPollClient) renew(cancel func(jobID string)) {
+	jobIDs := c.leasedJobs()
+	if len(jobIDs) == 0 {
+		return
+	}
+	lost, err := c.leaseClient.renew(jobIDs)
+	if err != nil {
+		// the leases are renewed in the next try before they expire
+		zap.L().Error(fmt.Sprintf("failed to renew the leases of %d jobs/reason:%s", len(jobIDs), err))
+		return
+	}
+	zap.L().Debug(fmt.Sprintf("renewed the leases of %d jobs", len(jobIDs)-len(lost)))
+	for _, jobID := range lost {
+		zap.L().Info(fmt.Sprintf("lost the lease of job(%s)", jobID))
+		c.release(jobID)
+		cancel(jobID)
+	}
+}
+
+// defaultEngineID is the host name, which is unique among the replicas of the engine.
+func defaultEngineID() string {
+	name, err := os.Hostname()
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to get the host name/reason:%s", err))
+		return DEFAULT_EDGE
+	}
+	return name
+}
+
+// startLeasing wraps the poll client to claim the jobs, and keeps the leases until the drain has
+// finished, because the jobs in flight are reported to the cloud until then.
+func (p *Poller) startLeasing(l leaseClient) {
+	p.leasing = newLeasingPollClient(p.pollClient, l, p.LeaseTTL)
+	p.pollClient = p.leasing
+	ctx, cancel := context.WithCancel(context.Background())
+	p.sysCom.AddStopper(core.StopFunc(cancel))
+	p.leasing.keepLeases(ctx, p.sysCom.EventBus, func(jobID string) {
+		p.sysCom.Invoke(
+			func(s core.Scheduler) {
+				if err := s.CancelJob(jobID); err != nil {
+					zap.L().Debug(fmt.Sprintf("not cancel a job(%s). Reason:%s", jobID, err))
+					return
+				}
+				zap.L().Info(fmt.Sprintf("cancelled a job(%s) taken over by another engine", jobID))
+			})
+	})
+}
@@ -0,0 +1,144 @@
+//go:build unit
+// +build unit
+
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRESTLeaseClient(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "test_key", r.Header.Get("X-Api-Key"))
+		switch r.URL.Path {
+		case CLAIM_PATH:
+			req := api.JobsClaimJobsRequest{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, api.JobsClaimJobsRequest{EngineID: "engine1", DeviceID: "device1", MaxResults: 2, LeaseTTLSec: 30}, req)
+			fmt.Fprintf(w, "[%s,{\"broken\":1},%s]", streamedJob("job1"), streamedJob("job2"))
+		case RENEW_PATH:
+			req := api.JobsRenewLeasesRequest{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, api.JobsRenewLeasesRequest{EngineID: "engine1", JobIds: []api.JobsJobId{"job1", "job2"}, LeaseTTLSec: 30}, req)
+			fmt.Fprint(w, `{"renewed":["job1"],"lost":["job2"]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := newRESTLeaseClient(srv.URL+"/", "test_key", "engine1", 30*time.Second)
+	jobs, err := c.claim("device1", 2)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "job1", jobs[0].JobData().ID)
+	assert.Equal(t, "job2", jobs[1].JobData().ID)
+
+	lost, err := c.renew([]string{"job1", "job2"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"job2"}, lost)
+
+	c = newRESTLeaseClient(srv.URL+"/unknown", "test_key", "engine1", 30*time.Second)
+	_, err = c.claim("device1", 2)
+	assert.NotNil(t, err)
+}
+
+type fakeLeaseClient struct {
+	jobs    []core.Job
+	lost    []string
+	mu      sync.Mutex
+	renewed [][]string
+}
+
+func (c *fakeLeaseClient) claim(string, int) ([]core.Job, error) {
+	return c.jobs, nil
+}
+
+func (c *fakeLeaseClient) renew(jobIDs []string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.renewed = append(c.renewed, jobIDs)
+	return c.lost, nil
+}
+
+func (c *fakeLeaseClient) renewedCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.renewed)
+}
+
+func TestLeasingPollClient(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	jobs := []core.Job{}
+	for _, jobID := range []string{"job1", "job2", "job3"} {
+		js, err := oneJobRequestImpl(core.READY)
+		assert.Nil(t, err)
+		js[0].JobData().ID = jobID
+		jobs = append(jobs, js[0])
+	}
+	l := &fakeLeaseClient{jobs: jobs, lost: []string{"job2"}}
+	c := newLeasingPollClient(&zeroJobsPollClient{}, l, 3*time.Hour)
+	got, err := c.request("device1", 3)
+	assert.Nil(t, err)
+	assert.Len(t, got, 3)
+	assert.Equal(t, []string{"job1", "job2", "job3"}, c.leasedJobs())
+
+	// the finished job is not renewed
+	bus := core.NewEventBus()
+	defer bus.Close()
+	c.keepLeases(context.Background(), bus, func(string) {})
+	jobs[2].JobData().Status = core.SUCCEEDED
+	bus.Publish(core.JobSucceeded, jobs[2])
+	assert.Eventually(t, func() bool { return len(c.leasedJobs()) == 2 }, time.Second, 10*time.Millisecond)
+
+	// the job taken over by another engine is cancelled
+	cancelled := []string{}
+	c.renew(func(jobID string) { cancelled = append(cancelled, jobID) })
+	assert.Equal(t, [][]string{{"job1", "job2"}}, l.renewed)
+	assert.Equal(t, []string{"job2"}, cancelled)
+	assert.Equal(t, []string{"job1"}, c.leasedJobs())
+}
+
+func TestLeasesKeptUntilDrained(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	jobs, err := oneJobRequestImpl(core.READY)
+	assert.Nil(t, err)
+	l := &fakeLeaseClient{jobs: jobs}
+	p := &Poller{LeaseTTL: 30 * time.Millisecond, sysCom: s}
+	p.pollClient = &zeroJobsPollClient{}
+	p.startLeasing(l)
+	_, err = p.request("device1", 1)
+	assert.Nil(t, err)
+
+	// the lease of the job in flight is renewed while the engine is draining
+	p.Cleanup()
+	n := l.renewedCount()
+	assert.Eventually(t, func() bool { return l.renewedCount() > n }, time.Second, 10*time.Millisecond)
+
+	// and the renewal stops after the drain
+	s.Stop()
+	time.Sleep(30 * time.Millisecond)
+	n = l.renewedCount()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, l.renewedCount())
+}
@@ -1,12 +1,16 @@
 package poller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/jobservice"
 	"go.uber.org/zap"
 )
 
@@ -18,6 +22,8 @@ const (
 	POLLING state = iota
 	SUB_IDLE
 	IDLE
+	// the jobs are delivered by the job stream
+	STREAMING
 )
 
 const (
@@ -43,13 +49,17 @@ func (s state) String() string {
 		return "SUB_IDLE"
 	case IDLE:
 		return "IDLE"
+	case STREAMING:
+		return "STREAMING"
 	default:
 		return "UNKNOWN"
 	}
 }
 
 type Poller struct {
-	Device       string        `toml:"device"`
+	Device string `toml:"device"`
+	// the devices hosted in the engine. Device is polled when empty
+	Devices      []string      `toml:"devices"`
 	Edge         string        `toml:"edge"`
 	Count        int           `toml:"count"`
 	NormalPeriod time.Duration `toml:"normal_period"`
@@ -65,7 +75,24 @@ type Poller struct {
 
 	EnableTestMode bool `toml:"enable_test_mode"`
 
+	// Stream gets the submitted jobs from the job stream as soon as they exist instead of polling.
+	// It is an opt-in, since the cloud has to provide GET /jobs/stream of the provider API.
+	// The jobs are polled when it is false, and while the stream is down
+	Stream            bool          `toml:"stream"`
+	StreamRetryPeriod time.Duration `toml:"stream_retry_period"`
+
+	// EngineID identifies the engine holding the leases of the claimed jobs. the host name when empty
+	EngineID string `toml:"engine_id"`
+	// LeaseTTL claims the submitted jobs with leases of the TTL instead of getting them,
+	// so that the engines polling the same device never run the same job. zero disables leasing.
+	// It is an opt-in, since the cloud has to provide POST /jobs/claim and POST /jobs/leases/renew
+	LeaseTTL time.Duration `toml:"lease_ttl"`
+
 	pollClient
+	streamClient jobStreamClient
+	streaming    atomic.Bool
+	stopStream   context.CancelFunc
+	leasing      *leasingPollClient
 
 	cred aws.Credentials
 
@@ -95,6 +122,14 @@ func (p *Poller) SetParams(params interface{}) error {
 	zap.L().Debug(fmt.Sprintf("Set params for poller: %v", pp))
 	setField[string]("device", &p.Device, pp, DEFAULT_DEVICE)
 	setField[string]("edge", &p.Edge, pp, DEFAULT_EDGE)
+	if devices, ok := pp["devices"].([]interface{}); ok {
+		p.Devices = []string{}
+		for _, d := range devices {
+			if device, ok := d.(string); ok {
+				p.Devices = append(p.Devices, device)
+			}
+		}
+	}
 	setField[int]("count", &p.Count, pp, DEFAULT_COUNT)
 	setField[int]("max_retry", &p.MaxRetry, pp, DEFAULT_MAX_RETRY)
 	setField[string]("region", &p.Region, pp, DEFAULT_REGION)
@@ -104,9 +139,13 @@ func (p *Poller) SetParams(params interface{}) error {
 	setField[string]("api_key", &p.APIKey, pp, DEFAULT_API_KEY)
 	setField[string]("session_token", &p.SessionToken, pp, "")
 	setField[bool]("enable_test_mode", &p.EnableTestMode, pp, false)
+	setField[bool]("stream", &p.Stream, pp, false)
+	setField[string]("engine_id", &p.EngineID, pp, defaultEngineID())
 
 	setDurationField("normal_period", &p.NormalPeriod, pp, DEFAULT_NORMAL_PERIOD)
 	setDurationField("idle_period", &p.IdlePeriod, pp, DEFAULT_IDLE_PERIOD)
+	setDurationField("stream_retry_period", &p.StreamRetryPeriod, pp, DEFAULT_STREAM_RETRY_PERIOD)
+	setDurationField("lease_ttl", &p.LeaseTTL, pp, 0)
 
 	return nil
 }
@@ -140,7 +179,10 @@ func (p *Poller) RequirePeriodUpdate() (bool, time.Duration) {
 }
 
 type pollClient interface {
-	request() ([]core.Job, error)
+	// request gets at most limit jobs of the device
+	request(deviceID string, limit int) ([]core.Job, error)
+	// requestCancelled returns the jobs cancelled in the cloud among the jobs handled in the engine
+	requestCancelled(deviceID string, handled []core.ScheduledJob) ([]string, error)
 }
 
 func (p *Poller) Setup() error {
@@ -154,32 +196,76 @@ func (p *Poller) Setup() error {
 		err        error
 	)
 
-	zap.L().Info("Set aws poll client")
-	pollClient, err = newAWSPollClient(
-		&awsPollClientParams{
-			cred:       cred,
-			region:     p.Region,
-			count:      p.Count,
-			endPoint:   p.Endpoint,
-			edgeName:   p.Edge,
-			deviceName: p.Device,
-			apiKey:     p.APIKey,
-		})
-	if err != nil {
-		zap.L().Error(fmt.Sprintf("failed to set aws poll client/reason:%s", err))
-		return err
+	if jobservice.UseGRPC() {
+		zap.L().Info("Set grpc poll client")
+		pollClient, err = newGRPCPollClient(jobservice.GetJobServiceSetting(), p.Count)
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to set grpc poll client/reason:%s", err))
+			return err
+		}
+	} else {
+		zap.L().Info("Set aws poll client")
+		pollClient, err = newAWSPollClient(
+			&awsPollClientParams{
+				cred:     cred,
+				region:   p.Region,
+				count:    p.Count,
+				endPoint: p.Endpoint,
+				edgeName: p.Edge,
+				apiKey:   p.APIKey,
+			})
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to set aws poll client/reason:%s", err))
+			return err
+		}
 	}
-	zap.L().Info(fmt.Sprintf("EdgeName:%s, DeviceName:%s", p.Edge, p.Device))
+	zap.L().Info(fmt.Sprintf("EdgeName:%s, Devices:%v", p.Edge, p.devices()))
 	p.pollClient = pollClient
 	p.cred = cred
 	p.currentPeriod = p.NormalPeriod
 	p.noJobsCount = 0
 	p.state = POLLING
 	p.sysCom = core.GetSystemComponents()
+	if p.LeaseTTL > 0 {
+		if jobservice.UseGRPC() {
+			zap.L().Info("leasing is not supported with the job service. getting jobs without leases")
+		} else {
+			zap.L().Info(fmt.Sprintf("claiming jobs as %s with leases of %s", p.EngineID, p.LeaseTTL))
+			p.startLeasing(newRESTLeaseClient(p.Endpoint, p.APIKey, p.EngineID, p.LeaseTTL))
+		}
+	}
+	if p.Stream {
+		if jobservice.UseGRPC() {
+			zap.L().Info("the job stream is not supported with the job service. polling only")
+		} else if p.leasing != nil {
+			// the streamed jobs are not claimed
+			zap.L().Info("the job stream is not supported with leasing. polling only")
+		} else {
+			p.streamClient = newSSEStreamClient(p.Endpoint, p.APIKey)
+			p.startStream()
+		}
+	}
 	return nil
 }
 
 func (p *Poller) Task() {
+	// cancellation requests are handled regardless of the polling state
+	p.cancelJobs()
+
+	if p.streaming.Load() {
+		if p.state != STREAMING {
+			zap.L().Info("Transition to streaming mode")
+			p.currentPeriod = p.NormalPeriod
+			p.noJobsCount = 0
+			p.updateState(STREAMING)
+		}
+		return
+	}
+	if p.state == STREAMING {
+		zap.L().Info("Transition to polling mode from streaming state")
+		p.updateState(POLLING)
+	}
+
 	zap.L().Debug("Poller is getting jobs")
 	jobsNum, err := p.getJobs()
 	if err != nil || jobsNum == 0 {
@@ -231,54 +317,180 @@ func (p *Poller) Task() {
 
 func (p *Poller) Cleanup() {
 	zap.L().Info("Poller is cleaning up")
+	if p.stopStream != nil {
+		p.stopStream()
+	}
+	if c, ok := p.pollClient.(*grpcPollClient); ok {
+		c.close()
+	}
 }
 
-func (p *Poller) request() ([]core.Job, error) {
-	return p.pollClient.request()
+func (p *Poller) request(deviceID string, limit int) ([]core.Job, error) {
+	return p.pollClient.request(deviceID, limit)
 }
 
-// TODO test
+func (p *Poller) devices() []string {
+	if len(p.Devices) == 0 {
+		return []string{p.Device}
+	}
+	return p.Devices
+}
+
+// getJobs gets the jobs of all the devices. An error is returned when no job is got
+// and the polling of a device has failed.
 func (p *Poller) getJobs() (int, error) {
-	if err := passPollingCondition(); err != nil {
+	handlingJobsNum := 0
+	var lastErr error
+	for _, device := range p.devices() {
+		n, err := p.getJobsOf(device)
+		if err != nil {
+			lastErr = err
+		}
+		handlingJobsNum += n
+	}
+	if handlingJobsNum == 0 {
+		return 0, lastErr
+	}
+	return handlingJobsNum, nil
+}
+
+// TODO test
+func (p *Poller) getJobsOf(device string) (int, error) {
+	if err := passPollingCondition(device); err != nil {
+		zap.L().Info(fmt.Sprintf("not get jobs of %s. reason:%s", device, err))
+		return 0, err
+	}
+	// not fetch the jobs which would be refused for the full queue
+	free := p.sysCom.GetQueueFreeSlotsOf(device)
+	if free <= 0 {
+		err := fmt.Errorf("no free slot in the queue of %s. current queue size:%d",
+			device, p.sysCom.GetCurrentQueueSizeOf(device))
 		zap.L().Info(fmt.Sprintf("not get jobs. reason:%s", err))
 		return 0, err
 	}
-	jobs, err := p.request()
+	limit := p.Count
+	if free < limit {
+		limit = free
+	}
+	jobs, err := p.request(device, limit)
 	if err != nil {
-		zap.L().Error(fmt.Sprintf("Failed to get jobs. Reason:%s", err))
+		zap.L().Error(fmt.Sprintf("Failed to get jobs of %s. Reason:%s", device, err))
 		return 0, err
 	}
-	zap.L().Debug(fmt.Sprintf("get %d jobs", len(jobs)))
+	zap.L().Debug(fmt.Sprintf("get %d jobs of %s", len(jobs), device))
+	return p.handleJobs(device, jobs), nil
+}
+
+// handleJobs hands the jobs of the device to the scheduler, and returns the number of the handled jobs.
+func (p *Poller) handleJobs(device string, jobs []core.Job) int {
 	handlingJobsNum := 0
 	for _, job := range jobs {
 		jd := job.JobData()
+		if jd.DeviceID == "" {
+			jd.DeviceID = device
+		}
+		if job.IsFinished() {
+			// e.g. an invalid job, which is reported without being processed
+			zap.L().Info(fmt.Sprintf("the job(%s) has finished in %s before being handled", jd.ID, jd.Status))
+			jd.UseJobInfoUpdate = true
+			job.JobContext().Publish(core.EventTypeOf(jd.Status), job)
+			if jd.Status == core.FAILED {
+				core.CountFailure(jd)
+			}
+			continue
+		}
+		// the engine accepts the job submitted in the cloud, and the scheduler handles the ready jobs only
+		if jd.Status == core.SUBMITTED {
+			if err := jd.SetStatus(core.READY); err != nil {
+				zap.L().Error(fmt.Sprintf("failed to accept the job(%s)/reason:%s", jd.ID, err))
+				core.RejectJob(job, err)
+				continue
+			}
+		}
 		zap.L().Debug(fmt.Sprintf("Handling a job. Job ID:%s created:%s", jd.ID, jd.Created))
-		p.sysCom.Invoke(
+		// e.g. refused as a duplicate fetched again before the status update of the job reaches the cloud
+		err := p.sysCom.Invoke(
 			func(s core.Scheduler) error {
-				s.HandleJob(job)
-				return nil
+				return s.HandleJob(job)
 			})
+		if err != nil {
+			zap.L().Info(fmt.Sprintf("the job(%s) is refused. Reason:%s", jd.ID, err))
+			if (errors.Is(err, core.ErrorDraining) || errors.Is(err, core.ErrorQueueFull)) && p.leasing != nil {
+				// another engine or the next poll takes over the job
+				p.leasing.release(jd.ID)
+			}
+			core.RejectJob(job, err)
+			continue
+		}
 		handlingJobsNum++
 	}
-	return handlingJobsNum, nil
+	return handlingJobsNum
+}
+
+// cancelJobs gets the jobs cancelled in the cloud and cancels them in the scheduler.
+func (p *Poller) cancelJobs() {
+	for _, device := range p.devices() {
+		p.cancelJobsOf(device)
+	}
+}
+
+func (p *Poller) cancelJobsOf(device string) {
+	handled := p.handledJobsOf(device)
+	if len(handled) == 0 {
+		return
+	}
+	jobIDs, err := p.pollClient.requestCancelled(device, handled)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("Failed to get cancelled jobs of %s. Reason:%s", device, err))
+		return
+	}
+	zap.L().Debug(fmt.Sprintf("get %d cancelled jobs", len(jobIDs)))
+	for _, jobID := range jobIDs {
+		p.sysCom.Invoke(
+			func(s core.Scheduler) {
+				if err := s.CancelJob(jobID); err != nil {
+					// most of the cancelled jobs have never been fetched by this engine
+					zap.L().Debug(fmt.Sprintf("not cancel a job(%s). Reason:%s", jobID, err))
+					return
+				}
+				zap.L().Info(fmt.Sprintf("cancelled a job(%s)", jobID))
+			})
+	}
+}
+
+// handledJobsOf returns the jobs of the device handled in the scheduler, which can be cancelled.
+func (p *Poller) handledJobsOf(device string) []core.ScheduledJob {
+	handled := []core.ScheduledJob{}
+	p.sysCom.Invoke(
+		func(s core.Scheduler) {
+			for _, sj := range s.ListJobs() {
+				if sj.DeviceID == device {
+					handled = append(handled, sj)
+				}
+			}
+		})
+	return handled
 }
 
 func (p *Poller) updateState(newState state) {
 	p.state = newState
 }
 
-func passPollingCondition() error {
+func passPollingCondition(device string) error {
 	s := core.GetSystemComponents()
 	// TODO remove redundant logging
-	if s.IsQueueOverRefillThreshold() {
+	if s.IsQueueOverRefillThresholdOf(device) {
 		msg := fmt.Sprintf("queue size is over refill-threshold. current queue size:%d",
-			s.GetCurrentQueueSize())
+			s.GetCurrentQueueSizeOf(device))
 		return fmt.Errorf(msg)
 	} else {
 		zap.L().Debug(fmt.Sprintf("queue is under refill-threshold. current queue size:%d",
-			s.GetCurrentQueueSize()))
+			s.GetCurrentQueueSizeOf(device)))
+	}
+	di, err := s.GetDeviceInfoOf(device)
+	if err != nil {
+		return err
 	}
-	di := s.GetDeviceInfo()
 	if di.Status == core.Available {
 		zap.L().Debug("device is available")
 	} else {
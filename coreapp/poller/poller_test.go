@@ -77,20 +77,28 @@ func TestPoll(t *testing.T) {
 
 type zeroJobsPollClient struct{}
 
-func (m *zeroJobsPollClient) request() ([]core.Job, error) {
+func (m *zeroJobsPollClient) request(_ string, _ int) ([]core.Job, error) {
 	return []core.Job{}, nil
 }
 
+func (m *zeroJobsPollClient) requestCancelled(_ string, _ []core.ScheduledJob) ([]string, error) {
+	return []string{}, nil
+}
+
 func (m *zeroJobsPollClient) downloadUserProgram(_ string) (string, error) {
 	return "", nil
 }
 
 type oneJobPollClient struct{}
 
-func (m *oneJobPollClient) request() ([]core.Job, error) {
+func (m *oneJobPollClient) request(_ string, _ int) ([]core.Job, error) {
 	return oneJobRequestImpl(core.READY)
 }
 
+func (m *oneJobPollClient) requestCancelled(_ string, _ []core.ScheduledJob) ([]string, error) {
+	return []string{}, nil
+}
+
 func (m *oneJobPollClient) downloadUserProgram(jobId string) (string, error) {
 	return "", nil
 }
@@ -99,7 +107,7 @@ type recoveringPollClient struct {
 	count int
 }
 
-func (m *recoveringPollClient) request() ([]core.Job, error) {
+func (m *recoveringPollClient) request(_ string, _ int) ([]core.Job, error) {
 	m.count++
 	if m.count >= 5 {
 		return oneJobRequestImpl(core.READY)
@@ -108,6 +116,10 @@ func (m *recoveringPollClient) request() ([]core.Job, error) {
 	}
 }
 
+func (m *recoveringPollClient) requestCancelled(_ string, _ []core.ScheduledJob) ([]string, error) {
+	return []string{}, nil
+}
+
 func (m *recoveringPollClient) downloadUserProgram(jobId string) (string, error) {
 	return "", nil
 }
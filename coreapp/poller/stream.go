@@ -0,0 +1,185 @@
+package poller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+const (
+	DEFAULT_STREAM_RETRY_PERIOD = time.Duration(10) * time.Second
+	// the path of the server-sent events of the submitted jobs in the provider API
+	STREAM_PATH = "/jobs/stream"
+)
+
+// jobStreamClient delivers the submitted jobs of the devices as soon as they exist.
+// stream calls connected once the stream is established and blocks until the stream drops or ctx is done.
+type jobStreamClient interface {
+	stream(ctx context.Context, devices []string, connected func(), onJob func(core.Job) error) error
+}
+
+// sseStreamClient reads the server-sent events of the provider API at GET {endpoint}/jobs/stream,
+// whose data is a submitted job in the schema of the provider API. The stream starts with the jobs
+// submitted before the connection, e.g.
+//
+//	event: job
+//	data: {"job_id": "...", "status": "submitted", ...}
+//
+// The endpoint is an optional one of the provider API, which the cloud has to provide for Poller.Stream.
+type sseStreamClient struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+func newSSEStreamClient(endpoint string, apiKey string) *sseStreamClient {
+	return &sseStreamClient{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		// no timeout, the stream is kept open
+		client: &http.Client{},
+	}
+}
+
+func (c *sseStreamClient) stream(ctx context.Context, devices []string, connected func(), onJob func(core.Job) error) error {
+	q := url.Values{}
+	for _, d := range devices {
+		q.Add("device_id", d)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(c.endpoint, "/")+STREAM_PATH+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-Api-Key", c.apiKey)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the job stream: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to connect to the job stream/status:%s", res.Status)
+	}
+	connected()
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	event, data := "", []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// an event ends with an empty line
+			if len(data) > 0 && (event == "" || event == "job") {
+				if err := c.handleData(strings.Join(data, "\n"), onJob); err != nil {
+					return err
+				}
+			}
+			event, data = "", []string{}
+		case strings.HasPrefix(line, ":"):
+			// comment, e.g. keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("the job stream dropped: %w", err)
+	}
+	return fmt.Errorf("the job stream is closed by the server")
+}
+
+func (c *sseStreamClient) handleData(data string, onJob func(core.Job) error) error {
+	jobDef := api.JobsJobDef{}
+	if err := jobDef.UnmarshalJSON([]byte(data)); err != nil {
+		// the job is left to the polling
+		zap.L().Error(fmt.Sprintf("failed to decode a job in the stream/reason:%s", err))
+		return nil
+	}
+	jobs, err := toJobSlice([]api.JobsJobDef{jobDef})
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to convert a job(%s) in the stream/reason:%s", jobDef.JobID, err))
+		return nil
+	}
+	for _, j := range jobs {
+		if err := onJob(j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startStream keeps the job stream open in the background. The periodic polling is skipped while
+// the stream is open, and takes over when the stream drops or the queues do not accept the jobs.
+func (p *Poller) startStream() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.stopStream = cancel
+	go func() {
+		for {
+			err := p.checkStreamCondition()
+			if err == nil {
+				err = p.streamClient.stream(ctx, p.devices(),
+					func() {
+						zap.L().Info(fmt.Sprintf("connected to the job stream of %v", p.devices()))
+						p.streaming.Store(true)
+					},
+					p.handleStreamedJob)
+				p.streaming.Store(false)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			zap.L().Info(fmt.Sprintf("fall back to polling. retry the job stream after %s/reason:%s",
+				p.StreamRetryPeriod, err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.StreamRetryPeriod):
+			}
+		}
+	}()
+}
+
+func (p *Poller) checkStreamCondition() error {
+	for _, device := range p.devices() {
+		if err := passPollingCondition(device); err != nil {
+			return err
+		}
+		if p.sysCom.GetQueueFreeSlotsOf(device) <= 0 {
+			return fmt.Errorf("no free slot in the queue of %s", device)
+		}
+	}
+	return nil
+}
+
+// handleStreamedJob handles the job delivered in the stream. An error closes the stream, so that the
+// polling, which gets no jobs unless the queue accepts them, takes over.
+func (p *Poller) handleStreamedJob(j core.Job) error {
+	jd := j.JobData()
+	if jd.DeviceID == "" {
+		jd.DeviceID = p.devices()[0]
+	}
+	if err := passPollingCondition(jd.DeviceID); err != nil {
+		zap.L().Info(fmt.Sprintf("not handle the streamed job(%s). reason:%s", jd.ID, err))
+		return err
+	}
+	// the job would be refused and rejected for the full queue
+	if p.sysCom.GetQueueFreeSlotsOf(jd.DeviceID) <= 0 {
+		err := fmt.Errorf("no free slot in the queue of %s. current queue size:%d",
+			jd.DeviceID, p.sysCom.GetCurrentQueueSizeOf(jd.DeviceID))
+		zap.L().Info(fmt.Sprintf("not handle the streamed job(%s). reason:%s", jd.ID, err))
+		return err
+	}
+	p.handleJobs(jd.DeviceID, []core.Job{j})
+	return nil
+}
@@ -0,0 +1,168 @@
+//go:build unit
+// +build unit
+
+package poller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamedJob(jobID string) string {
+	return fmt.Sprintf(`{"job_id":"%s","device_id":"device1","shots":1000,"job_type":"sampling",`+
+		`"job_info":{"program":["OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\n"]},`+
+		`"transpiler_info":{"transpiler_lib":null},"status":"submitted","submitted_at":"2024-04-01T09:00:00Z"}`, jobID)
+}
+
+func TestSSEStreamClient(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != STREAM_PATH {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, []string{"device1"}, r.URL.Query()["device_id"])
+		assert.Equal(t, "test_key", r.Header.Get("X-Api-Key"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprintf(w, "event: job\ndata: %s\n\n", streamedJob("job1"))
+		fmt.Fprint(w, "event: other\ndata: {}\n\n")
+		fmt.Fprint(w, "data: {broken\n\n")
+		fmt.Fprintf(w, "data: %s\n\n", streamedJob("job2"))
+	}))
+	defer srv.Close()
+
+	c := newSSEStreamClient(srv.URL, "test_key")
+	connected := false
+	jobIDs := []string{}
+	err = c.stream(context.Background(), []string{"device1"},
+		func() { connected = true },
+		func(j core.Job) error {
+			jobIDs = append(jobIDs, j.JobData().ID)
+			assert.Equal(t, sampling.SAMPLING_JOB, j.JobData().JobType)
+			return nil
+		})
+	assert.EqualError(t, err, "the job stream is closed by the server")
+	assert.True(t, connected)
+	assert.Equal(t, []string{"job1", "job2"}, jobIDs)
+
+	c = newSSEStreamClient(srv.URL+"/unknown", "test_key")
+	err = c.stream(context.Background(), []string{"device1"}, func() {}, func(core.Job) error { return nil })
+	assert.NotNil(t, err)
+}
+
+type blockingStreamClient struct {
+	jobs      []core.Job
+	delivered chan struct{}
+	drop      chan struct{}
+	tries     int
+}
+
+func (c *blockingStreamClient) stream(ctx context.Context, _ []string, connected func(), onJob func(core.Job) error) error {
+	c.tries++
+	connected()
+	for _, j := range c.jobs {
+		if err := onJob(j); err != nil {
+			return err
+		}
+	}
+	c.jobs = nil
+	close(c.delivered)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.drop:
+		return fmt.Errorf("dropped")
+	}
+}
+
+func TestStreamFallback(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	jobs, err := oneJobRequestImpl(core.READY)
+	assert.Nil(t, err)
+
+	p := &Poller{
+		Device:            "device1",
+		Count:             1,
+		NormalPeriod:      1,
+		IdlePeriod:        1,
+		MaxRetry:          3,
+		StreamRetryPeriod: time.Hour,
+	}
+	assert.Nil(t, p.Setup())
+	p.pollClient = &zeroJobsPollClient{}
+	c := &blockingStreamClient{jobs: jobs, delivered: make(chan struct{}), drop: make(chan struct{})}
+	p.streamClient = c
+	p.startStream()
+	defer p.Cleanup()
+
+	<-c.delivered
+	assert.True(t, p.streaming.Load())
+	p.Task()
+	assert.Equal(t, STREAMING, p.state)
+	assert.Equal(t, "device1", jobs[0].JobData().DeviceID)
+
+	// the polling takes over when the stream drops
+	close(c.drop)
+	assert.Eventually(t, func() bool { return !p.streaming.Load() }, time.Second, 10*time.Millisecond)
+	p.Task()
+	assert.Equal(t, SUB_IDLE, p.state)
+	assert.Equal(t, 1, c.tries)
+}
+
+// slotScheduler records the handled jobs while the queue has free slots
+type slotScheduler struct {
+	core.Scheduler
+	free    int
+	handled []core.Job
+}
+
+func (s *slotScheduler) Setup(*core.Conf) error        { return nil }
+func (s *slotScheduler) GetCurrentQueueSize() int      { return len(s.handled) }
+func (s *slotScheduler) GetQueueFreeSlots() int        { return s.free }
+func (s *slotScheduler) IsOverRefillThreshold() bool   { return false }
+func (s *slotScheduler) ListJobs() []core.ScheduledJob { return nil }
+func (s *slotScheduler) HandleJob(j core.Job) error {
+	if j.JobData().Status != core.READY {
+		return fmt.Errorf("the job(%s) is not ready", j.JobData().ID)
+	}
+	s.handled = append(s.handled, j)
+	s.free--
+	return nil
+}
+
+func TestHandleStreamedJob(t *testing.T) {
+	sc := &slotScheduler{free: 1}
+	s := core.SCWithScheduler(sc)
+	defer s.TearDown()
+	jobs, err := oneJobRequestImpl(core.SUBMITTED)
+	assert.Nil(t, err)
+	full, err := oneJobRequestImpl(core.SUBMITTED)
+	assert.Nil(t, err)
+
+	p := &Poller{Device: "device1", sysCom: s}
+
+	// the submitted job is accepted in READY
+	assert.Nil(t, p.handleStreamedJob(jobs[0]))
+	assert.Equal(t, jobs, sc.handled)
+	assert.Equal(t, core.READY, jobs[0].JobData().Status)
+	assert.Equal(t, "device1", jobs[0].JobData().DeviceID)
+
+	// the job is left to the polling for the full queue
+	assert.NotNil(t, p.handleStreamedJob(full[0]))
+	assert.Equal(t, jobs, sc.handled)
+	assert.Equal(t, core.SUBMITTED, full[0].JobData().Status)
+}
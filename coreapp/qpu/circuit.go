@@ -21,7 +21,7 @@ func (c *Circuit) ProgramContext() *parser.ProgramContext {
 	return c.programContext
 }
 
-func circuitValidate(qasm string, ds *DeviceSetting) error {
+func circuitValidate(qasm string, ds *DeviceSetting, di *core.DeviceInfo) error {
 	if qasm == "" {
 		msg := "no input qasm"
 		zap.L().Info(msg)
@@ -36,7 +36,6 @@ func circuitValidate(qasm string, ds *DeviceSetting) error {
 		zap.L().Info(err.Error())
 		return err
 	}
-	di := core.GetSystemComponents().GetDeviceInfo()
 	if di.Status != core.Available {
 		msg := fmt.Sprintf("device is not available. status:%s", di.Status)
 		zap.L().Info(msg)
@@ -160,6 +159,19 @@ func filterList(circ *Circuit, list []*QASMStatementType, returnIfFiltered bool)
 	return nil
 }
 
+// CountQubits returns the number of the qubits declared in the OpenQASM 3 program.
+func CountQubits(qasm string) (int, error) {
+	circ, err := ParseQASM(qasm)
+	if err != nil {
+		return 0, err
+	}
+	circIR, err := NewCircuitIR(circ.programContext)
+	if err != nil {
+		return 0, err
+	}
+	return circIR.ProgramIR.QubitCount, nil
+}
+
 func checkResource(circ *Circuit, qubinNumber int) error {
 	circIR, err := NewCircuitIR(circ.programContext)
 	if err != nil {
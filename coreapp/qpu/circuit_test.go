@@ -21,7 +21,8 @@ var testDeviceSetting *DeviceSetting = &DeviceSetting{
 func TestCircuitValidate(t *testing.T) {
 	s := core.SCWithUnimplementedContainer()
 	defer s.TearDown()
-	maxQubits := s.GetDeviceInfo().MaxQubits
+	di := s.GetDeviceInfo()
+	maxQubits := di.MaxQubits
 	assert.Equal(t, maxQubits, core.MockMaxQubits)
 
 	tests := []struct {
@@ -92,7 +93,7 @@ func TestCircuitValidate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := circuitValidate(tt.qasm, tt.deviceSetting)
+			err := circuitValidate(tt.qasm, tt.deviceSetting, di)
 			if tt.wantErrorMsg == "" {
 				assert.Nil(t, err)
 			} else {
@@ -11,6 +11,7 @@ import (
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/common"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	qint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/qpu/qpu_interface/v1"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/jobservice"
 	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -20,7 +21,7 @@ import (
 type GatewayAgent interface {
 	Setup() error
 	CallDeviceInfo() (*core.DeviceInfo, error)
-	CallJob(core.Job) error
+	CallJob(context.Context, core.Job) error
 	Reset()
 	Close()
 
@@ -45,29 +46,39 @@ func NewDefaultGatewayAgentSetting() DefaultGatewayAgentSetting {
 	}
 }
 
+const GATEWAY_SETTING_KEY = "gateway"
+
 type DefaultGatewayAgent struct {
+	settingKey     string
 	setting        DefaultGatewayAgentSetting
 	gatewayAddress string
 	gatewayConn    *grpc.ClientConn
 	apiConn        *grpc.ClientConn
 	gatewayClient  qint.QpuServiceClient
 	apiClient      *api.Client
+	jobService     *jobservice.Client
 	ctx            context.Context
 
 	lastDeviceInfo *core.DeviceInfo
 }
 
 func NewGatewayAgent() *DefaultGatewayAgent {
-	return &DefaultGatewayAgent{}
+	return NewGatewayAgentWithSetting(GATEWAY_SETTING_KEY)
+}
+
+// NewGatewayAgentWithSetting returns the agent of the gateway set in [com.<settingKey>],
+// e.g. one of the gateways of the devices hosted in one engine.
+func NewGatewayAgentWithSetting(settingKey string) *DefaultGatewayAgent {
+	return &DefaultGatewayAgent{settingKey: settingKey}
 }
 
 func (q *DefaultGatewayAgent) Setup() (err error) {
-	s, ok := core.GetComponentSetting("gateway")
+	s, ok := core.GetComponentSetting(q.settingKey)
 	if !ok {
-		msg := "gateway setting is not found"
+		msg := fmt.Sprintf("%s setting is not found", q.settingKey)
 		return fmt.Errorf(msg)
 	}
-	zap.L().Debug(fmt.Sprintf("gateway setting:%v", s))
+	zap.L().Debug(fmt.Sprintf("%s setting:%v", q.settingKey, s))
 	// TODO: fix this adhoc
 	// partial setting is not allowed...
 	mapped, ok := s.(map[string]interface{})
@@ -103,6 +114,12 @@ func (q *DefaultGatewayAgent) Setup() (err error) {
 	}
 	zap.L().Info("API Client created with local logging transport", zap.String("endpoint", q.setting.APIEndpoint))
 	q.apiClient = apiClient
+	if jobservice.UseGRPC() {
+		// the device is updated through the JobService instead of the provider API
+		if q.jobService, err = jobservice.NewClient(jobservice.GetJobServiceSetting()); err != nil {
+			return fmt.Errorf("failed to create job service client: %w", err)
+		}
+	}
 
 	q.Reset()
 	return nil
@@ -137,6 +154,8 @@ func (q *DefaultGatewayAgent) CallDeviceInfo() (*core.DeviceInfo, error) {
 		DeviceInfoSpecJson: di.DeviceInfo,
 		CalibratedAt:       di.CalibratedAt,
 	}
+	// the device reserved by the operators is unavailable for the users until the end of the reservation
+	cd = core.ApplyReservation(cd, time.Now())
 	q.callDeviceAPIOnChange(cd)
 	return cd, nil
 }
@@ -155,7 +174,7 @@ func mapServiceStatusToDeviceStatus(ss qint.ServiceStatus) core.DeviceStatus {
 	}
 }
 
-func (q *DefaultGatewayAgent) CallJob(j core.Job) error {
+func (q *DefaultGatewayAgent) CallJob(ctx context.Context, j core.Job) error {
 	var qasmToBeSent string
 	if j.JobData().TranspiledQASM == "" {
 		qasmToBeSent = j.JobData().QASM
@@ -166,7 +185,8 @@ func (q *DefaultGatewayAgent) CallJob(j core.Job) error {
 	zap.L().Debug(fmt.Sprintf("Sending a job to QPU/"+
 		"JobID:%s, Shots:%d,QASM:%s", j.JobData().ID, j.JobData().Shots, qasmToBeSent))
 	startTime := time.Now()
-	resp, err := q.gatewayClient.CallJob(q.ctx, &qint.CallJobRequest{
+	// the request is aborted when the job is cancelled or overruns its deadline
+	resp, err := q.gatewayClient.CallJob(ctx, &qint.CallJobRequest{
 		JobId:   j.JobData().ID,
 		Shots:   uint32(j.JobData().Shots),
 		Program: qasmToBeSent,
@@ -177,16 +197,22 @@ func (q *DefaultGatewayAgent) CallJob(j core.Job) error {
 	}
 	endTime := time.Now()
 	// TODO: fix this SRP violation
+	var st core.Status
 	switch resp.GetStatus() {
 	case qint.JobStatus_JOB_STATUS_SUCCESS:
-		j.JobData().Status = core.SUCCEEDED
+		st = core.SUCCEEDED
 	case qint.JobStatus_JOB_STATUS_FAILURE, qint.JobStatus_JOB_STATUS_INACTIVE:
-		j.JobData().Status = core.FAILED
+		st = core.FAILED
 	default:
 		msg := fmt.Sprintf("unknown status %d", resp.GetStatus())
 		zap.L().Error(msg)
 		return fmt.Errorf(msg)
 	}
+	if err := j.JobData().SetStatus(st); err != nil {
+		// the result of the QPU is not given to the job left in the illegal status
+		core.SetFailureWithError(j, core.NewInternalError("the job failed unexpectedly", err))
+		return nil
+	}
 	zap.L().Debug(fmt.Sprintf("JobID:%s, Status:%s", j.JobData().ID, j.JobData().Status))
 
 	r := j.JobData().Result
@@ -228,7 +254,7 @@ func (q *DefaultGatewayAgent) GetAddress() string {
 func (q *DefaultGatewayAgent) callDeviceAPIOnChange(newDI *core.DeviceInfo) { // Renamed function definition
 	updated := false
 	if hasStatusChanged(q.lastDeviceInfo, newDI) {
-		if err := q.updateDeviceStatus(newDI.Status); err != nil {
+		if err := q.updateDeviceStatus(newDI); err != nil {
 			zap.L().Error(fmt.Sprintf("failed to update device status/reason:%s", err))
 		} else {
 			updated = true
@@ -255,10 +281,20 @@ func (q *DefaultGatewayAgent) callDeviceAPIOnChange(newDI *core.DeviceInfo) { //
 	}
 }
 
-func (q *DefaultGatewayAgent) updateDeviceStatus(st core.DeviceStatus) error {
-	apiSt := toDeviceDeviceStatusUpdateStatus(st)
-	req := api.NewOptDevicesDeviceStatusUpdate(
-		api.DevicesDeviceStatusUpdate{Status: apiSt})
+func (q *DefaultGatewayAgent) updateDeviceStatus(di *core.DeviceInfo) error {
+	apiSt := toDeviceDeviceStatusUpdateStatus(di.Status)
+	if q.jobService != nil {
+		var availableAt *time.Time
+		if apiSt == api.DevicesDeviceStatusUpdateStatusUnavailable {
+			availableAt = di.AvailableAt
+		}
+		return q.jobService.UpdateDeviceStatus(q.setting.DeviceId, string(apiSt), availableAt)
+	}
+	update := api.DevicesDeviceStatusUpdate{Status: apiSt}
+	if di.AvailableAt != nil && apiSt == api.DevicesDeviceStatusUpdateStatusUnavailable {
+		update.AvailableAt = api.NewOptNilDateTime(*di.AvailableAt)
+	}
+	req := api.NewOptDevicesDeviceStatusUpdate(update)
 	params := api.PatchDeviceStatusParams{
 		DeviceID: q.setting.DeviceId,
 	}
@@ -282,6 +318,9 @@ func (q *DefaultGatewayAgent) updateDeviceInfo(di *core.DeviceInfo) error {
 		zap.L().Error(fmt.Sprintf("failed to parse time %s/reason:%s", di.CalibratedAt, err))
 		return err
 	}
+	if q.jobService != nil {
+		return q.jobService.UpdateDeviceInfo(q.setting.DeviceId, di.DeviceInfoSpecJson, jobservice.FormatTime(caStr))
+	}
 
 	req := api.NewOptDevicesDeviceInfoUpdate(
 		api.DevicesDeviceInfoUpdate{
@@ -307,6 +346,10 @@ func (q *DefaultGatewayAgent) updateDeviceInfo(di *core.DeviceInfo) error {
 }
 
 func (q *DefaultGatewayAgent) updateDevice(di *core.DeviceInfo) error {
+	if q.jobService != nil {
+		zap.L().Debug("the number of the qubits is not updated through the job service")
+		return nil
+	}
 	req := api.NewOptDevicesUpdateDeviceRequest(
 		api.DevicesUpdateDeviceRequest{
 			NQubits: api.NewOptNilInt(int(di.MaxQubits)),
@@ -363,9 +406,20 @@ func hasStatusChanged(oldSt, newSt *core.DeviceInfo) bool {
 		zap.L().Debug("Status is changed")
 		return true
 	}
+	if !equalTimePtr(oldSt.AvailableAt, newSt.AvailableAt) {
+		zap.L().Debug("AvailableAt is changed")
+		return true
+	}
 	return false
 }
 
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func hasDeviceInfoChanged(oldDI, newDI *core.DeviceInfo) bool {
 	if oldDI == nil {
 		zap.L().Debug("old device info is nil")
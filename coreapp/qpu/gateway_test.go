@@ -4,11 +4,14 @@
 package qpu
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	qint "github.com/oqtopus-team/oqtopus-engine/coreapp/gen/qpu/qpu_interface/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 func TestParseRFC3339Time(t *testing.T) {
@@ -120,3 +123,99 @@ func Test_hasDeviceChanged(t *testing.T) {
 		})
 	}
 }
+
+func Test_hasStatusChanged(t *testing.T) {
+	at := time.Date(2099, 1, 5, 12, 0, 0, 0, time.UTC)
+	later := at.Add(time.Hour)
+	tests := []struct {
+		name     string
+		oldDI    *core.DeviceInfo
+		newDI    *core.DeviceInfo
+		expected bool
+	}{
+		{
+			name:     "Status is changed",
+			oldDI:    &core.DeviceInfo{Status: core.Available},
+			newDI:    &core.DeviceInfo{Status: core.Unavailable, AvailableAt: &at},
+			expected: true,
+		},
+		{
+			name:     "AvailableAt is changed",
+			oldDI:    &core.DeviceInfo{Status: core.Unavailable, AvailableAt: &at},
+			newDI:    &core.DeviceInfo{Status: core.Unavailable, AvailableAt: &later},
+			expected: true,
+		},
+		{
+			name:     "Nothing is changed",
+			oldDI:    &core.DeviceInfo{Status: core.Unavailable, AvailableAt: &at},
+			newDI:    &core.DeviceInfo{Status: core.Unavailable, AvailableAt: &at},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := hasStatusChanged(tt.oldDI, tt.newDI)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+type callJobClient struct {
+	qint.QpuServiceClient
+	status qint.JobStatus
+}
+
+func (c *callJobClient) CallJob(context.Context, *qint.CallJobRequest, ...grpc.CallOption) (*qint.CallJobResponse, error) {
+	return &qint.CallJobResponse{
+		Status: c.status,
+		Result: &qint.Result{Counts: map[string]uint32{"00": 10}, Message: "done"},
+	}, nil
+}
+
+func TestCallJobStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      qint.JobStatus
+		firstStatus core.Status
+		wantStatus  core.Status
+		wantCounts  core.Counts
+	}{
+		{
+			name:        "succeeded",
+			status:      qint.JobStatus_JOB_STATUS_SUCCESS,
+			firstStatus: core.RUNNING,
+			wantStatus:  core.SUCCEEDED,
+			wantCounts:  core.Counts{"00": 10},
+		},
+		{
+			name:        "failed",
+			status:      qint.JobStatus_JOB_STATUS_FAILURE,
+			firstStatus: core.RUNNING,
+			wantStatus:  core.FAILED,
+			wantCounts:  core.Counts{"00": 10},
+		},
+		{
+			name:        "illegal transition",
+			status:      qint.JobStatus_JOB_STATUS_SUCCESS,
+			firstStatus: core.READY,
+			wantStatus:  core.FAILED,
+			wantCounts:  core.Counts{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &DefaultGatewayAgent{gatewayClient: &callJobClient{status: tt.status}}
+			jd := core.NewJobData()
+			jd.ID = "job"
+			jd.Status = tt.firstStatus
+			j := (&core.NormalJob{}).New(jd, nil)
+			assert.Nil(t, q.CallJob(context.Background(), j))
+			assert.Equal(t, tt.wantStatus, jd.Status)
+			assert.Equal(t, tt.wantCounts, jd.Result.Counts)
+			if tt.firstStatus == core.READY {
+				assert.Equal(t, core.InternalError, jd.Result.ErrorKind)
+			}
+		})
+	}
+}
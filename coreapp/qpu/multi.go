@@ -0,0 +1,104 @@
+package qpu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+// MultiQPU hosts the QPU backends of several devices in one engine, e.g. the simulators next to
+// the real hardware. A job is sent to the backend of its device, and to the default device when
+// the job has no device. The backends are set in [com.devices].
+type MultiQPU struct {
+	backends      map[string]core.QPUManager
+	defaultDevice string
+}
+
+func (m *MultiQPU) Setup(conf *core.Conf) error {
+	s := core.GetDevicesSetting()
+	if err := s.Validate(); err != nil {
+		zap.L().Error(fmt.Sprintf("invalid devices setting/reason:%s", err))
+		return err
+	}
+	m.backends = make(map[string]core.QPUManager)
+	m.defaultDevice = s.Default
+	for _, deviceID := range s.DeviceIDs() {
+		b, err := newBackend(s.Backends[deviceID])
+		if err != nil {
+			return fmt.Errorf("failed to create the backend of %s: %w", deviceID, err)
+		}
+		zap.L().Info(fmt.Sprintf("setting up the backend of %s", deviceID))
+		if err := b.Setup(conf); err != nil {
+			zap.L().Error(fmt.Sprintf("failed to setup the backend of %s/reason:%s", deviceID, err))
+			return err
+		}
+		m.backends[deviceID] = b
+	}
+	return nil
+}
+
+func newBackend(b core.BackendSetting) (core.QPUManager, error) {
+	switch b.QPU {
+	case "dummy":
+		return &DummyQPU{}, nil
+	case "gateway":
+		return &GatewayQPU{SettingKey: b.GatewaySetting}, nil
+	default:
+		return nil, fmt.Errorf("%s is an unknown QPU", b.QPU)
+	}
+}
+
+func (m *MultiQPU) backend(deviceID string) (core.QPUManager, error) {
+	if deviceID == "" {
+		deviceID = m.defaultDevice
+	}
+	b, ok := m.backends[deviceID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", core.ErrorUnknownDevice, deviceID)
+	}
+	return b, nil
+}
+
+func (m *MultiQPU) Send(ctx context.Context, j core.Job) error {
+	b, err := m.backend(j.JobData().DeviceID)
+	if err != nil {
+		return err
+	}
+	return b.Send(ctx, j)
+}
+
+// Stop stops the backends running goroutines.
+func (m *MultiQPU) Stop() {
+	for _, b := range m.backends {
+		if st, ok := b.(core.Stopper); ok {
+			st.Stop()
+		}
+	}
+}
+
+// Validate validates the QASM for the default device. ValidateOf is used for the device of the job.
+func (m *MultiQPU) Validate(qasm string) error {
+	return m.backends[m.defaultDevice].Validate(qasm)
+}
+
+func (m *MultiQPU) ValidateOf(deviceID string, qasm string) error {
+	b, err := m.backend(deviceID)
+	if err != nil {
+		return err
+	}
+	return b.Validate(qasm)
+}
+
+func (m *MultiQPU) GetDeviceInfo() *core.DeviceInfo {
+	return m.backends[m.defaultDevice].GetDeviceInfo()
+}
+
+func (m *MultiQPU) GetDeviceInfoOf(deviceID string) (*core.DeviceInfo, error) {
+	b, err := m.backend(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return b.GetDeviceInfo(), nil
+}
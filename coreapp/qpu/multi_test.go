@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package qpu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+)
+
+func registerDevicesSetting() {
+	core.ResetSetting()
+	core.RegisterSetting(core.DEVICES_SETTING_KEY, map[string]interface{}{
+		"default": "real",
+		"backends": map[string]interface{}{
+			"real":      map[string]interface{}{"qpu": "dummy"},
+			"simulator": map[string]interface{}{"qpu": "dummy"},
+		},
+	})
+}
+
+func TestMultiQPU(t *testing.T) {
+	registerDevicesSetting()
+	defer core.ResetSetting()
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	m := &MultiQPU{}
+	assert.Nil(t, m.Setup(&core.Conf{}))
+
+	for _, deviceID := range []string{"", "real", "simulator"} {
+		di, err := m.GetDeviceInfoOf(deviceID)
+		assert.Nil(t, err)
+		assert.Equal(t, core.Available, di.Status)
+	}
+	_, err := m.GetDeviceInfoOf("unknown")
+	assert.ErrorIs(t, err, core.ErrorUnknownDevice)
+	assert.Nil(t, m.ValidateOf("simulator", "OPENQASM 3;"))
+	assert.ErrorIs(t, m.ValidateOf("unknown", "OPENQASM 3;"), core.ErrorUnknownDevice)
+
+	jm, err := core.NewJobManager(&core.NormalJob{})
+	assert.Nil(t, err)
+	jd := core.NewJobData()
+	jd.ID = "test_multi_qpu"
+	jd.Status = core.RUNNING
+	jd.DeviceID = "simulator"
+	jc, _ := core.NewJobContext()
+	j, err := jm.NewJobFromJobData(jd, jc)
+	assert.Nil(t, err)
+	assert.Nil(t, m.Send(context.Background(), j))
+
+	jd.DeviceID = "unknown"
+	assert.ErrorIs(t, m.Send(context.Background(), j), core.ErrorUnknownDevice)
+}
+
+func TestMultiQPUInvalidSetting(t *testing.T) {
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(core.DEVICES_SETTING_KEY, map[string]interface{}{
+		"default": "missing",
+		"backends": map[string]interface{}{
+			"real": map[string]interface{}{"qpu": "dummy"},
+		},
+	})
+	assert.NotNil(t, (&MultiQPU{}).Setup(&core.Conf{}))
+}
@@ -1,17 +1,18 @@
 package qpu
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
-	"os"
-	"os/signal"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const successPropability float32 = 0.9
@@ -37,13 +38,17 @@ func (d *DummyQPU) Setup(conf *core.Conf) error {
 	return nil
 }
 
-func (d *DummyQPU) Send(inputJob core.Job) error {
+func (d *DummyQPU) Send(ctx context.Context, inputJob core.Job) error {
 	outputJobData := core.CloneJobData(inputJob.JobData())
 
 	zap.L().Info("[Dummy] starting QPU execution")
 	if d.EnableDummyQPUTimeInsertion {
 		zap.L().Debug(fmt.Sprintf("[Dummy] waiting %d seconds for QPU execution", d.DummyQPUTime))
-		<-time.After(time.Duration(d.DummyQPUTime) * time.Second)
+		select {
+		case <-time.After(time.Duration(d.DummyQPUTime) * time.Second):
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
 	} else {
 		zap.L().Debug("[Dummy] no waiting for QPU execution")
 	}
@@ -54,13 +59,13 @@ func (d *DummyQPU) Send(inputJob core.Job) error {
 	if err != nil {
 		return err
 	}
-	job.JobContext().DBChan <- job
+	job.JobContext().Publish(core.EventTypeOf(job.JobData().Status), job)
 	return nil
 }
 
 func (d *DummyQPU) Validate(qasm string) error {
 	return nil //adhoc
-	// return circuitValidate(qasm, d.deviceSetting)
+	// return circuitValidate(qasm, d.deviceSetting, d.GetDeviceInfo())
 }
 
 func (d *DummyQPU) GetDeviceInfo() *core.DeviceInfo {
@@ -82,13 +87,19 @@ func successOrFailure() string {
 }
 
 type GatewayQPU struct {
-	agent             GatewayAgent
-	deviceSetting     *DeviceSetting
+	agent         GatewayAgent
+	deviceSetting *DeviceSetting
+	// guards connected and currentDeviceInfo, which are updated by the device polling
+	mu                sync.RWMutex
 	connected         bool
 	currentDeviceInfo *core.DeviceInfo
+	// stops the device polling
+	stopPolling context.CancelFunc
 
 	EnableDummyQPUTimeInsertion bool
 	DummyQPUTime                int
+	// the component setting of the gateway. GATEWAY_SETTING_KEY when empty
+	SettingKey string
 }
 
 func (q *GatewayQPU) Setup(conf *core.Conf) error {
@@ -104,7 +115,10 @@ func (q *GatewayQPU) Setup(conf *core.Conf) error {
 	}
 	switch ds.DeviceName {
 	case "wako", "handai":
-		q.agent = NewGatewayAgent()
+		if q.SettingKey == "" {
+			q.SettingKey = GATEWAY_SETTING_KEY
+		}
+		q.agent = NewGatewayAgentWithSetting(q.SettingKey)
 		zap.L().Debug(fmt.Sprintf("Setting up Gateway QPU for %s", ds.DeviceName))
 	default:
 		return fmt.Errorf("unknown device name:%s", ds.DeviceName)
@@ -114,38 +128,37 @@ func (q *GatewayQPU) Setup(conf *core.Conf) error {
 		return err
 	}
 	q.deviceSetting = ds
-	q.connected = false
+	q.setDeviceInfo(&core.DeviceInfo{Status: core.Unavailable}, false)
 	if !conf.DisableStartDevicePolling {
 		q.startDevicePolling()
 	}
-	q.currentDeviceInfo = &core.DeviceInfo{
-		Status: core.Unavailable,
-	}
 	return nil
 }
 
 func (q *GatewayQPU) Validate(qasm string) error {
 	return nil //adhoc
-	//return circuitValidate(qasm, q.deviceSetting)
+	//return circuitValidate(qasm, q.deviceSetting, q.GetDeviceInfo())
 }
 
-func (q *GatewayQPU) Send(j core.Job) error {
+func (q *GatewayQPU) Send(ctx context.Context, j core.Job) error {
 	var err error
 	jd := j.JobData()
 	zap.L().Info("Starting Gateway QPU execution of Job ID:" + jd.ID)
 
 	if !q.GetConnected() {
-		err := fmt.Errorf("Gateway QPU is not connected")
-		msg := core.SetFailureWithError(j, err)
+		msg := core.SetFailureWithError(j, core.NewDeviceUnavailableError("Gateway QPU is not connected", nil))
 		zap.L().Info(msg)
+		// Unavailable to be retried while the gateway is restarting
+		err := status.Error(codes.Unavailable, msg)
 		return err
 	}
 	zap.L().Debug(fmt.Sprintf("Job ID:%s is processing", jd.ID))
-	err = q.agent.CallJob(j)
+	err = q.agent.CallJob(ctx, j)
 
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("Failed to Call the job (%s) in %s. Reeason:%s",
 			jd.ID, q.agent.GetAddress(), err))
+		err = core.NewDeviceUnavailableError("failed to run the job on the QPU", err)
 		msg := core.SetFailureWithError(j, err)
 		zap.L().Info(msg)
 		return err
@@ -156,43 +169,57 @@ func (q *GatewayQPU) Send(j core.Job) error {
 }
 
 func (q *GatewayQPU) GetDeviceInfo() *core.DeviceInfo {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
 	return q.currentDeviceInfo
 }
 
 func (q *GatewayQPU) GetConnected() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
 	return q.connected
 }
 
+func (q *GatewayQPU) setDeviceInfo(di *core.DeviceInfo, connected bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.currentDeviceInfo = di
+	q.connected = connected
+}
+
 func (q *GatewayQPU) startDevicePolling() {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.stopPolling = cancel
 	go func() {
 		t := time.NewTicker(time.Duration(q.deviceSetting.PollingPeriod) * time.Second)
+		defer t.Stop()
 		zap.L().Debug("Starting Device Polling")
-		q.startCleanUpGoroutine(t)
 		for {
 			di, err := q.agent.CallDeviceInfo()
 			if err != nil {
 				zap.L().Error(fmt.Sprintf("Failed to call device info. Reason:%s", err))
-				q.currentDeviceInfo = &core.DeviceInfo{Status: core.Unavailable}
-				q.connected = false
+				q.setDeviceInfo(&core.DeviceInfo{Status: core.Unavailable}, false)
 			} else {
-				q.currentDeviceInfo = di
-				q.connected = true
+				q.setDeviceInfo(di, true)
 			}
 			zap.L().Debug(fmt.Sprintf(
 				"Waiting %d seconds for the next device polling to %s",
 				q.deviceSetting.PollingPeriod, q.agent.GetAddress()))
-			<-t.C
+			select {
+			case <-ctx.Done():
+				zap.L().Debug("Stopped Device Polling")
+				return
+			case <-t.C:
+			}
 		}
 	}()
 }
 
-// TODO use run Group
-func (q *GatewayQPU) startCleanUpGoroutine(t *time.Ticker) {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-c
-		t.Stop()
-		q.agent.Close()
-	}()
+// Stop stops the device polling and closes the connection to the gateway. It is called after the drain,
+// because the jobs in flight call the gateway until they have finished.
+func (q *GatewayQPU) Stop() {
+	if q.stopPolling != nil {
+		q.stopPolling()
+	}
+	q.agent.Close()
 }
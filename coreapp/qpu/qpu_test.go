@@ -4,6 +4,7 @@
 package qpu
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -85,7 +86,7 @@ func TestGatewayQPUSend(t *testing.T) {
 			nj, err := jm.NewJobFromJobData(jd, jc)
 			assert.Nil(t, err)
 
-			sendErr := gatewayQPU.Send(nj)
+			sendErr := gatewayQPU.Send(context.Background(), nj)
 			if sendErr != nil {
 				assert.Regexp(t, tt.wantErr, sendErr)
 			}
@@ -102,7 +103,7 @@ func (m *MockGatewayAgent) Setup() error {
 	return nil
 }
 
-func (m *MockGatewayAgent) CallJob(j core.Job) error {
+func (m *MockGatewayAgent) CallJob(ctx context.Context, j core.Job) error {
 	return nil
 }
 
@@ -124,8 +125,40 @@ type MockGatewayAgentError struct {
 	MockGatewayAgent
 }
 
-func (m *MockGatewayAgentError) CallJob(j core.Job) error {
+func (m *MockGatewayAgentError) CallJob(ctx context.Context, j core.Job) error {
 	jd := j.JobData()
 	jd.Result.Message = "failed to call job"
 	return nil
 }
+
+type closeRecordingGatewayAgent struct {
+	MockGatewayAgent
+	closed chan struct{}
+}
+
+func (m *closeRecordingGatewayAgent) Close() {
+	close(m.closed)
+}
+
+func TestGatewayQPUStop(t *testing.T) {
+	agent := &closeRecordingGatewayAgent{closed: make(chan struct{})}
+	q := &GatewayQPU{
+		agent:         agent,
+		deviceSetting: &DeviceSetting{PollingPeriod: 1},
+	}
+	q.startDevicePolling()
+	assert.Eventually(t, q.GetConnected, time.Second, 10*time.Millisecond)
+
+	// the connection is kept until the components are stopped after the drain
+	select {
+	case <-agent.closed:
+		t.Fatal("the connection is closed before Stop")
+	default:
+	}
+	q.Stop()
+	select {
+	case <-agent.closed:
+	default:
+		t.Fatal("the connection is not closed by Stop")
+	}
+}
@@ -0,0 +1,202 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/oas"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"go.uber.org/zap"
+)
+
+const RestServerName = "rest"
+
+const (
+	DEFAULT_ADDRESS          = "localhost:8091"
+	DEFAULT_SHUTDOWN_TIMEOUT = 5 * time.Second
+)
+
+// RestServerImpl serves the HTTP API to submit the jobs to the engine directly, without the cloud,
+// e.g. for the users next to the device. The jobs are in the schemas of the provider API.
+// The status and the result are read from the DBManager, so that a local DB, memory or bolt, is needed.
+//
+//	POST /jobs           submits a job and returns the job in ready
+//	GET  /jobs/{job_id}  the job with its status and result
+type RestServerImpl struct {
+	Address string `toml:"address"`
+
+	server *http.Server
+}
+
+// JobRequest is the job to submit. The job ID, the status and the submission time are given by the engine.
+type JobRequest struct {
+	DeviceID       string                             `json:"device_id"`
+	Shots          int                                `json:"shots"`
+	JobType        api.JobsJobType                    `json:"job_type"`
+	JobInfo        api.JobsJobInfo                    `json:"job_info"`
+	TranspilerInfo api.OptNilJobsJobDefTranspilerInfo `json:"transpiler_info"`
+	MitigationInfo api.OptNilJobsJobDefMitigationInfo `json:"mitigation_info"`
+	Priority       api.OptString                      `json:"priority"`
+	Owner          api.OptString                      `json:"owner"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func (r *RestServerImpl) GetEmptyParams() interface{} {
+	return r
+}
+
+func (r *RestServerImpl) SetParams(p interface{}) error {
+	if p == nil {
+		zap.L().Debug("no params for rest server")
+		return nil
+	}
+	mp, ok := p.(map[string]interface{})
+	if !ok {
+		msg := fmt.Errorf("failed to set params for rest server/params: %s", p)
+		zap.L().Error(msg.Error())
+		return msg
+	}
+	if address, ok := mp["address"].(string); ok {
+		r.Address = address
+	}
+	return nil
+}
+
+func (r *RestServerImpl) Setup() error {
+	if r.Address == "" {
+		r.Address = DEFAULT_ADDRESS
+	}
+	r.server = &http.Server{
+		Addr:    r.Address,
+		Handler: NewHandler(core.GetSystemComponents()),
+	}
+	return nil
+}
+
+func (r *RestServerImpl) Serve() error {
+	zap.L().Info(fmt.Sprintf("rest server is listening on %s", r.Address))
+	if err := r.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (r *RestServerImpl) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SHUTDOWN_TIMEOUT)
+	defer cancel()
+	if err := r.server.Shutdown(ctx); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to shut down rest server/reason:%s", err))
+	}
+}
+
+func NewHandler(s *core.SystemComponents) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", func(w http.ResponseWriter, r *http.Request) {
+		req := JobRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid job: %s", err))
+			return
+		}
+		j, err := newJob(&req)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		jd := j.JobData()
+		if err := s.Invoke(func(d core.DBManager) error { return d.Insert(j) }); err != nil {
+			zap.L().Error(fmt.Sprintf("failed to insert job(%s)/reason:%s", jd.ID, err))
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		// the job is written in ready before it is handled, so that it is found as soon as it is returned
+		res := oas.ConvertToCloudJob(jd.Clone())
+		if err := s.Invoke(func(sc core.Scheduler) error { return sc.HandleJob(j) }); err != nil {
+			zap.L().Info(fmt.Sprintf("the job(%s) is refused. Reason:%s", jd.ID, err))
+			s.Invoke(func(d core.DBManager) error { return d.Delete(jd.ID) })
+			writeError(w, statusOfRefusal(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusCreated, res)
+	})
+	mux.HandleFunc("GET /jobs/{job_id}", func(w http.ResponseWriter, r *http.Request) {
+		jobID := r.PathValue("job_id")
+		var j core.Job
+		err := s.Invoke(func(d core.DBManager) (err error) {
+			j, err = d.Get(jobID)
+			return
+		})
+		if err != nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("job(%s) is not found", jobID))
+			return
+		}
+		writeJSON(w, http.StatusOK, oas.ConvertToCloudJob(j.JobData().Clone()))
+	})
+	return mux
+}
+
+// newJob makes the job in ready from the request, with the validation of the job type.
+func newJob(req *JobRequest) (core.Job, error) {
+	if err := req.JobType.Validate(); err != nil {
+		return nil, err
+	}
+	if len(req.JobInfo.Program) == 0 {
+		return nil, fmt.Errorf("no program in job_info")
+	}
+	now := time.Now()
+	def := &api.JobsJobDef{
+		JobID:          api.JobsJobId(uuid.New().String()),
+		DeviceID:       req.DeviceID,
+		Shots:          req.Shots,
+		JobType:        req.JobType,
+		JobInfo:        req.JobInfo,
+		TranspilerInfo: req.TranspilerInfo,
+		MitigationInfo: req.MitigationInfo,
+		Priority:       req.Priority,
+		Owner:          req.Owner,
+		Status:         api.JobsJobStatusReady,
+		SubmittedAt:    api.NewOptNilDateTime(now),
+		ReadyAt:        api.NewOptNilDateTime(now),
+	}
+	jd := oas.ConvertFromCloudJob(def)
+	jd.Result.Message = ""
+	jc, err := core.NewJobContext()
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to create a job context/reason:%s", err))
+		return nil, err
+	}
+	return core.GetJobManager().NewJobFromJobDataWithValidation(jd, jc)
+}
+
+// statusOfRefusal returns the status code of the job refused by the scheduler. The refusals for the state
+// of the engine, e.g. the full queue, are temporary, and the job can be submitted again later.
+func statusOfRefusal(err error) int {
+	switch {
+	case core.ErrorKindOf(err) == core.UserInputError:
+		return http.StatusBadRequest
+	case core.IsReofferedRefusal(err):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, &ErrorResponse{Message: msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		zap.L().Error(fmt.Sprintf("failed to write the response of rest server/reason:%s", err))
+	}
+}
@@ -0,0 +1,144 @@
+//go:build unit
+// +build unit
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+	h := NewHandler(s)
+
+	body := `{"device_id":"test_device","shots":1000,"job_type":"sampling",` +
+		`"job_info":{"program":["OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\n"]},` +
+		`"transpiler_info":{"transpiler_lib":null}}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	created := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.NotEmpty(t, created["job_id"])
+	assert.Equal(t, "ready", created["status"])
+	assert.Equal(t, 1000.0, created["shots"])
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+created["job_id"].(string), nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	got := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, created["job_id"], got["job_id"])
+	assert.Equal(t, "sampling", got["job_type"])
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/unknown_job", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlerInvalidJob(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+	h := NewHandler(s)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "broken json", body: "{"},
+		{name: "unknown job type", body: `{"shots":1000,"job_type":"unknown","job_info":{"program":["OPENQASM 3.0;"]}}`},
+		{name: "no program", body: `{"shots":1000,"job_type":"sampling","job_info":{"program":[]}}`},
+		{name: "0 shots", body: `{"shots":0,"job_type":"sampling","job_info":{"program":["OPENQASM 3.0;"]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(tt.body)))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestNewJobPriorityAndOwner(t *testing.T) {
+	s := core.SCWithDBContainer()
+	defer s.TearDown()
+	_, err := core.NewJobManager(&sampling.SamplingJob{})
+	assert.Nil(t, err)
+
+	req := &JobRequest{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"shots":1000,"job_type":"sampling",`+
+		`"job_info":{"program":["OPENQASM 3.0;"]},"priority":"high","owner":"project_a"}`), req))
+	j, err := newJob(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "high", j.JobData().Priority)
+	assert.Equal(t, "project_a", j.JobData().Owner)
+}
+
+// refusingScheduler refuses every job with err
+type refusingScheduler struct {
+	core.Scheduler
+	err error
+}
+
+func (r *refusingScheduler) Setup(*core.Conf) error   { return nil }
+func (r *refusingScheduler) HandleJob(core.Job) error { return r.err }
+
+func TestHandlerRefusedJob(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			name: "device not hosted",
+			err:  core.NewUserInputError("the device unknown is not hosted", core.ErrorUnknownDevice),
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "queue full",
+			err:  core.NewDeviceUnavailableError("the queue is full", core.ErrorQueueFull),
+			code: http.StatusServiceUnavailable,
+		},
+		{
+			name: "draining",
+			err:  core.NewDeviceUnavailableError("the engine is draining", core.ErrorDraining),
+			code: http.StatusServiceUnavailable,
+		},
+		{
+			name: "unexpected",
+			err:  fmt.Errorf("unexpected error"),
+			code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := core.SCWithSchedulerAndDB(&refusingScheduler{err: tt.err}, &core.MemoryDB{}, &core.Conf{})
+			defer s.TearDown()
+			_, err := core.NewJobManager(&sampling.SamplingJob{})
+			assert.Nil(t, err)
+			h := NewHandler(s)
+
+			body := `{"shots":1000,"job_type":"sampling","job_info":{"program":["OPENQASM 3.0;"]}}`
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body)))
+			assert.Equal(t, tt.code, rec.Code)
+			res := ErrorResponse{}
+			assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tt.err.Error(), res.Message)
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package sampling
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/mitig"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/qpu"
 	"go.uber.org/zap"
 )
 
@@ -25,8 +28,33 @@ func (j *SamplingJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
 	}
 }
 
-func (j *SamplingJob) PreProcess() {
-	if err := j.preProcessImpl(); err != nil {
+func (j *SamplingJob) Validate() error {
+	jd := j.JobData()
+	if err := core.ValidateSplittableShots(jd); err != nil {
+		return err
+	}
+	qubits, err := qpu.CountQubits(jd.QASM)
+	if err != nil {
+		// the program which the parser does not support is left to the transpiler and the QPU
+		zap.L().Debug(fmt.Sprintf("skip counting the qubits of job(%s)/reason:%s", jd.ID, err))
+		return nil
+	}
+	di, err := core.GetSystemComponents().GetDeviceInfoOf(jd.DeviceID)
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("failed to get the device info of %s/jobID:%s/reason:%s", jd.DeviceID, jd.ID, err))
+		return err
+	}
+	maxQubits := di.MaxQubits
+	if qubits > maxQubits {
+		msg := fmt.Sprintf("qubits(%d) is over the limit(%d)", qubits, maxQubits)
+		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", jd.ID))
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
+func (j *SamplingJob) PreProcess(ctx context.Context) {
+	if err := j.preProcessImpl(ctx); err != nil {
 		zap.L().Error(fmt.Sprintf("failed to pre-process a job(%s). Reason:%s",
 			j.JobData().ID, err.Error()))
 		core.SetFailureWithError(j, err)
@@ -36,7 +64,7 @@ func (j *SamplingJob) PreProcess() {
 	return
 }
 
-func (j *SamplingJob) preProcessImpl() (err error) {
+func (j *SamplingJob) preProcessImpl(ctx context.Context) (err error) {
 	err = nil
 	jd := j.JobData()
 	container := core.GetSystemComponents().Container
@@ -44,7 +72,9 @@ func (j *SamplingJob) preProcessImpl() (err error) {
 	if jd.NeedTranspiling() {
 		err = container.Invoke(
 			func(t core.Transpiler) error {
-				return t.Transpile(j)
+				return core.Retry(ctx, j.JobData(), "transpiler", func(ctx context.Context) error {
+					return t.Transpile(ctx, j)
+				})
 			})
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("failed to transpile a job(%s). Reason:%s", jd.ID, err.Error()))
@@ -57,20 +87,85 @@ func (j *SamplingJob) preProcessImpl() (err error) {
 	return
 }
 
-func (j *SamplingJob) Process() {
+func (j *SamplingJob) Process(ctx context.Context) {
 	c := core.GetSystemComponents().Container
 	err := c.Invoke(
 		func(q core.QPUManager) error {
-			return q.Send(j)
+			runs := j.shotsOfRuns()
+			if len(runs) > 1 {
+				return j.processInRuns(ctx, q, runs)
+			}
+			return core.Retry(ctx, j.JobData(), "qpu", func(ctx context.Context) error {
+				return q.Send(ctx, j)
+			})
 		})
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to send a job(%s) to QPU. Reason:%s", j.JobData().ID, err.Error()))
-		j.JobData().Status = core.FAILED
+		core.SetFailureWithError(j, core.WithErrorKind(core.DeviceUnavailableError, err))
 	}
 	zap.L().Debug(fmt.Sprintf("finished to process a job(%s)/status:%s", j.JobData().ID, j.JobData().Status))
 }
 
-func (j *SamplingJob) PostProcess() {
+// shotsOfRuns splits the shots of the job by the max shots of the device.
+func (j *SamplingJob) shotsOfRuns() []int {
+	jd := j.JobData()
+	di, err := core.GetSystemComponents().GetDeviceInfoOf(jd.DeviceID)
+	if err != nil {
+		return []int{jd.Shots}
+	}
+	return core.SplitShots(jd.Shots, di.MaxShots)
+}
+
+// processInRuns sends the job to the QPU once for each run of the shots, and merges the counts,
+// the execution time and the messages of the runs into the job. The job fails when any run fails.
+func (j *SamplingJob) processInRuns(ctx context.Context, q core.QPUManager, runs []int) error {
+	jd := j.JobData()
+	zap.L().Info(fmt.Sprintf("splitting job(%s) of %d shots into %d runs", jd.ID, jd.Shots, len(runs)))
+	counts := make(core.Counts)
+	var executionTime time.Duration
+	var last *core.JobData
+	messages := []string{}
+	for i, shots := range runs {
+		if err := context.Cause(ctx); err != nil {
+			return err
+		}
+		run := j.Clone()
+		run.JobData().Shots = shots
+		err := core.Retry(ctx, run.JobData(), "qpu", func(ctx context.Context) error {
+			return q.Send(ctx, run)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run %d/%d of the shots: %w", i+1, len(runs), err)
+		}
+		last = run.JobData()
+		if last.Result.Message != "" {
+			messages = append(messages, fmt.Sprintf("run %d/%d: %s", i+1, len(runs), last.Result.Message))
+		}
+		if last.Status != core.SUCCEEDED {
+			zap.L().Info(fmt.Sprintf("run %d/%d of job(%s) finished with %s", i+1, len(runs), jd.ID, last.Status))
+			j.finishRuns(last, strings.Join(messages, "; "))
+			return nil
+		}
+		core.MergeCounts(counts, last.Result.Counts)
+		executionTime += last.Result.ExecutionTime
+	}
+	jd.Result.Counts = counts
+	jd.Result.ExecutionTime = executionTime
+	j.finishRuns(last, strings.Join(messages, "; "))
+	return nil
+}
+
+// finishRuns sets the status of the last run to the job.
+func (j *SamplingJob) finishRuns(last *core.JobData, message string) {
+	jd := j.JobData()
+	jd.Result.Message = message
+	jd.Ended = last.Ended
+	if err := jd.SetStatus(last.Status); err != nil {
+		core.SetFailureWithError(j, core.NewInternalError("the job failed unexpectedly", err))
+	}
+}
+
+func (j *SamplingJob) PostProcess(ctx context.Context) {
 	j.mitigationInfo.Mitigated = true
 
 	shouldMitigate := false
@@ -91,7 +186,7 @@ func (j *SamplingJob) PostProcess() {
 
 	if shouldMitigate {
 		zap.L().Debug(fmt.Sprintf("JobID:%s - Starting pseudo inverse mitigation", j.JobData().ID))
-		mitig.PseudoInverseMitigation(j.JobData())
+		mitig.PseudoInverseMitigation(ctx, j.JobData())
 	} else {
 		zap.L().Debug(fmt.Sprintf("JobID:%s - Skipping pseudo inverse mitigation", j.JobData().ID))
 	}
@@ -104,6 +199,10 @@ func (j *SamplingJob) IsFinished() bool {
 		zap.L().Debug(fmt.Sprintf("job(%s) is failed", j.JobData().ID))
 		return true
 	}
+	if j.JobData().Status == core.CANCELLED {
+		zap.L().Debug(fmt.Sprintf("job(%s) is cancelled", j.JobData().ID))
+		return true
+	}
 	if j.mitigationInfo.NeedToBeMitigated {
 		zap.L().Debug(fmt.Sprintf("job(%s) need to be mitigated", j.JobData().ID))
 		return j.mitigationInfo.Mitigated
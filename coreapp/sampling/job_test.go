@@ -0,0 +1,177 @@
+//go:build unit
+// +build unit
+
+package sampling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	jm, err := core.NewJobManager(&SamplingJob{})
+	assert.Nil(t, err)
+
+	qasm := func(qubits int) string {
+		return fmt.Sprintf("OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[%d] q;\nh q[0];\n", qubits)
+	}
+	tests := []struct {
+		name      string
+		qasm      string
+		shots     int
+		wantError string
+	}{
+		{
+			name:  "valid",
+			qasm:  qasm(core.MockMaxQubits),
+			shots: core.MockMaxShots,
+		},
+		{
+			name:      "0 shots",
+			qasm:      qasm(2),
+			shots:     0,
+			wantError: "shots(0) must be greater than 0",
+		},
+		{
+			name:      "over max shots",
+			qasm:      qasm(2),
+			shots:     core.MockMaxShots + 1,
+			wantError: fmt.Sprintf("shots(%d) is over the limit(%d)", core.MockMaxShots+1, core.MockMaxShots),
+		},
+		{
+			name:      "over max qubits",
+			qasm:      qasm(core.MockMaxQubits + 1),
+			shots:     1000,
+			wantError: fmt.Sprintf("qubits(%d) is over the limit(%d)", core.MockMaxQubits+1, core.MockMaxQubits),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := core.NewJobData()
+			jd.ID = "sampling_job"
+			jd.JobType = SAMPLING_JOB
+			jd.QASM = tt.qasm
+			jd.Shots = tt.shots
+			jd.Transpiler = &core.TranspilerConfig{TranspilerLib: nil}
+			jc, err := core.NewJobContext()
+			assert.Nil(t, err)
+			j, err := jm.NewJobFromJobDataWithValidation(jd, jc)
+			if tt.wantError == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, j)
+			} else {
+				assert.EqualError(t, err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateSplittableShots(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(core.SHOTS_SETTING_KEY, core.ShotsSetting{MaxTotalShots: 3 * core.MockMaxShots})
+
+	j := &SamplingJob{jobData: core.NewJobData()}
+	j.jobData.QASM = "OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] q;\nh q[0];\n"
+	j.jobData.Shots = 3 * core.MockMaxShots
+	assert.Nil(t, j.Validate())
+	j.jobData.Shots = 3*core.MockMaxShots + 1
+	assert.EqualError(t, j.Validate(),
+		fmt.Sprintf("shots(%d) is over the limit(%d)", 3*core.MockMaxShots+1, 3*core.MockMaxShots))
+}
+
+type countingQPUForTest struct {
+	core.UnimplementedQPU
+	mu    sync.Mutex
+	shots []int
+	fail  int
+}
+
+func (q *countingQPUForTest) Send(ctx context.Context, j core.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	jd := j.JobData()
+	q.shots = append(q.shots, jd.Shots)
+	if len(q.shots) == q.fail {
+		jd.Result.Message = "failed on the device"
+		jd.SetStatus(core.FAILED)
+		return nil
+	}
+	jd.Result.Counts = core.Counts{"00": uint32(jd.Shots - 1), "11": 1}
+	jd.Result.ExecutionTime = time.Second
+	jd.Result.Message = fmt.Sprintf("%d shots", jd.Shots)
+	jd.SetStatus(core.SUCCEEDED)
+	return nil
+}
+
+func TestProcessInRuns(t *testing.T) {
+	tests := []struct {
+		name        string
+		shots       int
+		fail        int
+		wantShots   []int
+		wantStatus  core.Status
+		wantCounts  core.Counts
+		wantTime    time.Duration
+		wantMessage string
+	}{
+		{
+			name:        "not split",
+			shots:       core.MockMaxShots,
+			wantShots:   []int{core.MockMaxShots},
+			wantStatus:  core.SUCCEEDED,
+			wantCounts:  core.Counts{"00": uint32(core.MockMaxShots - 1), "11": 1},
+			wantTime:    time.Second,
+			wantMessage: fmt.Sprintf("%d shots", core.MockMaxShots),
+		},
+		{
+			name:       "split",
+			shots:      2*core.MockMaxShots + 10,
+			wantShots:  []int{core.MockMaxShots, core.MockMaxShots, 10},
+			wantStatus: core.SUCCEEDED,
+			wantCounts: core.Counts{"00": uint32(2*core.MockMaxShots + 10 - 3), "11": 3},
+			wantTime:   3 * time.Second,
+			wantMessage: fmt.Sprintf("run 1/3: %d shots; run 2/3: %d shots; run 3/3: 10 shots",
+				core.MockMaxShots, core.MockMaxShots),
+		},
+		{
+			name:        "failed run",
+			shots:       3 * core.MockMaxShots,
+			fail:        2,
+			wantShots:   []int{core.MockMaxShots, core.MockMaxShots},
+			wantStatus:  core.FAILED,
+			wantCounts:  core.Counts{},
+			wantMessage: fmt.Sprintf("run 1/3: %d shots; run 2/3: failed on the device", core.MockMaxShots),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &countingQPUForTest{fail: tt.fail}
+			s := core.SCWithQPU(q)
+			defer s.TearDown()
+
+			jd := core.NewJobData()
+			jd.ID = "sampling_job"
+			jd.Shots = tt.shots
+			jd.Status = core.RUNNING
+			j := &SamplingJob{jobData: jd}
+			j.Process(context.Background())
+			assert.Equal(t, tt.wantShots, q.shots)
+			assert.Equal(t, tt.wantStatus, jd.Status)
+			assert.Equal(t, tt.wantCounts, jd.Result.Counts)
+			assert.Equal(t, tt.wantTime, jd.Result.ExecutionTime)
+			assert.Equal(t, tt.shots, jd.Shots)
+			assert.Equal(t, tt.wantMessage, jd.Result.Message)
+		})
+	}
+}
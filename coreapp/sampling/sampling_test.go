@@ -4,6 +4,7 @@
 package sampling
 
 import (
+	"context"
 	"testing"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
@@ -36,7 +37,7 @@ func TestPostProcessMitigation(t *testing.T) {
 	assert.Nil(t, err)
 
 	sj := job.(*SamplingJob)
-	sj.PostProcess()
+	sj.PostProcess(context.Background())
 	actual := sj.JobData().Result.Counts
 	expect := core.Counts{"00": 191, "01": 268, "10": 225, "11": 315}
 
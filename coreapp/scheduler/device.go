@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+// DeviceScheduler has a scheduler for each device set in [com.devices], so that the jobs of a device
+// never wait for the jobs of another device. The jobs are routed by their device, and the jobs without
+// a device are routed to the default device.
+type DeviceScheduler struct {
+	newScheduler  func() core.Scheduler
+	schedulers    map[string]core.Scheduler
+	deviceIDs     []string
+	defaultDevice string
+}
+
+// deviceBound is implemented by the scheduler which keeps the jobs in the queue during the reservations
+// of its device.
+type deviceBound interface {
+	bindDevice(deviceID string)
+}
+
+// NewDeviceScheduler returns a DeviceScheduler which makes the scheduler of each device by newScheduler.
+func NewDeviceScheduler(newScheduler func() core.Scheduler) *DeviceScheduler {
+	return &DeviceScheduler{newScheduler: newScheduler}
+}
+
+func (d *DeviceScheduler) Setup(conf *core.Conf) error {
+	s := core.GetDevicesSetting()
+	if err := s.Validate(); err != nil {
+		zap.L().Error(fmt.Sprintf("invalid devices setting/reason:%s", err))
+		return err
+	}
+	d.schedulers = make(map[string]core.Scheduler)
+	d.deviceIDs = s.DeviceIDs()
+	d.defaultDevice = s.Default
+	for _, deviceID := range d.deviceIDs {
+		sc := d.newScheduler()
+		if err := sc.Setup(conf); err != nil {
+			zap.L().Error(fmt.Sprintf("failed to setup the scheduler of %s/reason:%s", deviceID, err))
+			return err
+		}
+		if b, ok := sc.(deviceBound); ok {
+			b.bindDevice(deviceID)
+		}
+		d.schedulers[deviceID] = sc
+	}
+	return nil
+}
+
+func (d *DeviceScheduler) Start() error {
+	for _, deviceID := range d.deviceIDs {
+		if err := d.schedulers[deviceID].Start(); err != nil {
+			zap.L().Error(fmt.Sprintf("failed to start the scheduler of %s/reason:%s", deviceID, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the schedulers of the devices.
+func (d *DeviceScheduler) Stop() {
+	for _, deviceID := range d.deviceIDs {
+		if st, ok := d.schedulers[deviceID].(core.Stopper); ok {
+			st.Stop()
+		}
+	}
+}
+
+func (d *DeviceScheduler) DeviceQueue(deviceID string) (core.Scheduler, error) {
+	if deviceID == "" {
+		deviceID = d.defaultDevice
+	}
+	sc, ok := d.schedulers[deviceID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", core.ErrorUnknownDevice, deviceID)
+	}
+	return sc, nil
+}
+
+func (d *DeviceScheduler) HandleJob(j core.Job) error {
+	sc, err := d.DeviceQueue(j.JobData().DeviceID)
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("refused job(%s)/reason:%s", j.JobData().ID, err))
+		return core.NewUserInputError(fmt.Sprintf("the device %s is not hosted", j.JobData().DeviceID), err)
+	}
+	return sc.HandleJob(j)
+}
+
+func (d *DeviceScheduler) CancelJob(jobID string) error {
+	for _, deviceID := range d.deviceIDs {
+		if err := d.schedulers[deviceID].CancelJob(jobID); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("job(%s) is not handled in the scheduler", jobID)
+}
+
+func (d *DeviceScheduler) GetCurrentQueueSize() int {
+	size := 0
+	for _, sc := range d.schedulers {
+		size += sc.GetCurrentQueueSize()
+	}
+	return size
+}
+
+func (d *DeviceScheduler) GetQueueFreeSlots() int {
+	slots := 0
+	for _, sc := range d.schedulers {
+		slots += sc.GetQueueFreeSlots()
+	}
+	return slots
+}
+
+// IsOverRefillThreshold is true when the queues of all the devices are over the threshold.
+func (d *DeviceScheduler) IsOverRefillThreshold() bool {
+	for _, sc := range d.schedulers {
+		if !sc.IsOverRefillThreshold() {
+			return false
+		}
+	}
+	return true
+}
+
+// Drain drains the schedulers of all the devices at the same time.
+func (d *DeviceScheduler) Drain(ctx context.Context) error {
+	errs := make([]error, len(d.deviceIDs))
+	var wg sync.WaitGroup
+	for i, deviceID := range d.deviceIDs {
+		wg.Add(1)
+		go func(i int, deviceID string) {
+			defer wg.Done()
+			if err := d.schedulers[deviceID].Drain(ctx); err != nil {
+				errs[i] = fmt.Errorf("failed to drain the scheduler of %s: %w", deviceID, err)
+			}
+		}(i, deviceID)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *DeviceScheduler) ExpireJobs(now time.Time) []string {
+	expired := []string{}
+	for _, deviceID := range d.deviceIDs {
+		expired = append(expired, d.schedulers[deviceID].ExpireJobs(now)...)
+	}
+	return expired
+}
+
+func (d *DeviceScheduler) ListJobs() []core.ScheduledJob {
+	jobs := []core.ScheduledJob{}
+	for _, deviceID := range d.deviceIDs {
+		jobs = append(jobs, d.schedulers[deviceID].ListJobs()...)
+	}
+	return jobs
+}
@@ -0,0 +1,44 @@
+//go:build unit
+// +build unit
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceScheduler(t *testing.T) {
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(core.DEVICES_SETTING_KEY, map[string]interface{}{
+		"default": "real",
+		"backends": map[string]interface{}{
+			"real":      map[string]interface{}{"qpu": "dummy"},
+			"simulator": map[string]interface{}{"qpu": "dummy"},
+		},
+	})
+	d := NewDeviceScheduler(func() core.Scheduler { return &NormalScheduler{} })
+	assert.Nil(t, d.Setup(&core.Conf{QueueMaxSize: 10}))
+
+	def, err := d.DeviceQueue("")
+	assert.Nil(t, err)
+	realQueue, err := d.DeviceQueue("real")
+	assert.Nil(t, err)
+	sim, err := d.DeviceQueue("simulator")
+	assert.Nil(t, err)
+	assert.Same(t, realQueue, def)
+	assert.NotSame(t, realQueue, sim)
+	assert.Equal(t, 20, d.GetQueueFreeSlots())
+	// the schedulers keep the jobs during the reservations of their devices
+	assert.Equal(t, "real", realQueue.(*NormalScheduler).deviceID)
+	assert.Equal(t, "simulator", sim.(*NormalScheduler).deviceID)
+
+	j := testJob(t, core.NORMAL_JOB, core.READY)
+	j.JobData().DeviceID = "unknown"
+	err = d.HandleJob(j)
+	assert.ErrorIs(t, err, core.ErrorUnknownDevice)
+	assert.Equal(t, core.UserInputError, core.ErrorKindOf(err))
+}
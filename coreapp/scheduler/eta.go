@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+)
+
+// DEFAULT_EXECUTION_TIME is the estimated processing time of a job type which has never been processed.
+const DEFAULT_EXECUTION_TIME = 10 * time.Second
+
+type executionKey struct {
+	jobType string
+	// the order of magnitude of the shots
+	shotsScale int
+}
+
+// executionHistory estimates the processing time of a job from the recent processing times of
+// the jobs of the same job type and the same order of magnitude of the shots.
+// A job of the scale never processed is estimated from the time per shot of the job type.
+type executionHistory struct {
+	mu      sync.Mutex
+	times   map[executionKey]time.Duration
+	perShot map[string]time.Duration
+}
+
+func newExecutionHistory() *executionHistory {
+	return &executionHistory{
+		times:   map[executionKey]time.Duration{},
+		perShot: map[string]time.Duration{},
+	}
+}
+
+func shotsScale(shots int) int {
+	s := 0
+	for shots >= 10 {
+		shots /= 10
+		s++
+	}
+	return s
+}
+
+// exponential moving average
+func average(old time.Duration, ok bool, d time.Duration) time.Duration {
+	if !ok {
+		return d
+	}
+	return (old*7 + d*3) / 10
+}
+
+func (h *executionHistory) record(jobType string, shots int, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	k := executionKey{jobType: jobType, shotsScale: shotsScale(shots)}
+	old, ok := h.times[k]
+	h.times[k] = average(old, ok, d)
+	if shots > 0 {
+		old, ok := h.perShot[jobType]
+		h.perShot[jobType] = average(old, ok, d/time.Duration(shots))
+	}
+}
+
+func (h *executionHistory) estimate(jobType string, shots int) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if d, ok := h.times[executionKey{jobType: jobType, shotsScale: shotsScale(shots)}]; ok {
+		return d
+	}
+	if d, ok := h.perShot[jobType]; ok && shots > 0 {
+		return d * time.Duration(shots)
+	}
+	return DEFAULT_EXECUTION_TIME
+}
+
+// jobProgress is the stage of a job handled in the scheduler.
+type jobProgress struct {
+	stage    string
+	handled  time.Time
+	enqueued time.Time
+	started  time.Time
+}
+
+func (n *NormalScheduler) setStage(jid string, stage string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := time.Now()
+	p, ok := n.progress[jid]
+	if !ok {
+		p = &jobProgress{handled: now}
+		n.progress[jid] = p
+	}
+	p.stage = stage
+	switch stage {
+	case core.StageQueued:
+		p.enqueued = now
+	case core.StageProcessing:
+		p.started = now
+	}
+}
+
+// ListJobs returns the jobs handled in the scheduler. The jobs are processed one by one, so the job
+// in the queue is estimated to start when the jobs before it have been processed.
+// The jobs in pre-processing are estimated to be queued after the jobs in the queue.
+// The position in the queue is in the order the fifo would dequeue the jobs now, so that the
+// schedulers reordering the jobs are estimated in their own order.
+func (n *NormalScheduler) ListJobs() []core.ScheduledJob {
+	now := time.Now()
+	// the queued jobs in the order they will be processed
+	queued := []core.Job{}
+	for _, jis := range n.queue.fifo.Snapshot() {
+		queued = append(queued, jis.job)
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	parents := []core.ScheduledJob{}
+	post := []core.ScheduledJob{}
+	processing := []core.ScheduledJob{}
+	pre := []core.ScheduledJob{}
+	// the end of the processing of the jobs listed so far
+	cursor := now
+	for jid, j := range n.handlingJobs {
+		p, ok := n.progress[jid]
+		if !ok {
+			continue
+		}
+		sj := n.newScheduledJob(j, p)
+		switch p.stage {
+		case core.StagePostProcessing:
+			post = append(post, sj)
+		case core.StageProcessing:
+			end := p.started.Add(n.history.estimate(j.JobData().JobType, j.JobData().Shots))
+			sj.EstimatedStartAt = timePtr(p.started)
+			sj.EstimatedEndAt = timePtr(end)
+			if end.After(cursor) {
+				cursor = end
+			}
+			processing = append(processing, sj)
+		case core.StagePreProcessing:
+			pre = append(pre, sj)
+		case core.StageRunningChildren:
+			// the children are listed as the jobs of their own
+			parents = append(parents, sj)
+		}
+	}
+
+	sort.Slice(post, func(a, b int) bool {
+		return n.progress[post[a].JobID].started.Before(n.progress[post[b].JobID].started)
+	})
+	jobs := append(parents, post...)
+	jobs = append(jobs, processing...)
+	for i, j := range queued {
+		p, ok := n.progress[j.JobData().ID]
+		if !ok {
+			continue
+		}
+		sj := n.newScheduledJob(j, p)
+		sj.Stage = core.StageQueued
+		sj.Position = i + 1
+		cursor = n.scheduleAt(&sj, cursor)
+		jobs = append(jobs, sj)
+	}
+	sort.Slice(pre, func(a, b int) bool {
+		return n.progress[pre[a].JobID].handled.Before(n.progress[pre[b].JobID].handled)
+	})
+	for _, sj := range pre {
+		cursor = n.scheduleAt(&sj, cursor)
+		jobs = append(jobs, sj)
+	}
+	return jobs
+}
+
+func (n *NormalScheduler) newScheduledJob(j core.Job, p *jobProgress) core.ScheduledJob {
+	jd := j.JobData()
+	sj := core.ScheduledJob{
+		JobID:    jd.ID,
+		JobType:  jd.JobType,
+		DeviceID: jd.DeviceID,
+		Status:   jd.Status.String(),
+		Stage:    p.stage,
+		Shots:    jd.Shots,
+	}
+	if created := time.Time(jd.Created); !created.IsZero() {
+		sj.SubmittedAt = timePtr(created)
+	}
+	if !p.enqueued.IsZero() {
+		sj.EnqueuedAt = timePtr(p.enqueued)
+	}
+	if !p.started.IsZero() {
+		sj.StartedAt = timePtr(p.started)
+	}
+	return sj
+}
+
+// scheduleAt estimates the job to start at the cursor, or after the reservations of the device
+// not allowing the job, and returns the estimated end.
+func (n *NormalScheduler) scheduleAt(sj *core.ScheduledJob, cursor time.Time) time.Time {
+	if j, ok := n.handlingJobs[sj.JobID]; ok {
+		cursor = core.GetReservations().AvailableAt(cursor, n.deviceID, j.JobData().Owner)
+	}
+	end := cursor.Add(n.history.estimate(sj.JobType, sj.Shots))
+	sj.EstimatedStartAt = timePtr(cursor)
+	sj.EstimatedEndAt = timePtr(end)
+	return end
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionHistory(t *testing.T) {
+	h := newExecutionHistory()
+	assert.Equal(t, DEFAULT_EXECUTION_TIME, h.estimate(core.NORMAL_JOB, 1000))
+
+	h.record(core.NORMAL_JOB, 1000, 2*time.Second)
+	assert.Equal(t, 2*time.Second, h.estimate(core.NORMAL_JOB, 1000))
+	// the same order of magnitude
+	assert.Equal(t, 2*time.Second, h.estimate(core.NORMAL_JOB, 5000))
+	// estimated from the time per shot
+	assert.Equal(t, 200*time.Second, h.estimate(core.NORMAL_JOB, 100000))
+	// another job type
+	assert.Equal(t, DEFAULT_EXECUTION_TIME, h.estimate("sse", 1000))
+
+	h.record(core.NORMAL_JOB, 1000, 4*time.Second)
+	assert.Equal(t, 2600*time.Millisecond, h.estimate(core.NORMAL_JOB, 1000))
+}
+
+func TestListJobs(t *testing.T) {
+	n := &NormalScheduler{}
+	n.Setup(&core.Conf{QueueMaxSize: 10})
+	defer n.queue.TearDown()
+	n.history.record(core.NORMAL_JOB, 1000, time.Second)
+
+	handle := func(stage string) core.Job {
+		j := testJob(t, core.NORMAL_JOB, core.READY)
+		n.handlingJobs[j.JobData().ID] = j
+		n.setStage(j.JobData().ID, stage)
+		if stage == core.StageQueued {
+			assert.Nil(t, n.queue.Put(&jobInScheduler{job: j}))
+		}
+		return j
+	}
+	pre := handle(core.StagePreProcessing)
+	processing := handle(core.StageProcessing)
+	queued1 := handle(core.StageQueued)
+	queued2 := handle(core.StageQueued)
+
+	jobs := n.ListJobs()
+	assert.Len(t, jobs, 4)
+	ids := []string{}
+	for _, j := range jobs {
+		ids = append(ids, j.JobID)
+	}
+	assert.Equal(t, []string{
+		processing.JobData().ID,
+		queued1.JobData().ID,
+		queued2.JobData().ID,
+		pre.JobData().ID,
+	}, ids)
+	assert.Equal(t, []int{0, 1, 2, 0}, []int{jobs[0].Position, jobs[1].Position, jobs[2].Position, jobs[3].Position})
+	assert.NotNil(t, jobs[0].StartedAt)
+	assert.NotNil(t, jobs[1].EnqueuedAt)
+	// the jobs are processed one by one
+	for i := 1; i < len(jobs); i++ {
+		assert.Equal(t, *jobs[i-1].EstimatedEndAt, *jobs[i].EstimatedStartAt)
+		assert.Equal(t, time.Second, jobs[i].EstimatedEndAt.Sub(*jobs[i].EstimatedStartAt))
+	}
+}
+
+func TestListJobsInDequeueOrder(t *testing.T) {
+	n := &NormalScheduler{}
+	n.setupWithFIFO(&core.Conf{QueueMaxSize: 10}, newPriorityFIFO(map[string]string{}, time.Minute))
+	defer n.queue.TearDown()
+
+	queue := func(priority string) core.Job {
+		j := testJob(t, core.NORMAL_JOB, core.READY)
+		j.JobData().Priority = priority
+		n.handlingJobs[j.JobData().ID] = j
+		n.setStage(j.JobData().ID, core.StageQueued)
+		assert.Nil(t, n.queue.Put(&jobInScheduler{job: j}))
+		return j
+	}
+	low := queue(LowPriority)
+	normal := queue(NormalPriority)
+	high := queue(HighPriority)
+
+	jobs := n.ListJobs()
+	assert.Len(t, jobs, 3)
+	// the positions and the estimates follow the priorities, not the arrival
+	assert.Equal(t, []string{high.JobData().ID, normal.JobData().ID, low.JobData().ID},
+		[]string{jobs[0].JobID, jobs[1].JobID, jobs[2].JobID})
+	assert.Equal(t, []int{1, 2, 3}, []int{jobs[0].Position, jobs[1].Position, jobs[2].Position})
+	assert.True(t, jobs[0].EstimatedStartAt.Before(*jobs[2].EstimatedStartAt))
+}
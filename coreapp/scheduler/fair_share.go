@@ -0,0 +1,307 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const FAIR_SHARE_SETTING_KEY = "fair_share"
+
+// FairShareSetting is the setting of FairShareScheduler in [com.fair_share].
+// Each owner is given quantum x weight of the QPU time in a round of the deficit round robin.
+// The weight of an owner is set in [com.fair_share.weights], e.g. project_a = 2,
+// and default_weight is used for the owners not listed.
+type FairShareSetting struct {
+	Quantum       string             `toml:"quantum"`
+	DefaultWeight float64            `toml:"default_weight"`
+	Weights       map[string]float64 `toml:"weights"`
+}
+
+func NewFairShareSetting() FairShareSetting {
+	return FairShareSetting{
+		Quantum:       "10s",
+		DefaultWeight: 1,
+		Weights:       map[string]float64{},
+	}
+}
+
+func getFairShareSetting() FairShareSetting {
+	return core.GetSetting(FAIR_SHARE_SETTING_KEY, NewFairShareSetting())
+}
+
+// FairShareScheduler is a NormalScheduler which shares the QPU time among the owners of the jobs
+// by the weighted deficit round robin, so that a heavy owner cannot occupy the QPU.
+type FairShareScheduler struct {
+	NormalScheduler
+}
+
+func (f *FairShareScheduler) Setup(conf *core.Conf) error {
+	s := getFairShareSetting()
+	quantum, err := time.ParseDuration(s.Quantum)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to parse quantum:%s/reason:%s", s.Quantum, err))
+		return err
+	}
+	if quantum <= 0 {
+		return fmt.Errorf("quantum must be positive")
+	}
+	if s.DefaultWeight <= 0 {
+		return fmt.Errorf("default weight must be positive")
+	}
+	for owner, w := range s.Weights {
+		if w <= 0 {
+			return fmt.Errorf("weight of %s must be positive", owner)
+		}
+	}
+	return f.setupWithFIFO(conf, newFairShareFIFO(quantum, s.DefaultWeight, s.Weights))
+}
+
+type fairShareEntry struct {
+	jis   *jobInScheduler
+	owner string
+}
+
+type ownerState struct {
+	weight  float64
+	deficit time.Duration
+	// the estimated QPU time of a job, which is the moving average of the recent jobs of the owner
+	cost time.Duration
+	// the estimated costs of the jobs being processed, to be corrected by the actual QPU time
+	charged map[string]time.Duration
+}
+
+// fairShareFIFO is a fifo which dequeues the jobs by the weighted deficit round robin over the owners.
+// The cost of a job is estimated from the recent QPU time of the owner, and the deficit of the owner
+// is corrected with the actual QPU time when the job has been processed.
+// The indices of Get and Remove are in the order of arrival.
+type fairShareFIFO struct {
+	mu            sync.Mutex
+	cond          *sync.Cond
+	entries       []*fairShareEntry
+	owners        map[string]*ownerState
+	ring          []string // the owners with queued jobs in the round robin order
+	next          int      // the owner in its turn
+	quantum       time.Duration
+	defaultWeight float64
+	weights       map[string]float64
+	// warnedNoOwner is set when the warning of the jobs without their owners has been logged
+	warnedNoOwner bool
+}
+
+func newFairShareFIFO(quantum time.Duration, defaultWeight float64, weights map[string]float64) *fairShareFIFO {
+	f := &fairShareFIFO{
+		owners:        map[string]*ownerState{},
+		quantum:       quantum,
+		defaultWeight: defaultWeight,
+		weights:       weights,
+	}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *fairShareFIFO) owner(name string) *ownerState {
+	o, ok := f.owners[name]
+	if !ok {
+		w, ok := f.weights[name]
+		if !ok {
+			w = f.defaultWeight
+		}
+		o = &ownerState{
+			weight:  w,
+			cost:    f.quantum,
+			charged: map[string]time.Duration{},
+		}
+		f.owners[name] = o
+	}
+	return o
+}
+
+func (f *fairShareFIFO) Enqueue(jis *jobInScheduler) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := jis.job.JobData().Owner
+	f.owner(name)
+	if name == "" && len(f.owners) == 1 && !f.warnedNoOwner {
+		// e.g. the cloud which does not send the owners of the jobs
+		zap.L().Warn("every job has the empty owner, so that the fair-share scheduling works as a FIFO. " +
+			"the cloud has to send the owners of the jobs")
+		f.warnedNoOwner = true
+	}
+	if !f.inRing(name) {
+		f.ring = append(f.ring, name)
+		if len(f.ring) == 1 {
+			f.next = 0
+			f.startTurn(name)
+		}
+	}
+	f.entries = append(f.entries, &fairShareEntry{jis: jis, owner: name})
+	f.cond.Signal()
+	return nil
+}
+
+func (f *fairShareFIFO) inRing(name string) bool {
+	for _, n := range f.ring {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fairShareFIFO) startTurn(name string) {
+	o := f.owners[name]
+	o.deficit += time.Duration(float64(f.quantum) * o.weight)
+}
+
+func (f *fairShareFIFO) Dequeue() (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.entries) == 0 {
+		return nil, fmt.Errorf("empty queue")
+	}
+	return f.dequeueLocked(), nil
+}
+
+func (f *fairShareFIFO) DequeueOrWaitForNextElement() (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.entries) == 0 {
+		f.cond.Wait()
+	}
+	return f.dequeueLocked(), nil
+}
+
+func (f *fairShareFIFO) dequeueLocked() *jobInScheduler {
+	e := f.nextLocked()
+	zap.L().Debug(fmt.Sprintf("dequeue job(%s) of owner %q/deficit:%s", e.jis.job.JobData().ID, e.owner, f.owners[e.owner].deficit))
+	return e.jis
+}
+
+// nextLocked takes the entry in the turn of the round robin out of the entries.
+func (f *fairShareFIFO) nextLocked() *fairShareEntry {
+	for {
+		name := f.ring[f.next]
+		o := f.owners[name]
+		idx := f.firstOf(name)
+		if idx < 0 {
+			// the jobs of the owner have been removed
+			o.resetDeficit()
+			f.leaveRing(f.next)
+			continue
+		}
+		if o.deficit >= o.cost {
+			e := f.entries[idx]
+			f.entries = append(f.entries[:idx], f.entries[idx+1:]...)
+			o.deficit -= o.cost
+			o.charged[e.jis.job.JobData().ID] = o.cost
+			if f.firstOf(name) < 0 {
+				o.resetDeficit()
+				f.leaveRing(f.next)
+			}
+			return e
+		}
+		f.next = (f.next + 1) % len(f.ring)
+		f.startTurn(f.ring[f.next])
+	}
+}
+
+// resetDeficit drops the unused deficit of the owner leaving the round robin.
+// The overuse is kept, so that the owner cannot escape it by leaving.
+func (o *ownerState) resetDeficit() {
+	if o.deficit > 0 {
+		o.deficit = 0
+	}
+}
+
+// leaveRing removes the owner at i from the ring. The next owner takes the turn.
+func (f *fairShareFIFO) leaveRing(i int) {
+	f.ring = append(f.ring[:i], f.ring[i+1:]...)
+	if len(f.ring) == 0 {
+		f.next = 0
+		return
+	}
+	f.next = i % len(f.ring)
+	f.startTurn(f.ring[f.next])
+}
+
+func (f *fairShareFIFO) firstOf(name string) int {
+	for i, e := range f.entries {
+		if e.owner == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// RecordUsage corrects the deficit of the owner with the actual QPU time of the job,
+// and updates the estimated cost of the jobs of the owner.
+func (f *fairShareFIFO) RecordUsage(j core.Job, used time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jd := j.JobData()
+	o := f.owner(jd.Owner)
+	if charged, ok := o.charged[jd.ID]; ok {
+		o.deficit -= used - charged
+		delete(o.charged, jd.ID)
+	}
+	// exponential moving average
+	o.cost = (o.cost*7 + used*3) / 10
+	if o.cost <= 0 {
+		o.cost = time.Millisecond
+	}
+}
+
+func (f *fairShareFIFO) Get(index int) (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if index < 0 || index >= len(f.entries) {
+		return nil, fmt.Errorf("index out of bounds")
+	}
+	return f.entries[index].jis, nil
+}
+
+func (f *fairShareFIFO) GetLen() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.entries)
+}
+
+func (f *fairShareFIFO) Remove(index int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if index < 0 || index >= len(f.entries) {
+		return fmt.Errorf("index out of bounds")
+	}
+	f.entries = append(f.entries[:index], f.entries[index+1:]...)
+	return nil
+}
+
+// Snapshot returns the queued jobs in the order of the round robin, which is run on a copy of
+// the deficits. The actual QPU time of the jobs being processed is not known yet.
+func (f *fairShareFIFO) Snapshot() []*jobInScheduler {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := &fairShareFIFO{
+		entries:       append([]*fairShareEntry{}, f.entries...),
+		owners:        map[string]*ownerState{},
+		ring:          append([]string{}, f.ring...),
+		next:          f.next,
+		quantum:       f.quantum,
+		defaultWeight: f.defaultWeight,
+		weights:       f.weights,
+	}
+	for name, o := range f.owners {
+		copied := *o
+		copied.charged = map[string]time.Duration{}
+		c.owners[name] = &copied
+	}
+	jobs := make([]*jobInScheduler, 0, len(c.entries))
+	for len(c.entries) > 0 {
+		jobs = append(jobs, c.nextLocked().jis)
+	}
+	return jobs
+}
@@ -0,0 +1,109 @@
+//go:build unit
+// +build unit
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/oas"
+	api "github.com/oqtopus-team/oqtopus-engine/coreapp/oas/gen/providerapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOwnedJobInScheduler converts the job definition from the cloud, so that the owner is
+// the one the fair-share scheduling sees in the running engine
+func newOwnedJobInScheduler(id string, owner string) *jobInScheduler {
+	jd := oas.ConvertFromCloudJob(&api.JobsJobDef{
+		JobID:   api.JobsJobId(id),
+		JobType: api.JobsJobTypeSampling,
+		JobInfo: api.JobsJobInfo{Program: []string{"OPENQASM 3;"}},
+		Status:  api.JobsJobStatusReady,
+		Owner:   api.NewOptString(owner),
+	})
+	return &jobInScheduler{
+		job: (&core.NormalJob{}).New(jd, nil),
+	}
+}
+
+func TestFairShareFIFO(t *testing.T) {
+	f := newFairShareFIFO(time.Second, 1, map[string]float64{"light": 2})
+	// a heavy owner has filled the queue before the others
+	for _, id := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
+		f.Enqueue(newOwnedJobInScheduler(id, "heavy"))
+	}
+	for _, id := range []string{"l1", "l2", "l3", "l4"} {
+		f.Enqueue(newOwnedJobInScheduler(id, "light"))
+	}
+	f.Enqueue(newOwnedJobInScheduler("o1", "other"))
+
+	// Snapshot runs the round robin without changing the deficits
+	expected := []string{"h1", "l1", "l2", "o1", "h2", "l3", "l4", "h3", "h4", "h5", "h6"}
+	assert.Equal(t, expected, jobIDsOf(f.Snapshot()))
+	assert.Equal(t, expected, jobIDsOf(f.Snapshot()))
+
+	ids := []string{}
+	for f.GetLen() > 0 {
+		jis, err := f.Dequeue()
+		assert.Nil(t, err)
+		ids = append(ids, jis.job.JobData().ID)
+	}
+	// the light owner has twice the weight of the others
+	assert.Equal(t, expected, ids)
+}
+
+func TestFairShareFIFONoOwner(t *testing.T) {
+	f := newFairShareFIFO(time.Second, 1, map[string]float64{})
+	f.Enqueue(newOwnedJobInScheduler("o1", "owner"))
+	f.Enqueue(newOwnedJobInScheduler("n1", ""))
+	assert.False(t, f.warnedNoOwner)
+
+	f = newFairShareFIFO(time.Second, 1, map[string]float64{})
+	f.Enqueue(newOwnedJobInScheduler("n1", ""))
+	f.Enqueue(newOwnedJobInScheduler("n2", ""))
+	// the jobs without their owners are dequeued in the order of arrival
+	assert.True(t, f.warnedNoOwner)
+	for _, id := range []string{"n1", "n2"} {
+		jis, err := f.Dequeue()
+		assert.Nil(t, err)
+		assert.Equal(t, id, jis.job.JobData().ID)
+	}
+}
+
+func TestFairShareFIFOUsage(t *testing.T) {
+	f := newFairShareFIFO(time.Second, 1, map[string]float64{})
+	f.Enqueue(newOwnedJobInScheduler("a1", "a"))
+	jis, err := f.Dequeue()
+	assert.Nil(t, err)
+	// the job of a has used the QPU for 3 quanta
+	f.RecordUsage(jis.job, 3*time.Second)
+	assert.Equal(t, -2*time.Second, f.owners["a"].deficit)
+	// the estimated cost follows the recent QPU time of a
+	assert.Equal(t, 1600*time.Millisecond, f.owners["a"].cost)
+
+	f.Enqueue(newOwnedJobInScheduler("a2", "a"))
+	f.Enqueue(newOwnedJobInScheduler("b1", "b"))
+	f.Enqueue(newOwnedJobInScheduler("b2", "b"))
+	ids := []string{}
+	for f.GetLen() > 0 {
+		jis, err := f.Dequeue()
+		assert.Nil(t, err)
+		ids = append(ids, jis.job.JobData().ID)
+	}
+	// a has to wait for b to use the QPU
+	assert.Equal(t, []string{"b1", "b2", "a2"}, ids)
+}
+
+func TestFairShareFIFORemove(t *testing.T) {
+	f := newFairShareFIFO(time.Second, 1, map[string]float64{})
+	f.Enqueue(newOwnedJobInScheduler("a1", "a"))
+	f.Enqueue(newOwnedJobInScheduler("b1", "b"))
+	assert.Nil(t, f.Remove(0))
+	jis, err := f.Dequeue()
+	assert.Nil(t, err)
+	assert.Equal(t, "b1", jis.job.JobData().ID)
+	_, err = f.Dequeue()
+	assert.EqualError(t, err, "empty queue")
+}
@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const PRIORITY_SETTING_KEY = "priority"
+
+// the priority classes from the highest
+const (
+	HighPriority   = "high"
+	NormalPriority = "normal"
+	LowPriority    = "low"
+)
+
+var priorityClasses = []string{HighPriority, NormalPriority, LowPriority}
+
+// PrioritySetting is the setting of PriorityScheduler in [com.priority].
+// A job waiting in the queue is promoted by one class every aging interval,
+// so that the low priority jobs run eventually.
+// The priority of a job type is set in [com.priority.job_types], e.g. sse = "low".
+type PrioritySetting struct {
+	AgingInterval string            `toml:"aging_interval"`
+	JobTypes      map[string]string `toml:"job_types"`
+}
+
+func NewPrioritySetting() PrioritySetting {
+	return PrioritySetting{
+		AgingInterval: "5m",
+		JobTypes:      map[string]string{},
+	}
+}
+
+func getPrioritySetting() PrioritySetting {
+	return core.GetSetting(PRIORITY_SETTING_KEY, NewPrioritySetting())
+}
+
+// PriorityScheduler is a NormalScheduler which processes the queued jobs in the order of
+// their priority classes instead of the order of arrival.
+type PriorityScheduler struct {
+	NormalScheduler
+}
+
+func (p *PriorityScheduler) Setup(conf *core.Conf) error {
+	s := getPrioritySetting()
+	agingInterval, err := time.ParseDuration(s.AgingInterval)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to parse aging interval:%s/reason:%s", s.AgingInterval, err))
+		return err
+	}
+	for jobType, class := range s.JobTypes {
+		if classIndex(class) < 0 {
+			return fmt.Errorf("unknown priority class %s of %s job", class, jobType)
+		}
+	}
+	return p.setupWithFIFO(conf, newPriorityFIFO(s.JobTypes, agingInterval))
+}
+
+type priorityEntry struct {
+	jis      *jobInScheduler
+	class    int
+	enqueued time.Time
+}
+
+// priorityFIFO is a fifo which dequeues the job of the highest priority class.
+// The jobs in the same class are dequeued in the order of arrival.
+// The indices of Get and Remove are in the order of arrival.
+type priorityFIFO struct {
+	mu            sync.Mutex
+	cond          *sync.Cond
+	entries       []*priorityEntry
+	jobTypes      map[string]string
+	agingInterval time.Duration
+	now           func() time.Time
+}
+
+func newPriorityFIFO(jobTypes map[string]string, agingInterval time.Duration) *priorityFIFO {
+	f := &priorityFIFO{
+		jobTypes:      jobTypes,
+		agingInterval: agingInterval,
+		now:           time.Now,
+	}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func classIndex(class string) int {
+	for i, c := range priorityClasses {
+		if c == class {
+			return i
+		}
+	}
+	return -1
+}
+
+// classOf returns the priority class of the job. The class given to the job has precedence over
+// the class of the job type. The cloud does not send the priority of a job yet, so that the jobs
+// from the cloud get the class of their job type until it does.
+func (f *priorityFIFO) classOf(jd *core.JobData) int {
+	if i := classIndex(jd.Priority); i >= 0 {
+		return i
+	}
+	if jd.Priority != "" {
+		zap.L().Info(fmt.Sprintf("unknown priority %s of job(%s). use the priority of the job type", jd.Priority, jd.ID))
+	}
+	if i := classIndex(f.jobTypes[jd.JobType]); i >= 0 {
+		return i
+	}
+	return classIndex(NormalPriority)
+}
+
+// effectiveClass is the class promoted by the waiting time.
+func (f *priorityFIFO) effectiveClass(e *priorityEntry, now time.Time) int {
+	if f.agingInterval <= 0 {
+		return e.class
+	}
+	c := e.class - int(now.Sub(e.enqueued)/f.agingInterval)
+	if c < 0 {
+		return 0
+	}
+	return c
+}
+
+func (f *priorityFIFO) Enqueue(jis *jobInScheduler) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e := &priorityEntry{
+		jis:      jis,
+		class:    f.classOf(jis.job.JobData()),
+		enqueued: f.now(),
+	}
+	zap.L().Debug(fmt.Sprintf("enqueue job(%s) in %s priority", jis.job.JobData().ID, priorityClasses[e.class]))
+	f.entries = append(f.entries, e)
+	f.cond.Signal()
+	return nil
+}
+
+func (f *priorityFIFO) Dequeue() (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.entries) == 0 {
+		return nil, fmt.Errorf("empty queue")
+	}
+	return f.dequeueLocked(), nil
+}
+
+func (f *priorityFIFO) DequeueOrWaitForNextElement() (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.entries) == 0 {
+		f.cond.Wait()
+	}
+	return f.dequeueLocked(), nil
+}
+
+func (f *priorityFIFO) dequeueLocked() *jobInScheduler {
+	now := f.now()
+	best, bestClass := f.best(f.entries, now)
+	e := f.entries[best]
+	f.entries = append(f.entries[:best], f.entries[best+1:]...)
+	zap.L().Debug(fmt.Sprintf("dequeue job(%s) in %s priority promoted to %s after %s",
+		e.jis.job.JobData().ID, priorityClasses[e.class], priorityClasses[bestClass], now.Sub(e.enqueued)))
+	return e.jis
+}
+
+// best returns the index and the effective class of the entry to be dequeued next.
+func (f *priorityFIFO) best(entries []*priorityEntry, now time.Time) (int, int) {
+	best := 0
+	bestClass := f.effectiveClass(entries[0], now)
+	for i := 1; i < len(entries); i++ {
+		// the earlier entry wins in the same class
+		if c := f.effectiveClass(entries[i], now); c < bestClass {
+			best = i
+			bestClass = c
+		}
+	}
+	return best, bestClass
+}
+
+func (f *priorityFIFO) Get(index int) (*jobInScheduler, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if index < 0 || index >= len(f.entries) {
+		return nil, fmt.Errorf("index out of bounds")
+	}
+	return f.entries[index].jis, nil
+}
+
+func (f *priorityFIFO) GetLen() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.entries)
+}
+
+func (f *priorityFIFO) Remove(index int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if index < 0 || index >= len(f.entries) {
+		return fmt.Errorf("index out of bounds")
+	}
+	f.entries = append(f.entries[:index], f.entries[index+1:]...)
+	return nil
+}
+
+// Snapshot returns the queued jobs in the order of the priorities at present.
+// The later aging is not taken into account.
+func (f *priorityFIFO) Snapshot() []*jobInScheduler {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	entries := append([]*priorityEntry{}, f.entries...)
+	jobs := make([]*jobInScheduler, 0, len(entries))
+	for len(entries) > 0 {
+		best, _ := f.best(entries, now)
+		jobs = append(jobs, entries[best].jis)
+		entries = append(entries[:best], entries[best+1:]...)
+	}
+	return jobs
+}
@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPriorityJobInScheduler(t *testing.T, id string, jobType string, priority string) *jobInScheduler {
+	jd := &core.JobData{ID: id, JobType: jobType, Priority: priority}
+	return &jobInScheduler{
+		job: (&core.NormalJob{}).New(jd, nil),
+	}
+}
+
+func dequeueIDs(t *testing.T, f *priorityFIFO) []string {
+	ids := []string{}
+	for f.GetLen() > 0 {
+		jis, err := f.Dequeue()
+		assert.Nil(t, err)
+		ids = append(ids, jis.job.JobData().ID)
+	}
+	return ids
+}
+
+func jobIDsOf(jobs []*jobInScheduler) []string {
+	ids := []string{}
+	for _, jis := range jobs {
+		ids = append(ids, jis.job.JobData().ID)
+	}
+	return ids
+}
+
+func TestPriorityFIFO(t *testing.T) {
+	f := newPriorityFIFO(map[string]string{"sse": LowPriority, "calibration": HighPriority}, time.Minute)
+	now := time.Now()
+	f.now = func() time.Time { return now }
+
+	f.Enqueue(newPriorityJobInScheduler(t, "bulk1", "sampling", ""))
+	f.Enqueue(newPriorityJobInScheduler(t, "sse1", "sse", ""))
+	f.Enqueue(newPriorityJobInScheduler(t, "bulk2", "sampling", ""))
+	f.Enqueue(newPriorityJobInScheduler(t, "calib", "calibration", ""))
+	f.Enqueue(newPriorityJobInScheduler(t, "bench", "sampling", HighPriority))
+	f.Enqueue(newPriorityJobInScheduler(t, "unknown", "sampling", "urgent"))
+
+	// Get and Remove use the order of arrival
+	jis, err := f.Get(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "sse1", jis.job.JobData().ID)
+
+	// Snapshot uses the order of dequeueing
+	assert.Equal(t, []string{"calib", "bench", "bulk1", "bulk2", "unknown", "sse1"}, jobIDsOf(f.Snapshot()))
+	assert.Equal(t, 6, f.GetLen())
+	assert.Equal(t, []string{"calib", "bench", "bulk1", "bulk2", "unknown", "sse1"}, dequeueIDs(t, f))
+	_, err = f.Dequeue()
+	assert.EqualError(t, err, "empty queue")
+}
+
+func TestPriorityFIFOAging(t *testing.T) {
+	f := newPriorityFIFO(map[string]string{}, time.Minute)
+	now := time.Now()
+	f.now = func() time.Time { return now }
+
+	f.Enqueue(newPriorityJobInScheduler(t, "low", "sampling", LowPriority))
+	now = now.Add(time.Minute)
+	f.Enqueue(newPriorityJobInScheduler(t, "normal", "sampling", NormalPriority))
+	// the low job is promoted to normal and has arrived earlier
+	assert.Equal(t, []string{"low", "normal"}, dequeueIDs(t, f))
+
+	f.Enqueue(newPriorityJobInScheduler(t, "low", "sampling", LowPriority))
+	now = now.Add(90 * time.Second)
+	f.Enqueue(newPriorityJobInScheduler(t, "high", "sampling", HighPriority))
+	// promoted only once after 90 seconds
+	assert.Equal(t, []string{"high", "low"}, dequeueIDs(t, f))
+}
+
+func TestPriorityFIFOWait(t *testing.T) {
+	f := newPriorityFIFO(map[string]string{}, time.Minute)
+	got := make(chan string)
+	go func() {
+		jis, err := f.DequeueOrWaitForNextElement()
+		assert.Nil(t, err)
+		got <- jis.job.JobData().ID
+	}()
+	f.Enqueue(newPriorityJobInScheduler(t, "job", "sampling", ""))
+	select {
+	case id := <-got:
+		assert.Equal(t, "job", id)
+	case <-time.After(time.Second):
+		t.Fatal("not dequeued")
+	}
+}
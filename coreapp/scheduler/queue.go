@@ -1,7 +1,9 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	conq "github.com/enriquebris/goconcurrentqueue"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
@@ -17,6 +19,8 @@ type fifo interface {
 	Get(index int) (*jobInScheduler, error)
 	GetLen() int
 	Remove(index int) error
+	// Snapshot returns the queued jobs in the order they would be dequeued now.
+	Snapshot() []*jobInScheduler
 }
 
 type conqFIFO struct {
@@ -65,84 +69,144 @@ func (c *conqFIFO) Remove(index int) error {
 	return c.FIFO.Remove(index)
 }
 
+func (c *conqFIFO) Snapshot() []*jobInScheduler {
+	jobs := []*jobInScheduler{}
+	for i := 0; i < c.GetLen(); i++ {
+		if jis, err := c.Get(i); err == nil {
+			jobs = append(jobs, jis)
+		}
+	}
+	return jobs
+}
+
 type NormalQueue struct {
 	fifo            fifo
 	maxSize         int
 	refillThreshold int
 	queueChan       queueChan
-	cancelChan      chan struct{}
+	// stops the goroutine putting the jobs from queueChan
+	cancel context.CancelFunc
+	// serializes the changes of the fifo, to keep the size under maxSize and to find and remove
+	// a job at once
+	mu sync.Mutex
+	// put is signaled when a job is put, for the processor waiting for the job
+	put chan struct{}
 }
 
-// TODO: use rungroup
+// Setup starts the goroutine putting the jobs from queueChan, which exits at TearDown.
 func (n *NormalQueue) Setup(conf *core.Conf) error {
+	return n.setupWithFIFO(conf, newConqFIFO())
+}
+
+func (n *NormalQueue) setupWithFIFO(conf *core.Conf, f fifo) error {
 	n.refillThreshold = conf.QueueRefillThreshold
 	n.maxSize = conf.QueueMaxSize
-	n.fifo = newConqFIFO()
+	n.fifo = f
 	n.queueChan = make(queueChan)
-	n.cancelChan = make(chan struct{})
+	n.put = make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
 	go func() {
-		defer close(n.cancelChan)
 		for {
 			var jis *jobInScheduler
 			select {
-			case <-n.cancelChan:
+			case <-ctx.Done():
 				return
 			case jis = <-n.queueChan:
 			}
-			jd := jis.job.JobData()
-			if n.maxSize <= n.fifo.GetLen() {
-				zap.L().Info(fmt.Sprintf("Failed to put %s. Normal Queue is full.", jd.ID))
-				continue
-			}
-			zap.L().Debug(fmt.Sprintf("Putting %s to normalQueue", jd.ID))
-			err := n.fifo.Enqueue(jis)
-			if err != nil {
-				zap.L().Error(
-					fmt.Sprintf("Failed to put %s to normalQueue. Reason:%s", jd.ID, err))
+			if err := n.Put(jis); err != nil && jis.finished != nil {
+				// release the handler waiting for the job
+				jis.finished.Done()
 			}
 		}
 	}()
 	return nil
 }
 
-func (n *NormalQueue) TearDown() {
-	n.cancelChan <- struct{}{}
-}
-
-// wait until the next elements gets enqueued
-func (n *NormalQueue) Dequeue(wait bool) (jis *jobInScheduler, err error) {
-	var tmp interface{} // TODO: use generic type?
-	jis = nil
-	err = nil
-	if wait {
-		tmp, err = n.fifo.DequeueOrWaitForNextElement()
-	} else {
-		tmp, err = n.fifo.Dequeue()
+// Put enqueues the job. ErrorQueueFull is returned when the queue has reached maxSize.
+func (n *NormalQueue) Put(jis *jobInScheduler) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	jd := jis.job.JobData()
+	if n.maxSize <= n.fifo.GetLen() {
+		zap.L().Info(fmt.Sprintf("Failed to put %s. Normal Queue is full.", jd.ID))
+		return core.ErrorQueueFull
 	}
+	zap.L().Debug(fmt.Sprintf("Putting %s to normalQueue", jd.ID))
+	err := n.fifo.Enqueue(jis)
+	if err != nil {
+		zap.L().Error(
+			fmt.Sprintf("Failed to put %s to normalQueue. Reason:%s", jd.ID, err))
+		return err
+	}
+	select {
+	case n.put <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (n *NormalQueue) TearDown() {
+	n.cancel()
+}
+
+// Dequeue removes the first job from the queue. The scheduler waits for the put signal
+// when the queue is empty.
+func (n *NormalQueue) Dequeue() (*jobInScheduler, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.dequeue()
+}
+
+// dequeue must be called with n.mu held.
+func (n *NormalQueue) dequeue() (*jobInScheduler, error) {
+	jis, err := n.fifo.Dequeue()
 	if err != nil {
 		zap.L().Debug("no job in NormalQueue.", zap.Error(err))
-		return
+		return nil, err
 	}
-	jis = tmp.(*jobInScheduler)
 	zap.L().Debug(fmt.Sprintf("Dequeued job:%s", jis.job.JobData().ID))
-	return
+	return jis, nil
 }
 
+// Take removes the first job accepted by f from the queue. Nil is returned when there is no such job.
+func (n *NormalQueue) Take(f func(*core.JobData) bool) *jobInScheduler {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := 0; i < n.fifo.GetLen(); i++ {
+		jis, err := n.fifo.Get(i)
+		if err != nil || !f(jis.job.JobData()) {
+			continue
+		}
+		if err := n.fifo.Remove(i); err != nil {
+			zap.L().Error(fmt.Sprintf("Failed to remove idx:%d. Reason:%s", i, err))
+			return nil
+		}
+		zap.L().Debug(fmt.Sprintf("Took job:%s", jis.job.JobData().ID))
+		return jis
+	}
+	return nil
+}
+
+// Delete removes the job from the queue and releases the handler waiting for the job.
+// The job is found and removed under the lock, so that a job dequeued at the same time is
+// neither removed by mistake nor released twice.
 func (n *NormalQueue) Delete(jobID string) error {
 	zap.L().Debug(fmt.Sprintf("deleting %s to normalQueue", jobID))
-	var idx int
-	var err error
-
-	idx, err = n.getIdx(jobID)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	idx, jis, err := n.find(jobID)
 	if err != nil {
 		zap.L().Info(fmt.Sprintf("Failed to Delete %s. Reason:%s", jobID, err))
 		return err
 	}
-	err = n.fifo.Remove(idx)
-	if err != nil {
+	if err := n.fifo.Remove(idx); err != nil {
 		zap.L().Error(fmt.Sprintf("Failed to remove idx:%d. Reason:%s", idx, err))
 		return err
 	}
+	if jis.finished != nil {
+		jis.finished.Done()
+	}
 	return nil
 }
 
@@ -154,14 +218,15 @@ func (n *NormalQueue) GetCurrentSize() int {
 	return n.fifo.GetLen()
 }
 
-func (n *NormalQueue) getIdx(jobID string) (int, error) {
+// find must be called with n.mu held.
+func (n *NormalQueue) find(jobID string) (int, *jobInScheduler, error) {
 	for i := 0; i < n.fifo.GetLen(); i++ {
 		js, err := n.fifo.Get(i)
 		if err == nil {
 			if js.job.JobData().ID == jobID {
-				return i, nil
+				return i, js, nil
 			}
 		}
 	}
-	return 0, fmt.Errorf("No entry")
+	return 0, nil, fmt.Errorf("No entry")
 }
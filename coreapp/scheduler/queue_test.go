@@ -4,6 +4,10 @@
 package scheduler
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	"github.com/stretchr/testify/assert"
 
@@ -51,11 +55,23 @@ func TestPutNormalQueue(t *testing.T) {
 	n.queueChan <- newjobInScheduler(t, "test1")
 	<-queuedChan
 	assert.Equal(t, 1, n.fifo.GetLen())
-	js, err := n.Dequeue(false)
+	js, err := n.Dequeue()
 	assert.Nil(t, err)
 	assert.Equal(t, js.job.JobData().ID, "test1")
 }
 
+func TestPutNormalQueueFull(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	n := &NormalQueue{}
+	n.Setup(&core.Conf{QueueMaxSize: 1})
+	defer n.TearDown()
+
+	assert.Nil(t, n.Put(newjobInScheduler(t, "test1")))
+	assert.ErrorIs(t, n.Put(newjobInScheduler(t, "test2")), core.ErrorQueueFull)
+	assert.Equal(t, 1, n.fifo.GetLen())
+}
+
 func TestNormalQueueDelete(t *testing.T) {
 	s := core.SCWithUnimplementedContainer()
 	defer s.TearDown()
@@ -83,23 +99,67 @@ func TestNormalQueueDelete(t *testing.T) {
 	var jwg *jobInScheduler
 	var err error
 
-	jwg, err = n.Dequeue(false)
+	jwg, err = n.Dequeue()
 	assert.Nil(t, err)
 	assert.Equal(t, jwg.job.JobData().ID, "test1")
 
-	jwg, err = n.Dequeue(false)
+	jwg, err = n.Dequeue()
 	assert.Nil(t, err)
 	assert.Equal(t, jwg.job.JobData().ID, "test2")
 
-	jwg, err = n.Dequeue(false)
+	jwg, err = n.Dequeue()
 	assert.Nil(t, err)
 	assert.Equal(t, jwg.job.JobData().ID, "test4")
 
-	jwg, err = n.Dequeue(false)
+	jwg, err = n.Dequeue()
 	assert.EqualError(t, err, "empty queue")
 	assert.Nil(t, jwg)
 }
 
+func TestNormalQueueDeleteWhileDequeue(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	n := &NormalQueue{}
+	n.Setup(&core.Conf{QueueMaxSize: 1000})
+	defer n.TearDown()
+
+	const jobs = 200
+	var finished sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		jis := newjobInScheduler(t, fmt.Sprintf("test%d", i))
+		jis.finished = &finished
+		finished.Add(1)
+		assert.Nil(t, n.Put(jis))
+	}
+	// each job is either dequeued or deleted, and released once
+	var dequeued, deleted atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			jis, err := n.Dequeue()
+			if err != nil {
+				return
+			}
+			dequeued.Add(1)
+			jis.finished.Done()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := jobs - 1; i >= 0; i-- {
+			if n.Delete(fmt.Sprintf("test%d", i)) == nil {
+				deleted.Add(1)
+			}
+		}
+	}()
+	wg.Wait()
+	finished.Wait()
+	assert.Equal(t, int64(jobs), dequeued.Load()+deleted.Load())
+	assert.Equal(t, 0, n.GetCurrentSize())
+}
+
 func newjobInScheduler(t *testing.T, id string) *jobInScheduler {
 	jm, err := core.NewJobManager(&core.NormalJob{})
 	assert.Nil(t, err)
@@ -1,8 +1,11 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	"go.uber.org/zap"
@@ -10,68 +13,198 @@ import (
 
 type statusHistory map[string][]core.Status
 
+const queueFullMessage = "the queue of the device is full. please submit the job again later"
+const drainingMessage = "the engine is shutting down. please submit the job again later"
+
 type NormalScheduler struct {
 	queue         *NormalQueue
 	statusHistory statusHistory
-	mu            sync.RWMutex
+	handlingJobs  map[string]core.Job
+	// the jobs holding a slot of the queue, from the admission until the processing starts
+	admitted map[string]struct{}
+	progress map[string]*jobProgress
+	history  *executionHistory
+	// draining refuses new jobs and keeps the jobs out of the queue
+	draining bool
+	handlers sync.WaitGroup
+	mu       sync.RWMutex
+	// stopLoop stops the processing loop, which closes loopDone when it has exited
+	stopLoop context.CancelFunc
+	loopDone chan struct{}
+	// deviceID is the device of the scheduler in DeviceScheduler, whose reservations keep the jobs
+	// in the queue. empty for the only device of the engine
+	deviceID string
+}
+
+// usageRecorder is implemented by the fifo which takes the QPU time used by the processed jobs
+// into account. The processing of a job occupies the QPU, because the jobs are processed one by one.
+type usageRecorder interface {
+	RecordUsage(core.Job, time.Duration)
 }
 
 type jobInScheduler struct {
 	job      core.Job
 	finished *sync.WaitGroup
+	// requeued is set when the job is taken out of the queue by the drain, to be processed in the next run
+	requeued bool
+	// expired is set when the job is taken out of the queue for its TTL
+	expired bool
 }
 
 func (n *NormalScheduler) Setup(conf *core.Conf) error {
+	return n.setupWithFIFO(conf, newConqFIFO())
+}
+
+func (n *NormalScheduler) setupWithFIFO(conf *core.Conf, f fifo) error {
 	n.queue = &NormalQueue{}
-	n.queue.Setup(conf)
+	n.queue.setupWithFIFO(conf, f)
 	n.statusHistory = make(statusHistory)
+	n.handlingJobs = make(map[string]core.Job)
+	n.admitted = make(map[string]struct{})
+	n.progress = make(map[string]*jobProgress)
+	n.history = newExecutionHistory()
 	n.mu = sync.RWMutex{}
 	return nil
 }
 
-// TODO: use rungroup
+func (n *NormalScheduler) bindDevice(deviceID string) {
+	n.deviceID = deviceID
+}
+
+// Start starts the loop processing the queued jobs one by one. The loop exits at Stop,
+// which the run group calls after the drain.
 func (n *NormalScheduler) Start() error {
-	// TODO: functionalize
-	go func() {
-		for {
-			zap.L().Debug("checking the queue...")
-			jis, err := n.queue.Dequeue(true)
-			if err != nil {
-				zap.L().Error(fmt.Sprintf("failed to get job from queue. Reason:%s", err))
-				continue
-			}
-			jid := jis.job.JobData().ID
-			zap.L().Debug(fmt.Sprintf("processing job:%s", jid))
-
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						zap.L().Error("recovered from panic in scheduler start", zap.String("jobID", jid), zap.Any("panic", r))
-						jis.job.JobData().Status = core.FAILED
-						jis.job.JobContext().DBChan <- jis.job.Clone()
-					}
-					jis.finished.Done()
-				}()
-
-				// TODO: not update status in scheduler
-				st := core.RUNNING
-				n.mu.Lock()
-				n.statusHistory[jid] = append(n.statusHistory[jid], st)
-				n.mu.Unlock()
-				jis.job.JobData().Status = st
-				jis.job.JobContext().DBChan <- jis.job.Clone()
-				jis.job.Process()
-				zap.L().Debug(fmt.Sprintf("finished to process job(%s), status:%s", jid, jis.job.JobData().Status))
-			}()
-		}
-	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	n.stopLoop = cancel
+	n.loopDone = make(chan struct{})
+	go n.run(ctx)
 	// TODO connected Channel
 	return nil
 }
 
-func (n *NormalScheduler) HandleJob(j core.Job) {
+// Stop stops the processing loop and the queue. It returns when the loop has exited.
+func (n *NormalScheduler) Stop() {
+	if n.stopLoop != nil {
+		n.stopLoop()
+		<-n.loopDone
+	}
+	n.queue.TearDown()
+}
+
+func (n *NormalScheduler) run(ctx context.Context) {
+	defer close(n.loopDone)
+	for {
+		zap.L().Debug("checking the queue...")
+		jis := n.nextJob(ctx)
+		if jis == nil {
+			zap.L().Info("stopped the scheduler")
+			return
+		}
+		jid := jis.job.JobData().ID
+		n.leave(jid)
+		if jis.job.JobContext().IsCancelled() {
+			zap.L().Info(fmt.Sprintf("skip processing cancelled job:%s", jid))
+			jis.finished.Done()
+			continue
+		}
+		zap.L().Debug(fmt.Sprintf("processing job:%s", jid))
+
+		// the failure of the job is reported by its handler, which waits for jis.finished
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					zap.L().Error("recovered from panic in scheduler start", zap.String("jobID", jid), zap.Any("panic", r))
+					core.SetFailureWithError(jis.job, core.NewInternalError("the job failed unexpectedly", fmt.Errorf("panic: %v", r)))
+				}
+				jis.finished.Done()
+			}()
+
+			// TODO: not update status in scheduler
+			st := core.RUNNING
+			n.mu.Lock()
+			n.statusHistory[jid] = append(n.statusHistory[jid], st)
+			n.mu.Unlock()
+			if err := jis.job.JobData().SetStatus(st); err != nil {
+				core.SetFailureWithError(jis.job, core.NewInternalError("the job failed unexpectedly", err))
+				return
+			}
+			jis.job.JobContext().Publish(core.JobRunning, jis.job)
+			n.setStage(jid, core.StageProcessing)
+			ctx, cancel := core.NewStageContext(jis.job, "processing")
+			defer cancel()
+			started := time.Now()
+			jis.job.Process(ctx)
+			used := time.Since(started)
+			if r, ok := n.queue.fifo.(usageRecorder); ok {
+				r.RecordUsage(jis.job, used)
+			}
+			if jd := jis.job.JobData(); jd.Status != core.FAILED {
+				n.history.record(jd.JobType, jd.Shots, used)
+			}
+			n.setStage(jid, core.StagePostProcessing)
+			failIfTimedOut(ctx, jis.job)
+			zap.L().Debug(fmt.Sprintf("finished to process job(%s), status:%s", jid, jis.job.JobData().Status))
+		}()
+	}
+}
+
+// nextJob waits for the next job to process. During a reservation of the device, the jobs are kept
+// in the queue, except the jobs of the project of the reservation.
+func (n *NormalScheduler) nextJob(ctx context.Context) *jobInScheduler {
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		calendar := core.GetReservations()
+		changed := calendar.Changed()
+		now := time.Now()
+		var (
+			jis      *jobInScheduler
+			boundary time.Time
+		)
+		if r, ok := calendar.Active(now, n.deviceID); ok {
+			jis = n.queue.Take(func(jd *core.JobData) bool {
+				return r.Allows(jd.Owner)
+			})
+			boundary = r.End
+		} else {
+			jis, _ = n.queue.Dequeue()
+			if next, ok := calendar.Next(now, n.deviceID); ok {
+				boundary = next.Start
+			}
+		}
+		if jis != nil {
+			return jis
+		}
+		n.waitForQueue(ctx, changed, boundary.Sub(now), !boundary.IsZero())
+	}
+}
+
+// waitForQueue waits until a job is put, the reservations are changed, d has passed if timed,
+// or ctx is done.
+func (n *NormalScheduler) waitForQueue(ctx context.Context, changed <-chan struct{}, d time.Duration, timed bool) {
+	var wake <-chan time.Time
+	if timed {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		wake = t.C
+	}
+	select {
+	case <-n.queue.put:
+	case <-changed:
+	case <-wake:
+	case <-ctx.Done():
+	}
+}
+
+func (n *NormalScheduler) HandleJob(j core.Job) error {
 	zap.L().Debug(fmt.Sprintf("starting to handle job(%s) in %s", j.JobData().ID, j.JobData().Status))
+	if err := n.admit(j); err != nil {
+		return err
+	}
 	go func() {
+		defer n.handlers.Done()
+		defer n.release(j)
 		defer func() {
 			if r := recover(); r != nil {
 				zap.L().Error("recovered from panic in handle job", zap.String("jobID", j.JobData().ID), zap.Any("panic", r))
@@ -87,24 +220,93 @@ func (n *NormalScheduler) HandleJob(j core.Job) {
 		}()
 		n.handleImpl(j)
 	}()
+	return nil
 }
 
-func (n *NormalScheduler) HandleJobForTest(j core.Job, wg *sync.WaitGroup) {
+func (n *NormalScheduler) HandleJobForTest(j core.Job, wg *sync.WaitGroup) error {
+	if err := n.admit(j); err != nil {
+		wg.Done()
+		return err
+	}
 	go func() {
 		defer wg.Done()
+		defer n.handlers.Done()
+		defer n.release(j)
 		n.handleImpl(j)
 	}()
+	return nil
+}
+
+// admit refuses the job when the same job ID is already handled in the engine, or when there is
+// no free slot in the queue. The refused job is not written to the DB, not to overwrite the active one.
+// A slot is held by the job in pre-processing too, so that the job never finds the queue full
+// after the pre-processing.
+func (n *NormalScheduler) admit(j core.Job) error {
+	jid := j.JobData().ID
+	if jm := core.GetJobManager(); jm != nil {
+		if err := jm.Activate(jid); err != nil {
+			core.CountDuplicateJob(jid, err)
+			return err
+		}
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.draining {
+		if jm := core.GetJobManager(); jm != nil {
+			jm.Release(jid)
+		}
+		zap.L().Info(fmt.Sprintf("refused job(%s). the scheduler is draining", jid))
+		return core.NewDeviceUnavailableError(
+			drainingMessage, core.ErrorDraining)
+	}
+	if n.queue.maxSize <= len(n.admitted) {
+		if jm := core.GetJobManager(); jm != nil {
+			jm.Release(jid)
+		}
+		zap.L().Info(fmt.Sprintf("refused job(%s). Normal Queue is full", jid))
+		return core.NewDeviceUnavailableError(
+			queueFullMessage, core.ErrorQueueFull)
+	}
+	n.admitted[jid] = struct{}{}
+	n.handlers.Add(1)
+	return nil
+}
+
+func (n *NormalScheduler) leave(jid string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.admitted, jid)
+}
+
+func (n *NormalScheduler) release(j core.Job) {
+	n.leave(j.JobData().ID)
+	if jm := core.GetJobManager(); jm != nil {
+		jm.Release(j.JobData().ID)
+	}
 }
 
 func (n *NormalScheduler) handleImpl(j core.Job) {
+	core.StartJobDeadline(j, time.Now())
+	n.mu.Lock()
+	n.handlingJobs[j.JobData().ID] = j
+	n.mu.Unlock()
+	defer func() {
+		n.mu.Lock()
+		delete(n.handlingJobs, j.JobData().ID)
+		delete(n.progress, j.JobData().ID)
+		n.mu.Unlock()
+		if j.JobData().Status == core.FAILED {
+			core.CountFailure(j.JobData())
+		}
+	}()
 	defer func() {
 		if r := recover(); r != nil {
 			zap.L().Error("recovered from panic in handle impl", zap.String("jobID", j.JobData().ID), zap.Any("panic", r))
-			j.JobData().Status = core.FAILED
+			core.SetFailureWithError(j, core.NewInternalError("the job failed unexpectedly", fmt.Errorf("panic: %v", r)))
 			n.mu.Lock()
 			n.statusHistory[j.JobData().ID] = append(n.statusHistory[j.JobData().ID], core.FAILED)
 			n.mu.Unlock()
-			j.JobContext().DBChan <- j.Clone()
+			j.JobContext().Publish(core.JobFailed, j)
 		}
 	}()
 
@@ -123,11 +325,20 @@ func (n *NormalScheduler) handleImpl(j core.Job) {
 			return
 		}
 		zap.L().Debug(fmt.Sprintf("handling job(%s). start pre-processing", jid))
-		j.PreProcess()
+		n.setStage(jid, core.StagePreProcessing)
+		j.JobContext().Publish(core.JobPreprocessing, j)
+		ctx, cancel := core.NewStageContext(j, "pre-processing")
+		runInPool(ctx, core.StagePool(core.PreProcessPool), j, j.PreProcess)
+		failIfTimedOut(ctx, j)
+		cancel()
+		if n.finishIfCancelled(j) {
+			return
+		}
 		if j.IsFinished() {
 			j.JobData().UseJobInfoUpdate = true //TODO: fix this adhoc
 		}
-		j.JobContext().DBChan <- j.Clone()
+		// queued or finished in pre-processing
+		j.JobContext().Publish(core.EventTypeOf(j.JobData().Status), j)
 		if j.IsFinished() {
 			zap.L().Debug(fmt.Sprintf("finished to handle job(%s) after pre-processing", jid))
 			n.mu.Lock()
@@ -141,39 +352,252 @@ func (n *NormalScheduler) handleImpl(j core.Job) {
 			job:      j,
 			finished: &wg,
 		}
-		n.queue.queueChan <- jis
-		wg.Wait()                           // wait for processing
+		n.mu.RLock()
+		draining := n.draining
+		n.mu.RUnlock()
+		if draining {
+			// not write to DB, the job is still READY to be processed in the next run
+			zap.L().Info(fmt.Sprintf("job(%s) is not queued. the scheduler is draining", jid))
+			return
+		}
+		if p, ok := j.(core.ParentJob); ok {
+			// the parent is not queued, not to hold the QPU while its children are queued
+			n.runChildren(p)
+		} else {
+			n.setStage(jid, core.StageQueued)
+			if err := n.queue.Put(jis); err != nil {
+				// the slot of the job has been taken by another job after the processing of the job
+				core.SetFailureWithError(j, core.NewDeviceUnavailableError(
+					queueFullMessage, err))
+				j.JobData().UseJobInfoUpdate = true
+				n.mu.Lock()
+				n.statusHistory[jid] = append(n.statusHistory[jid], j.JobData().Status)
+				n.mu.Unlock()
+				j.JobContext().Publish(core.EventTypeOf(j.JobData().Status), j)
+				return
+			}
+			wg.Wait() // wait for processing
+		}
+		if jis.requeued {
+			zap.L().Info(fmt.Sprintf("job(%s) is left in %s for the next run", jid, j.JobData().Status))
+			return
+		}
+		if jis.expired {
+			core.SetExpired(j)
+			j.JobData().UseJobInfoUpdate = true
+			n.mu.Lock()
+			n.statusHistory[jid] = append(n.statusHistory[jid], j.JobData().Status)
+			n.mu.Unlock()
+			j.JobContext().Publish(core.EventTypeOf(j.JobData().Status), j)
+			return
+		}
+		if n.finishIfCancelled(j) {
+			return
+		}
 		j.JobData().UseJobInfoUpdate = true //TODO: fix this adhoc
 		zap.L().Debug(fmt.Sprintf("Processed Job Status: %s", j.JobData().Status))
 		if j.IsFinished() {
-			j.JobContext().DBChan <- j.Clone()
 			zap.L().Debug(fmt.Sprintf("finished to handle job(%s) after processing with status:%s",
 				jid, j.JobData().Status.String()))
 			n.mu.Lock()
 			n.statusHistory[jid] = append(n.statusHistory[jid], j.JobData().Status)
 			n.mu.Unlock()
-			j.JobContext().DBChan <- j.Clone()
+			j.JobContext().Publish(core.EventTypeOf(j.JobData().Status), j)
 			return
 		}
 		zap.L().Debug(fmt.Sprintf("handling job(%s). start post-processing", jid))
-		j.PostProcess()
+		j.JobContext().Publish(core.JobMitigating, j)
+		ctx, cancel = core.NewStageContext(j, "post-processing")
+		runInPool(ctx, core.StagePool(core.PostProcessPool), j, j.PostProcess)
+		failIfTimedOut(ctx, j)
+		cancel()
+		if n.finishIfCancelled(j) {
+			return
+		}
 		if j.IsFinished() {
 			zap.L().Debug(fmt.Sprintf("finished to handle job(%s) after post-processing with status:%s",
 				jid, j.JobData().Status.String()))
 			n.mu.Lock()
 			n.statusHistory[jid] = append(n.statusHistory[jid], j.JobData().Status)
 			n.mu.Unlock()
-			j.JobContext().DBChan <- j.Clone()
+			j.JobContext().Publish(core.EventTypeOf(j.JobData().Status), j)
 			return
 		}
 		zap.L().Debug(fmt.Sprintf("one more loop for job(%s)", jid))
 	}
 }
 
+// runChildren runs the parent job in place of its processing. The parent leaves its slot of the queue
+// to its children, which are handled in the scheduler one by one until they finish.
+func (n *NormalScheduler) runChildren(p core.ParentJob) {
+	jid := p.JobData().ID
+	n.leave(jid)
+	n.mu.Lock()
+	n.statusHistory[jid] = append(n.statusHistory[jid], core.RUNNING)
+	n.mu.Unlock()
+	if err := p.JobData().SetStatus(core.RUNNING); err != nil {
+		core.SetFailureWithError(p, core.NewInternalError("the job failed unexpectedly", err))
+		return
+	}
+	p.JobContext().Publish(core.JobRunning, p)
+	n.setStage(jid, core.StageRunningChildren)
+	ctx, cancel := core.NewStageContext(p, "processing")
+	defer cancel()
+	p.RunChildren(ctx, n.handleChild)
+	failIfTimedOut(ctx, p)
+	n.setStage(jid, core.StagePostProcessing)
+}
+
+// handleChild handles the child job of a parent job until the child finishes.
+// The child is admitted like the other jobs, so that it is refused when the queue is full or draining.
+func (n *NormalScheduler) handleChild(child core.Job) error {
+	if err := n.admit(child); err != nil {
+		return err
+	}
+	defer n.handlers.Done()
+	defer n.release(child)
+	defer func() {
+		n.mu.Lock()
+		delete(n.statusHistory, child.JobData().ID)
+		n.mu.Unlock()
+	}()
+	n.handleImpl(child)
+	return nil
+}
+
+// Drain refuses new jobs, takes the queued jobs out of the queue and waits until the jobs
+// in pre-processing, processing and post-processing are finished or ctx is done.
+// The jobs taken out of the queue are left in READY, not to be written to the DB, so that
+// they are processed in the next run.
+func (n *NormalScheduler) Drain(ctx context.Context) error {
+	n.mu.Lock()
+	n.draining = true
+	n.mu.Unlock()
+	n.queue.mu.Lock()
+	for {
+		jis, err := n.queue.dequeue()
+		if err != nil {
+			break
+		}
+		jid := jis.job.JobData().ID
+		zap.L().Info(fmt.Sprintf("requeued job(%s) for the next run", jid))
+		n.leave(jid)
+		jis.requeued = true
+		jis.finished.Done()
+	}
+	n.queue.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.handlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		n.mu.RLock()
+		jobIDs := make([]string, 0, len(n.handlingJobs))
+		for jid := range n.handlingJobs {
+			jobIDs = append(jobIDs, jid)
+		}
+		n.mu.RUnlock()
+		sort.Strings(jobIDs)
+		return fmt.Errorf("jobs %v are still in flight: %w", jobIDs, context.Cause(ctx))
+	}
+}
+
+// ExpireJobs takes the jobs which have waited longer than their TTL out of the queue.
+// The handlers of the jobs fail them.
+func (n *NormalScheduler) ExpireJobs(now time.Time) []string {
+	expired := []string{}
+	for {
+		jis := n.queue.Take(func(jd *core.JobData) bool {
+			return core.IsExpired(jd, now)
+		})
+		if jis == nil {
+			return expired
+		}
+		jid := jis.job.JobData().ID
+		zap.L().Info(fmt.Sprintf("job(%s) expired in the queue", jid))
+		n.leave(jid)
+		jis.expired = true
+		jis.finished.Done()
+		expired = append(expired, jid)
+	}
+}
+
+// runInPool runs the stage of the job with a worker of the pool, not to overload the services
+// called in the stage. The stage is skipped when the job is cancelled or times out while waiting.
+func runInPool(ctx context.Context, pool *core.WorkerPool, j core.Job, stage func(context.Context)) {
+	release, err := pool.Acquire(ctx)
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("job(%s) stopped waiting for a worker/reason:%s", j.JobData().ID, err))
+		return
+	}
+	defer release()
+	stage(ctx)
+}
+
+// finishIfCancelled reports the cancellation of the job when the job has been cancelled.
+// The status set by the aborted stage is overwritten with CANCELLED.
+func (n *NormalScheduler) finishIfCancelled(j core.Job) bool {
+	if !j.JobContext().IsCancelled() {
+		return false
+	}
+	jid := j.JobData().ID
+	zap.L().Info(fmt.Sprintf("job(%s) is cancelled in %s", jid, j.JobData().Status))
+	core.SetCancelled(j)
+	j.JobData().UseJobInfoUpdate = true
+	n.mu.Lock()
+	n.statusHistory[jid] = append(n.statusHistory[jid], core.CANCELLED)
+	n.mu.Unlock()
+	j.JobContext().Publish(core.JobCancelled, j)
+	return true
+}
+
+// failIfTimedOut fails the job when the stage has overrun the deadline of the job.
+// A stage which returns after the deadline is failed even if it has ignored the context.
+func failIfTimedOut(ctx context.Context, j core.Job) {
+	if !core.IsTimedOut(ctx) {
+		return
+	}
+	zap.L().Error(fmt.Sprintf("job(%s) timed out/reason:%s", j.JobData().ID, context.Cause(ctx)))
+	core.SetTimedOut(j, ctx)
+}
+
+// CancelJob cancels the job handled in the scheduler.
+// A queued job is removed from the queue, and a job in pre-processing, processing or
+// post-processing is aborted through the cancellation of its context.
+func (n *NormalScheduler) CancelJob(jobID string) error {
+	n.mu.RLock()
+	j, ok := n.handlingJobs[jobID]
+	n.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("job(%s) is not handled in the scheduler", jobID)
+	}
+	zap.L().Info(fmt.Sprintf("cancelling job(%s) in %s", jobID, j.JobData().Status))
+	j.JobContext().Cancel()
+	if err := n.queue.Delete(jobID); err != nil {
+		zap.L().Debug(fmt.Sprintf("job(%s) is not in the queue/reason:%s", jobID, err))
+	}
+	return nil
+}
+
 func (n *NormalScheduler) GetCurrentQueueSize() int {
 	return n.queue.fifo.GetLen()
 }
 
+// GetQueueFreeSlots returns the number of the jobs which can be handled without being refused.
+func (n *NormalScheduler) GetQueueFreeSlots() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if free := n.queue.maxSize - len(n.admitted); free > 0 {
+		return free
+	}
+	return 0
+}
+
 func (n *NormalScheduler) IsOverRefillThreshold() bool {
 	return n.queue.refillThreshold <= n.queue.fifo.GetLen()
 }
@@ -4,10 +4,13 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +23,12 @@ const FAILED_IN_PROCESS_JOB = "FAILED_in_process_job"
 const FAILED_IN_POST_PROCESS_JOB = "FAILED_in_post_process_job"
 const SUCCESS_IN_POST_PROCESS_JOB = "success_in_post_process_job"
 const PANIC_IN_PROCESS_JOB = "panic_in_process_job"
+const WAIT_FOR_CANCEL_JOB = "wait_for_cancel_job"
+const WAIT_FOR_TIMEOUT_JOB = "wait_for_timeout_job"
+const WAIT_FOR_RELEASE_JOB = "wait_for_release_job"
+
+// started is signaled when waitForReleaseJob starts processing, and released is closed to finish it
+var started, released chan struct{}
 
 func TestMain(m *testing.M) {
 	jm, _ = core.NewJobManager(
@@ -29,6 +38,9 @@ func TestMain(m *testing.M) {
 		&FAILEDInPostProcessJob{},
 		&successInPostProcessJob{},
 		&panicInProcessJob{},
+		&waitForCancelJob{},
+		&waitForTimeoutJob{},
+		&waitForReleaseJob{},
 	)
 	m.Run()
 }
@@ -140,6 +152,321 @@ func TestHandleJob(t *testing.T) {
 	}
 }
 
+func TestPanicReportedOnce(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	assert.Nil(t, s.StartContainer())
+	sub := s.EventBus.Subscribe("test", core.DEFAULT_EVENT_BUFFER_SIZE, core.DropOldest)
+
+	j := testJob(t, PANIC_IN_PROCESS_JOB, core.READY)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	nsc.HandleJobForTest(j, &wg)
+	wg.Wait()
+	s.EventBus.Close()
+
+	failed := 0
+	for e := range sub.Events() {
+		if e.Job.JobData().ID == j.JobData().ID && e.Type == core.JobFailed {
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+}
+
+func TestCancelJob(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	t.Run("cancel a job not handled in the scheduler", func(t *testing.T) {
+		assert.NotNil(t, nsc.CancelJob("not_handled_job"))
+	})
+
+	t.Run("cancel a job in pre-processing", func(t *testing.T) {
+		j := testJob(t, WAIT_FOR_CANCEL_JOB, core.READY)
+		jobID := j.JobData().ID
+		var wg sync.WaitGroup
+		wg.Add(1)
+		nsc.HandleJobForTest(j, &wg)
+		assert.Eventually(t, func() bool {
+			return nsc.CancelJob(jobID) == nil
+		}, time.Second, 10*time.Millisecond)
+		wg.Wait()
+		assert.Equal(t, []core.Status{core.READY, core.CANCELLED}, nsc.statusHistory[jobID])
+		assert.Equal(t, core.CANCELLED, j.JobData().Status)
+	})
+}
+
+func TestDuplicateJob(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	j := testJob(t, WAIT_FOR_CANCEL_JOB, core.READY)
+	jobID := j.JobData().ID
+	var wg sync.WaitGroup
+	wg.Add(1)
+	nsc.HandleJobForTest(j, &wg)
+	assert.True(t, core.GetJobManager().IsActive(jobID))
+
+	// the same job fetched again is refused while the first one is active
+	dup := testJob(t, core.NORMAL_JOB, core.READY)
+	dup.JobData().ID = jobID
+	before := core.DuplicateJobCount()
+	var dupWG sync.WaitGroup
+	dupWG.Add(1)
+	nsc.HandleJobForTest(dup, &dupWG)
+	dupWG.Wait()
+	assert.Equal(t, before+1, core.DuplicateJobCount())
+	assert.Equal(t, core.READY, dup.JobData().Status)
+
+	assert.Eventually(t, func() bool {
+		return nsc.CancelJob(jobID) == nil
+	}, time.Second, 10*time.Millisecond)
+	wg.Wait()
+	assert.False(t, core.GetJobManager().IsActive(jobID))
+}
+
+func TestQueueFull(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+	nsc.queue.maxSize = 1
+
+	j := testJob(t, WAIT_FOR_CANCEL_JOB, core.READY)
+	jobID := j.JobData().ID
+	var wg sync.WaitGroup
+	wg.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(j, &wg))
+	// the job in pre-processing holds the slot
+	assert.Equal(t, 0, nsc.GetQueueFreeSlots())
+
+	refused := testJob(t, core.NORMAL_JOB, core.READY)
+	var refusedWG sync.WaitGroup
+	refusedWG.Add(1)
+	err = nsc.HandleJobForTest(refused, &refusedWG)
+	refusedWG.Wait()
+	assert.ErrorIs(t, err, core.ErrorQueueFull)
+	assert.Equal(t, core.DeviceUnavailableError, core.ErrorKindOf(err))
+	assert.False(t, core.GetJobManager().IsActive(refused.JobData().ID))
+	assert.Equal(t, core.READY, refused.JobData().Status)
+
+	assert.Eventually(t, func() bool {
+		return nsc.CancelJob(jobID) == nil
+	}, time.Second, 10*time.Millisecond)
+	wg.Wait()
+	assert.Equal(t, 1, nsc.GetQueueFreeSlots())
+}
+
+func TestJobTimeout(t *testing.T) {
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(core.JOB_TIMEOUT_SETTING_KEY, map[string]interface{}{
+		WAIT_FOR_TIMEOUT_JOB: "100ms",
+	})
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	j := testJob(t, WAIT_FOR_TIMEOUT_JOB, core.READY)
+	jobID := j.JobData().ID
+	var wg sync.WaitGroup
+	wg.Add(1)
+	nsc.HandleJobForTest(j, &wg)
+	wg.Wait()
+	assert.Equal(t, []core.Status{core.READY, core.RUNNING, core.FAILED}, nsc.statusHistory[jobID])
+	assert.Contains(t, j.JobData().Result.Message, core.ErrorJobTimeout.Error())
+}
+
+func TestDrain(t *testing.T) {
+	started, released = make(chan struct{}, 1), make(chan struct{})
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	processing := testJob(t, WAIT_FOR_RELEASE_JOB, core.READY)
+	var processingWG sync.WaitGroup
+	processingWG.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(processing, &processingWG))
+	<-started
+	queued := testJob(t, core.NORMAL_JOB, core.READY)
+	var queuedWG sync.WaitGroup
+	queuedWG.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(queued, &queuedWG))
+	assert.Eventually(t, func() bool {
+		return nsc.GetCurrentQueueSize() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	drained := make(chan error)
+	go func() {
+		drained <- nsc.Drain(context.Background())
+	}()
+	// the queued job is left in READY for the next run
+	queuedWG.Wait()
+	assert.Equal(t, core.READY, queued.JobData().Status)
+	assert.Equal(t, 0, nsc.GetCurrentQueueSize())
+
+	refused := testJob(t, core.NORMAL_JOB, core.READY)
+	var refusedWG sync.WaitGroup
+	refusedWG.Add(1)
+	err = nsc.HandleJobForTest(refused, &refusedWG)
+	assert.ErrorIs(t, err, core.ErrorDraining)
+	assert.False(t, core.GetJobManager().IsActive(refused.JobData().ID))
+
+	// the job in processing is finished before the drain returns
+	close(released)
+	assert.Nil(t, <-drained)
+	processingWG.Wait()
+	assert.Equal(t, core.SUCCEEDED, processing.JobData().Status)
+
+	// the processing loop exits when the system components are stopped after the drain
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the processing loop has not exited")
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	started, released = make(chan struct{}, 1), make(chan struct{})
+	defer close(released)
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	j := testJob(t, WAIT_FOR_RELEASE_JOB, core.READY)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(j, &wg))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = nsc.Drain(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), j.JobData().ID)
+}
+
+func TestReservation(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+	now := time.Now()
+	r, err := core.GetReservations().Add(core.Reservation{
+		Start: now.Add(-time.Minute), End: now.Add(time.Hour), Project: "project_a"})
+	assert.Nil(t, err)
+	defer core.GetReservations().Delete(r.ID)
+
+	held := testJob(t, core.NORMAL_JOB, core.READY)
+	var heldWG sync.WaitGroup
+	heldWG.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(held, &heldWG))
+	assert.Eventually(t, func() bool {
+		return nsc.GetCurrentQueueSize() == 1
+	}, time.Second, 10*time.Millisecond)
+	// estimated to start after the reservation
+	jobs := nsc.ListJobs()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, r.End, *jobs[0].EstimatedStartAt)
+
+	// the job of the project runs in the reservation
+	reserved := testJob(t, core.NORMAL_JOB, core.READY)
+	reserved.JobData().Owner = "project_a"
+	var reservedWG sync.WaitGroup
+	reservedWG.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(reserved, &reservedWG))
+	reservedWG.Wait()
+	assert.Equal(t, core.SUCCEEDED, reserved.JobData().Status)
+	assert.Equal(t, core.READY, held.JobData().Status)
+
+	assert.Nil(t, core.GetReservations().Delete(r.ID))
+	heldWG.Wait()
+	assert.Equal(t, core.SUCCEEDED, held.JobData().Status)
+}
+
+func TestReservationOfAnotherDevice(t *testing.T) {
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	nsc.bindDevice("device1")
+	err := s.StartContainer()
+	assert.Nil(t, err)
+	now := time.Now()
+	r, err := core.GetReservations().Add(core.Reservation{
+		DeviceID: "device2", Start: now.Add(-time.Minute), End: now.Add(time.Hour)})
+	assert.Nil(t, err)
+	defer core.GetReservations().Delete(r.ID)
+
+	// the reservation of device2 does not keep the jobs of device1
+	j := testJob(t, core.NORMAL_JOB, core.READY)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(j, &wg))
+	wg.Wait()
+	assert.Equal(t, core.SUCCEEDED, j.JobData().Status)
+}
+
+func TestExpireJobs(t *testing.T) {
+	core.ResetSetting()
+	defer core.ResetSetting()
+	core.RegisterSetting(core.JOB_TTL_SETTING_KEY, map[string]interface{}{
+		core.NORMAL_JOB: "1h",
+	})
+	started, released = make(chan struct{}, 1), make(chan struct{})
+	nsc := &NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	err := s.StartContainer()
+	assert.Nil(t, err)
+
+	processing := testJob(t, WAIT_FOR_RELEASE_JOB, core.READY)
+	var processingWG sync.WaitGroup
+	processingWG.Add(1)
+	assert.Nil(t, nsc.HandleJobForTest(processing, &processingWG))
+	<-started
+	old := testJob(t, core.NORMAL_JOB, core.READY)
+	old.JobData().Created = strfmt.DateTime(time.Now().Add(-2 * time.Hour))
+	fresh := testJob(t, core.NORMAL_JOB, core.READY)
+	fresh.JobData().Created = strfmt.DateTime(time.Now())
+	var queuedWG sync.WaitGroup
+	queuedWG.Add(2)
+	assert.Nil(t, nsc.HandleJobForTest(old, &queuedWG))
+	assert.Nil(t, nsc.HandleJobForTest(fresh, &queuedWG))
+	assert.Eventually(t, func() bool {
+		return nsc.GetCurrentQueueSize() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{old.JobData().ID}, nsc.ExpireJobs(time.Now()))
+	assert.Equal(t, 1, nsc.GetCurrentQueueSize())
+	close(released)
+	queuedWG.Wait()
+	processingWG.Wait()
+	assert.Equal(t, core.FAILED, old.JobData().Status)
+	assert.Contains(t, old.JobData().Result.Message, "expired")
+	assert.Equal(t, core.SUCCEEDED, fresh.JobData().Status)
+}
+
 func testJob(t *testing.T, jobType string, firstStatus core.Status) core.Job {
 	jd := core.NewJobData()
 	jd.ID = uuid.NewString()
@@ -165,7 +492,7 @@ func (j *FAILEDInPreProcessJob) New(jd *core.JobData, jc *core.JobContext) core.
 	}
 }
 
-func (j *FAILEDInPreProcessJob) PreProcess() {
+func (j *FAILEDInPreProcessJob) PreProcess(ctx context.Context) {
 	j.JobData().Status = core.FAILED
 	return
 }
@@ -185,7 +512,7 @@ func (j *FAILEDInProcessJob) New(jd *core.JobData, jc *core.JobContext) core.Job
 	}
 }
 
-func (j *FAILEDInProcessJob) Process() {
+func (j *FAILEDInProcessJob) Process(ctx context.Context) {
 	j.JobData().Status = core.FAILED
 	return
 }
@@ -205,12 +532,12 @@ func (j *FAILEDInPostProcessJob) New(jd *core.JobData, jc *core.JobContext) core
 	}
 }
 
-func (j *FAILEDInPostProcessJob) Process() {
+func (j *FAILEDInPostProcessJob) Process(ctx context.Context) {
 	j.JobData().Status = core.RUNNING
 	return
 }
 
-func (j *FAILEDInPostProcessJob) PostProcess() {
+func (j *FAILEDInPostProcessJob) PostProcess(ctx context.Context) {
 	j.JobData().Status = core.FAILED
 	return
 }
@@ -230,12 +557,12 @@ func (j *successInPostProcessJob) New(jd *core.JobData, jc *core.JobContext) cor
 	}
 }
 
-func (j *successInPostProcessJob) Process() {
+func (j *successInPostProcessJob) Process(ctx context.Context) {
 	j.JobData().Status = core.SUCCEEDED
 	return
 }
 
-func (j *successInPostProcessJob) PostProcess() {
+func (j *successInPostProcessJob) PostProcess(ctx context.Context) {
 	j.JobData().Status = core.SUCCEEDED
 	return
 }
@@ -263,10 +590,69 @@ func (j *panicInProcessJob) New(jd *core.JobData, jc *core.JobContext) core.Job
 	}
 }
 
-func (j *panicInProcessJob) Process() {
+func (j *panicInProcessJob) Process(ctx context.Context) {
 	panic("panic in process")
 }
 
 func (j *panicInProcessJob) JobType() string {
 	return PANIC_IN_PROCESS_JOB
 }
+
+type waitForCancelJob struct {
+	*core.UnimplementedJob
+}
+
+func (j *waitForCancelJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
+	u := &core.UnimplementedJob{}
+	return &waitForCancelJob{
+		UnimplementedJob: u.New(jd, jc).(*core.UnimplementedJob),
+	}
+}
+
+func (j *waitForCancelJob) PreProcess(ctx context.Context) {
+	<-ctx.Done()
+}
+
+func (j *waitForCancelJob) JobType() string {
+	return WAIT_FOR_CANCEL_JOB
+}
+
+type waitForTimeoutJob struct {
+	*core.UnimplementedJob
+}
+
+func (j *waitForTimeoutJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
+	u := &core.UnimplementedJob{}
+	return &waitForTimeoutJob{
+		UnimplementedJob: u.New(jd, jc).(*core.UnimplementedJob),
+	}
+}
+
+func (j *waitForTimeoutJob) Process(ctx context.Context) {
+	<-ctx.Done()
+}
+
+func (j *waitForTimeoutJob) JobType() string {
+	return WAIT_FOR_TIMEOUT_JOB
+}
+
+type waitForReleaseJob struct {
+	*core.UnimplementedJob
+}
+
+func (j *waitForReleaseJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
+	u := &core.UnimplementedJob{}
+	return &waitForReleaseJob{
+		UnimplementedJob: u.New(jd, jc).(*core.UnimplementedJob),
+	}
+}
+
+func (j *waitForReleaseJob) Process(ctx context.Context) {
+	started <- struct{}{}
+	<-released
+	j.JobData().Status = core.SUCCEEDED
+}
+
+func (j *waitForReleaseJob) JobType() string {
+	return WAIT_FOR_RELEASE_JOB
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const JobTTLTaskName = "job_ttl"
+
+// JobTTLTaskImpl periodically fails the queued jobs which have waited longer than the TTL of
+// their job type in [com.job_ttl], e.g. while the device is in maintenance.
+type JobTTLTaskImpl struct {
+	core.DefaultTaskImpl
+}
+
+func (t *JobTTLTaskImpl) Task() {
+	var expired []string
+	core.GetSystemComponents().Invoke(
+		func(sc core.Scheduler) {
+			expired = sc.ExpireJobs(time.Now())
+		})
+	if len(expired) > 0 {
+		zap.L().Info(fmt.Sprintf("expired %d jobs:%v", len(expired), expired))
+	}
+}
@@ -1,7 +1,9 @@
 package sse
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,8 +32,19 @@ func (j *SSEJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
 	}
 }
 
-func (j *SSEJob) PreProcess() {
-	if err := j.preProcessImpl(); err != nil {
+func (j *SSEJob) Validate() error {
+	jd := j.JobData()
+	maxSize := conf.GetSSEConf().MaxFileSize
+	if int64(len(jd.QASM)) > maxSize {
+		msg := fmt.Sprintf("size of user program(%d bytes) is over the limit(%d bytes)", len(jd.QASM), maxSize)
+		zap.L().Info(msg + fmt.Sprintf("/jobID:%s", jd.ID))
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
+func (j *SSEJob) PreProcess(ctx context.Context) {
+	if err := j.preProcessImpl(ctx); err != nil {
 		zap.L().Error(fmt.Sprintf("failed to pre-process a job(%s). Reason:%s", j.JobData().ID, err.Error()))
 		core.SetFailureWithError(j, err)
 		return
@@ -39,7 +52,7 @@ func (j *SSEJob) PreProcess() {
 	return
 }
 
-func (j *SSEJob) preProcessImpl() (err error) {
+func (j *SSEJob) preProcessImpl(ctx context.Context) (err error) {
 	container := core.GetSystemComponents().Container
 	err = nil
 	jd := j.JobData()
@@ -48,6 +61,7 @@ func (j *SSEJob) preProcessImpl() (err error) {
 			return d.Insert(j)
 		})
 	if err != nil {
+		err = core.NewInternalError("failed to store the job", err)
 		return
 	}
 
@@ -55,11 +69,13 @@ func (j *SSEJob) preProcessImpl() (err error) {
 	apiClient, err := apiclient.NewSseApiClient()
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to make a client to get the source file of SSE: %s", err))
+		err = core.NewInternalError("failed to download the user program", err)
 		return
 	}
 	userprogramData, err := downloadUserProgram(jd.ID, sseconf, apiClient)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("Failed to download userprogram. Reason:%s", err))
+		err = core.NewDependencyFailureError("failed to download the user program", err)
 		return
 	}
 	j.UserProgramData = userprogramData
@@ -67,6 +83,7 @@ func (j *SSEJob) preProcessImpl() (err error) {
 	// Make tmp dir
 	dirPath, inputDirPath, err := makeTmpDir(jd.ID, sseconf.HostPath)
 	if err != nil {
+		err = core.NewInternalError("failed to prepare the user program", err)
 		return
 	}
 
@@ -80,13 +97,18 @@ func (j *SSEJob) preProcessImpl() (err error) {
 	return
 }
 
-func (j *SSEJob) Process() {
+func (j *SSEJob) Process(ctx context.Context) {
 	jd := j.JobData()
 	zap.L().Info("Starting SSE execution of Job ID:" + jd.ID)
 	startTime := time.Now()
-	err := RunSSE(j)
+	err := RunSSE(ctx, j)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("Failed to run SSE %s", err.Error()))
+		var je *core.JobError
+		if !errors.As(err, &je) && !core.IsTimedOut(ctx) && ctx.Err() == nil {
+			// the errors of docker are not shown to the user
+			err = core.NewInternalError("failed to run the SSE container", err)
+		}
 		core.SetFailureWithError(j, err)
 		return
 	}
@@ -99,12 +121,12 @@ func (j *SSEJob) Process() {
 	return
 }
 
-func (j *SSEJob) PostProcess() {
+func (j *SSEJob) PostProcess(ctx context.Context) {
 	return
 }
 
 func (j *SSEJob) IsFinished() bool {
-	return j.JobData().Status == core.SUCCEEDED || j.JobData().Status == core.FAILED
+	return j.JobData().Status == core.SUCCEEDED || j.JobData().Status == core.FAILED || j.JobData().Status == core.CANCELLED
 }
 
 func (j *SSEJob) JobData() *core.JobData {
@@ -145,8 +167,8 @@ func makeUserProgramFile(jobID string, userprogramData string, inputDirPath stri
 	// base64 decode
 	decoded, err := base64.StdEncoding.DecodeString(userprogramData)
 	if err != nil {
-		err = fmt.Errorf("failed to decode user program. Reason:%s", err.Error())
-		zap.L().Error(err.Error())
+		zap.L().Error(fmt.Sprintf("failed to decode user program. Reason:%s", err.Error()))
+		err = core.NewUserInputError("failed to decode user program", err)
 		return
 	}
 
@@ -154,8 +176,8 @@ func makeUserProgramFile(jobID string, userprogramData string, inputDirPath stri
 	filePath := filepath.Join(inputDirPath, userProgramName)
 	err = os.WriteFile(filePath, decoded, 0600)
 	if err != nil {
-		err = fmt.Errorf("failed to write user program to file. Reason:%s", err.Error())
-		zap.L().Error(err.Error())
+		zap.L().Error(fmt.Sprintf("failed to write user program to file. Reason:%s", err.Error()))
+		err = core.NewInternalError("failed to prepare the user program", err)
 		return
 	}
 
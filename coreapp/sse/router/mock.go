@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
@@ -11,7 +12,7 @@ type successQPUForTest struct {
 	core.UnimplementedQPU
 }
 
-func (successQPUForTest) Send(j core.Job) error {
+func (successQPUForTest) Send(ctx context.Context, j core.Job) error {
 	j.JobData().Result.Counts = map[string]uint32{"00": 400, "11": 600}
 	j.JobData().Result.TranspilerInfo.PhysicalVirtualMapping = map[uint32]uint32{0: 1, 1: 0}
 	j.JobData().Result.Message = "dummysuccessresult"
@@ -23,7 +24,7 @@ type failQPUForTest struct {
 	core.UnimplementedQPU
 }
 
-func (failQPUForTest) Send(j core.Job) error {
+func (failQPUForTest) Send(ctx context.Context, j core.Job) error {
 	j.JobData().Result.Message = "dummyfailureresult"
 	j.JobData().Status = core.FAILED
 	return nil
@@ -33,7 +34,7 @@ type errorQPUForTest struct {
 	core.UnimplementedQPU
 }
 
-func (errorQPUForTest) Send(j core.Job) error {
+func (errorQPUForTest) Send(ctx context.Context, j core.Job) error {
 	return fmt.Errorf("QPU error")
 }
 
@@ -44,7 +45,7 @@ func (successTranspilerForTest) IsAcceptableTranspilerLib(string) bool {
 }
 func (successTranspilerForTest) Setup(*core.Conf) error { return nil }
 func (successTranspilerForTest) GetHealth() error       { return nil }
-func (successTranspilerForTest) Transpile(j core.Job) error {
+func (successTranspilerForTest) Transpile(ctx context.Context, j core.Job) error {
 	j.JobData().TranspiledQASM = "transpiled QASM"
 	return nil
 }
@@ -55,8 +56,10 @@ type failTranspilerForTest struct{}
 func (failTranspilerForTest) IsAcceptableTranspilerLib(string) bool { return true }
 func (failTranspilerForTest) Setup(*core.Conf) error                { return nil }
 func (failTranspilerForTest) GetHealth() error                      { return nil }
-func (failTranspilerForTest) Transpile(core.Job) error              { return fmt.Errorf("Transpile Error") }
-func (failTranspilerForTest) TearDown()                             {}
+func (failTranspilerForTest) Transpile(context.Context, core.Job) error {
+	return fmt.Errorf("Transpile Error")
+}
+func (failTranspilerForTest) TearDown() {}
 
 func getContainer(transpiler core.Transpiler, qpu core.QPUManager) *dig.Container {
 	s := core.NewSystemComponents(dig.New())
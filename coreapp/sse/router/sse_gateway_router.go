@@ -76,6 +76,12 @@ func (m *GRPCRouter) TranspileAndExec(ctx context.Context, userReq *sse.Transpil
 	err = m.container.Invoke(
 		func(q core.QPUManager) error {
 			deviceInfo := q.GetDeviceInfo()
+			if md, ok := q.(core.MultiDeviceQPUManager); ok {
+				var err error
+				if deviceInfo, err = md.GetDeviceInfoOf(jd.DeviceID); err != nil {
+					return err
+				}
+			}
 			return validateShots(jd.Shots, deviceInfo)
 		})
 	if err != nil {
@@ -88,6 +94,9 @@ func (m *GRPCRouter) TranspileAndExec(ctx context.Context, userReq *sse.Transpil
 	// Validate the QASM
 	err = m.container.Invoke(
 		func(q core.QPUManager) error {
+			if md, ok := q.(core.MultiDeviceQPUManager); ok {
+				return md.ValidateOf(jd.DeviceID, jd.QASM)
+			}
 			return q.Validate(jd.QASM)
 		})
 	if err != nil {
@@ -114,7 +123,9 @@ func (m *GRPCRouter) TranspileAndExec(ctx context.Context, userReq *sse.Transpil
 		// Transpile the quantum circuit
 		err = m.container.Invoke(
 			func(t core.Transpiler) error {
-				return t.Transpile(j)
+				return core.Retry(ctx, j.JobData(), "transpiler", func(ctx context.Context) error {
+					return t.Transpile(ctx, j)
+				})
 			})
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("Failed to transpile the quantum circuit. Reason:%s", err))
@@ -140,7 +151,9 @@ func (m *GRPCRouter) TranspileAndExec(ctx context.Context, userReq *sse.Transpil
 	// Call QPU
 	err = m.container.Invoke(
 		func(q core.QPUManager) error {
-			err = q.Send(j)
+			err = core.Retry(ctx, j.JobData(), "qpu", func(ctx context.Context) error {
+				return q.Send(ctx, j)
+			})
 			return err
 		})
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -97,7 +98,7 @@ type ContainerResult struct {
 	reserror error
 }
 
-func RunSSE(j core.Job) error {
+func RunSSE(ctx context.Context, j core.Job) error {
 	jd := j.JobData()
 	zap.L().Info(fmt.Sprintf("Starting SSE container and executing user program of Job ID:%s", jd.ID))
 	sseconf := conf.GetSSEConf()
@@ -144,7 +145,7 @@ func RunSSE(j core.Job) error {
 
 	// Initialize directories and permmissions and setup iptables in container
 	cmdString := fmt.Sprintf("sh -c \"/root/init.sh %d\"", sseconf.GatewayRouterListenPort)
-	err, _ = execCommandInContainer(&conDef, jd, sseconf, "root", true, cmdString)
+	err, _ = execCommandInContainer(ctx, &conDef, jd, sseconf, "root", true, cmdString)
 	if err != nil {
 		errAfterContainerMake(jd.ID, err, &conDef, sseconf.HostPath)
 		return err
@@ -161,7 +162,12 @@ func RunSSE(j core.Job) error {
 	// continue to get the log for userprogram even if an error occurs
 	zap.L().Info("Executing user program in container")
 	cmd := "uv run --project /app python " + sseconf.ContainerPathIn + "/" + sseconf.UserProgramName + " 1> /proc/1/fd/1 2> /proc/1/fd/2"
-	execErr, errMsgToReturn := execCommandInContainer(&conDef, jd, sseconf, "appuser", false, cmd)
+	execErr, errMsgToReturn := execCommandInContainer(ctx, &conDef, jd, sseconf, "appuser", false, cmd)
+	if ctx.Err() != nil {
+		// the job is cancelled or timed out. the result is not needed any more
+		errAfterContainerMake(jd.ID, execErr, &conDef, sseconf.HostPath)
+		return execErr
+	}
 
 	// Copy result from container
 	// continue to get the log for userprogram even if an error occurs
@@ -215,7 +221,8 @@ func RunSSE(j core.Job) error {
 	if execErr == nil && copyErr == nil {
 		return nil
 	} else {
-		return fmt.Errorf(errMsgToReturn)
+		// the user program has failed. the message is written for the user
+		return core.NewUserInputError(errMsgToReturn, errors.Join(execErr, copyErr))
 	}
 }
 
@@ -333,10 +340,11 @@ func copyUserProgramIntoContainer(conDef *ContainerDefinition, outputJob *core.J
 	return nil
 }
 
-func execCommandInContainer(conDef *ContainerDefinition, outputJob *core.JobData, sseconf *conf.SSEConf, user string, privileged bool, cmd string) (error, string) {
+func execCommandInContainer(ctx context.Context, conDef *ContainerDefinition, outputJob *core.JobData, sseconf *conf.SSEConf, user string, privileged bool, cmd string) (error, string) {
 	// Define error message
 	msg := "failed to exec command in container"
 	msgTimeout := "The SSE execution has timed out after " + strconv.Itoa(sseconf.SSETimeout) + " seconds."
+	msgCancelled := "The SSE execution has been cancelled."
 	msgExitCode := "exit code is not 0"
 
 	// Generate exec command
@@ -349,7 +357,6 @@ func execCommandInContainer(conDef *ContainerDefinition, outputJob *core.JobData
 		Detach:       false,
 		Tty:          false,
 	}
-	ctx := context.Background()
 
 	// Generate instance docker exec
 	respExecCreate, err := conDef.client.ContainerExecCreate(ctx, conDef.ID, execConfig)
@@ -388,6 +395,14 @@ func execCommandInContainer(conDef *ContainerDefinition, outputJob *core.JobData
 	case <-time.After(time.Duration(sseconf.SSETimeout) * time.Second):
 		err = makeErrMsg(msgTimeout, nil)
 		return err, msgTimeout
+	case <-ctx.Done():
+		if core.IsTimedOut(ctx) {
+			msgJobTimeout := context.Cause(ctx).Error()
+			err = makeErrMsg(msgJobTimeout, nil)
+			return err, msgJobTimeout
+		}
+		err = makeErrMsg(msgCancelled, ctx.Err())
+		return err, msgCancelled
 	case err := <-resultChan:
 		if err != nil {
 			err = makeErrMsg(msg, err)
@@ -658,7 +673,10 @@ func setResultToOutputJob(outputJob *core.JobData, outPath string, fileName stri
 		err = makeErrMsg(msg, err)
 		return err
 	}
-	outputJob.Status = status
+	if err := outputJob.SetStatus(status); err != nil {
+		err = makeErrMsg(msg, err)
+		return err
+	}
 
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -667,7 +668,7 @@ func Test_execCommandInContainer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err, errMsg := execCommandInContainer(tt.args.conDef, tt.args.outputJob, tt.args.sseconf, tt.args.user, false, tt.args.cmd)
+			err, errMsg := execCommandInContainer(context.Background(), tt.args.conDef, tt.args.outputJob, tt.args.sseconf, tt.args.user, false, tt.args.cmd)
 			tt.assertion(t, err)
 			assert.Equal(t, tt.wantErrMsg, errMsg)
 		})
@@ -33,7 +33,6 @@ type Tranqu struct {
 	address string
 	conn    *grpc.ClientConn
 	client  tranqu.TranspilerServiceClient
-	ctx     context.Context
 }
 
 func (t *Tranqu) IsAcceptableTranspilerLib(lib string) bool {
@@ -73,7 +72,6 @@ func (t *Tranqu) Setup(_ *core.Conf) error {
 		zap.L().Error(fmt.Sprintf("failed to make connection to %s/reason:%s", t.address, connErr))
 		return connErr
 	}
-	t.ctx = context.Background()
 	t.conn = conn
 	t.client = tranqu.NewTranspilerServiceClient(conn)
 	zap.L().Debug(fmt.Sprintf("GatewayAgent is ready to use %s", t.address))
@@ -84,7 +82,7 @@ func (t *Tranqu) GetHealth() error {
 	return nil
 }
 
-func (t *Tranqu) Transpile(j core.Job) error {
+func (t *Tranqu) Transpile(ctx context.Context, j core.Job) error {
 	req := &tranqu.TranspileRequest{}
 	req.Reset()
 	req.RequestId = j.JobData().ID
@@ -96,21 +94,28 @@ func (t *Tranqu) Transpile(j core.Job) error {
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to marshal transpiler options:%v/reason:%s",
 			j.JobData().Transpiler.TranspilerOptions, err))
-		return err
+		return core.NewUserInputError("transpiler options are invalid", err)
 	}
 	req.TranspilerOptions = string(b)
-	req.Device = core.GetSystemComponents().GetDeviceInfo().DeviceInfoSpecJson
+	di, err := core.GetSystemComponents().GetDeviceInfoOf(j.JobData().DeviceID)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to get the device info of %s/reason:%s", j.JobData().DeviceID, err))
+		return core.NewUserInputError("the device is not found", err)
+	}
+	req.Device = di.DeviceInfoSpecJson
 	req.DeviceLib = "oqtopus"
 
 	zap.L().Debug(
 		fmt.Sprintf(
 			"transpile request/RequestID:%s/Program:%s/TranspilerLib:%s/TranspilerOptions:%s/Device:%s/DeviceLib:%s",
 			req.RequestId, req.Program, req.TranspilerLib, req.TranspilerOptions, req.Device, req.DeviceLib))
-	res, err := t.client.Transpile(t.ctx, req)
+	// the request is aborted when the job is cancelled or overruns its deadline
+	res, err := t.client.Transpile(ctx, req)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to transpile RequestID:%s/reason:%s",
 			req.RequestId, err))
-		return err
+		// the gRPC error is wrapped to keep its code for the retry
+		return core.NewDependencyFailureError("failed to call the transpiler", err)
 	}
 	zap.L().Debug(fmt.Sprintf("transpiled response/requestID:%s/status:%d/virtualPhysicalMapping:%v/stats:%v",
 		req.RequestId, res.GetStatus(), res.GetVirtualPhysicalMapping(), res.GetStats()))
@@ -119,7 +124,8 @@ func (t *Tranqu) Transpile(j core.Job) error {
 		zap.L().Debug(fmt.Sprintf("transpiled program:%s", res.GetTranspiledProgram()))
 	case 1:
 		zap.L().Error(fmt.Sprintf("transpile failed/requestID:%s", req.RequestId))
-		return fmt.Errorf("transpile failed")
+		// the transpiler is working, but it cannot transpile the program
+		return core.NewUserInputError("transpile failed", nil)
 	default:
 		zap.L().Error(fmt.Sprintf("unknown status:%d/requestID:%s", res.GetStatus(), req.RequestId))
 	}
@@ -129,14 +135,14 @@ func (t *Tranqu) Transpile(j core.Job) error {
 	vpm, err := toVirtualPhysicalMappingFromString(vpmStr)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to get virtual physical mapping:%s/reason:%s", vpmStr, err))
-		return err
+		return core.NewDependencyFailureError("invalid response from the transpiler", err)
 	}
 	j.JobData().Result.TranspilerInfo.VirtualPhysicalMappingRaw = vpm
 
 	pvm, err := toPhysicalVirtualMappingFromString(vpmStr)
 	if err != nil {
 		zap.L().Error(fmt.Sprintf("failed to get physical virtual mapping:%s/reason:%s", vpmStr, err))
-		return err
+		return core.NewDependencyFailureError("invalid response from the transpiler", err)
 	}
 	zap.L().Debug(fmt.Sprintf("physical virtual mapping:%v", pvm))
 	j.JobData().Result.TranspilerInfo.PhysicalVirtualMapping = pvm
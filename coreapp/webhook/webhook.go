@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const WebhookServerName = "webhook"
+
+// WebhookServerImpl posts the lifecycle events of the jobs to the configured URLs.
+// The events are dropped when the receivers are too slow, so that the webhooks
+// never delay the jobs.
+type WebhookServerImpl struct {
+	URLs    []string `toml:"urls"`
+	Events  []string `toml:"events"` // all the events when empty
+	Timeout string   `toml:"timeout"`
+
+	client *http.Client
+	events map[core.EventType]bool
+	sub    *core.Subscription
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+type Payload struct {
+	Event     core.EventType `json:"event"`
+	Time      time.Time      `json:"time"`
+	JobID     string         `json:"job_id"`
+	JobType   string         `json:"job_type"`
+	Status    string         `json:"status"`
+	ErrorKind core.ErrorKind `json:"error_kind,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+func (w *WebhookServerImpl) Setup() error {
+	timeout := 5 * time.Second
+	if w.Timeout != "" {
+		d, err := time.ParseDuration(w.Timeout)
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to parse the timeout of webhook:%s/reason:%s", w.Timeout, err))
+			return err
+		}
+		timeout = d
+	}
+	w.client = &http.Client{Timeout: timeout}
+	w.events = make(map[core.EventType]bool)
+	for _, e := range w.Events {
+		w.events[core.EventType(e)] = true
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	w.sub = core.GetSystemComponents().EventBus.Subscribe(
+		WebhookServerName, core.DEFAULT_EVENT_BUFFER_SIZE, core.DropOldest)
+	return nil
+}
+
+func (w *WebhookServerImpl) GetEmptyParams() interface{} {
+	return w
+}
+
+func (w *WebhookServerImpl) SetParams(p interface{}) error {
+	if p == nil {
+		zap.L().Debug("no params for webhook server")
+		return nil
+	}
+	mp, ok := p.(map[string]interface{})
+	if !ok {
+		msg := fmt.Errorf("failed to set params for webhook server/params: %s", p)
+		zap.L().Error(msg.Error())
+		return msg
+	}
+	if urls, ok := mp["urls"].([]interface{}); ok {
+		w.URLs = toStrings(urls)
+	}
+	if events, ok := mp["events"].([]interface{}); ok {
+		w.Events = toStrings(events)
+	}
+	if timeout, ok := mp["timeout"].(string); ok {
+		w.Timeout = timeout
+	}
+	return nil
+}
+
+func (w *WebhookServerImpl) Start() error {
+	go func() {
+		defer close(w.done)
+		for {
+			select {
+			case e, ok := <-w.sub.Events():
+				if !ok {
+					return
+				}
+				if len(w.events) != 0 && !w.events[e.Type] {
+					continue
+				}
+				w.post(e)
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *WebhookServerImpl) Cleanup() {
+	close(w.stop)
+	<-w.done
+}
+
+// Handle is not used, because the webhooks receive the jobs as events.
+func (w *WebhookServerImpl) Handle(core.Job) error {
+	return nil
+}
+
+func (w *WebhookServerImpl) post(e core.JobEvent) {
+	b, err := json.Marshal(NewPayload(e))
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("failed to marshal the webhook payload of job(%s)/reason:%s",
+			e.Job.JobData().ID, err))
+		return
+	}
+	for _, url := range w.URLs {
+		if err := w.postTo(url, b); err != nil {
+			zap.L().Warn(fmt.Sprintf("failed to post %s event of job(%s) to %s/reason:%s",
+				e.Type, e.Job.JobData().ID, url, err))
+		}
+	}
+}
+
+func (w *WebhookServerImpl) postTo(url string, body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status:%s", res.Status)
+	}
+	return nil
+}
+
+func NewPayload(e core.JobEvent) *Payload {
+	jd := e.Job.JobData()
+	p := &Payload{
+		Event:   e.Type,
+		Time:    e.Time,
+		JobID:   jd.ID,
+		JobType: jd.JobType,
+		Status:  jd.Status.String(),
+	}
+	if jd.Status == core.FAILED {
+		p.ErrorKind = jd.Result.ErrorKind
+		p.Message = core.FailureMessage(jd)
+	}
+	return p
+}
+
+func toStrings(vs []interface{}) []string {
+	ss := []string{}
+	for _, v := range vs {
+		if s, ok := v.(string); ok {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
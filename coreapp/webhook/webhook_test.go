@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJobForTest(t *testing.T, id string, st core.Status) core.Job {
+	jd := core.NewJobData()
+	jd.ID = id
+	jd.JobType = "sampling"
+	jd.Status = st
+	jc, err := core.NewJobContext()
+	assert.Nil(t, err)
+	return (&core.NormalJob{}).New(jd, jc)
+}
+
+func TestWebhookPost(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	received := make(chan Payload, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		p := Payload{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+		received <- p
+	}))
+	defer srv.Close()
+
+	w := &WebhookServerImpl{}
+	assert.Nil(t, w.SetParams(map[string]interface{}{
+		"urls":    []interface{}{srv.URL},
+		"events":  []interface{}{"succeeded", "failed"},
+		"timeout": "1s",
+	}))
+	assert.Nil(t, w.Setup())
+	assert.Nil(t, w.Start())
+	defer w.Cleanup()
+
+	// the events not configured are not posted
+	s.EventBus.Publish(core.JobQueued, newJobForTest(t, "job1", core.READY))
+	s.EventBus.Publish(core.JobSucceeded, newJobForTest(t, "job1", core.SUCCEEDED))
+	failed := newJobForTest(t, "job2", core.FAILED)
+	failed.JobData().Result.ErrorKind = core.UserInputError
+	failed.JobData().Result.Message = "invalid program"
+	s.EventBus.Publish(core.JobFailed, failed)
+
+	p := receivePayload(t, received)
+	assert.Equal(t, core.JobSucceeded, p.Event)
+	assert.Equal(t, "job1", p.JobID)
+	assert.Equal(t, "sampling", p.JobType)
+	assert.Equal(t, core.SUCCEEDED.String(), p.Status)
+	assert.Empty(t, p.ErrorKind)
+	assert.Empty(t, p.Message)
+	assert.False(t, p.Time.IsZero())
+
+	p = receivePayload(t, received)
+	assert.Equal(t, core.JobFailed, p.Event)
+	assert.Equal(t, "job2", p.JobID)
+	assert.Equal(t, core.FAILED.String(), p.Status)
+	assert.Equal(t, core.UserInputError, p.ErrorKind)
+	assert.Equal(t, "invalid program", p.Message)
+
+	select {
+	case p := <-received:
+		t.Fatalf("unexpected %s event of job(%s)", p.Event, p.JobID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWebhookSlowReceiver(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	w := &WebhookServerImpl{URLs: []string{srv.URL}, Timeout: "5s"}
+	assert.Nil(t, w.Setup())
+	assert.Nil(t, w.Start())
+
+	// the publishers are never blocked by the receiver, and the oldest events are dropped
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < core.DEFAULT_EVENT_BUFFER_SIZE+10; i++ {
+			s.EventBus.Publish(core.JobQueued, newJobForTest(t, "job1", core.READY))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("the publishers are blocked by the webhook")
+	}
+	assert.Eventually(t, func() bool { return w.sub.Dropped() > 0 }, time.Second, 10*time.Millisecond)
+
+	close(release)
+	w.Cleanup()
+}
+
+func receivePayload(t *testing.T, received <-chan Payload) Payload {
+	select {
+	case p := <-received:
+		return p
+	case <-time.After(3 * time.Second):
+		t.Fatal("no webhook received")
+		return Payload{}
+	}
+}
@@ -0,0 +1,322 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"go.uber.org/zap"
+)
+
+const WORKFLOW_JOB = "workflow"
+
+// Step is a job of an existing job type run in a workflow.
+type Step struct {
+	Name           string                 `json:"name"`
+	JobType        string                 `json:"job_type"`
+	Program        string                 `json:"program"`
+	Shots          int                    `json:"shots"`                     // the shots of the workflow when 0
+	Transpiler     *core.TranspilerConfig `json:"transpiler"`                // the transpiler of the workflow when null
+	Operator       json.RawMessage        `json:"operator,omitempty"`        // for estimation
+	MitigationInfo json.RawMessage        `json:"mitigation_info,omitempty"` // for sampling and estimation
+	DependsOn      []string               `json:"depends_on"`
+	// ProgramFrom is the step whose transpiled program is used as the program of this step.
+	// e.g. an estimation on the same qubit mapping as the preceding sampling
+	ProgramFrom string `json:"program_from"`
+}
+
+// Definition is the DAG of the steps given as the program of a workflow job.
+type Definition struct {
+	Steps []Step `json:"steps"`
+}
+
+// WorkflowJob runs its steps as child jobs in the order of their dependencies.
+// The child jobs are made by the JobManager and handled in the scheduler like the other jobs,
+// so that the workflow itself never occupies the QPU.
+type WorkflowJob struct {
+	jobData    *core.JobData
+	jobContext *core.JobContext
+	definition *Definition
+	order      []*Step
+}
+
+func (j *WorkflowJob) New(jd *core.JobData, jc *core.JobContext) core.Job {
+	return &WorkflowJob{
+		jobData:    jd,
+		jobContext: jc,
+	}
+}
+
+func (j *WorkflowJob) Validate() error {
+	d, err := ParseDefinition(j.JobData().QASM)
+	if err != nil {
+		return err
+	}
+	_, err = d.sort()
+	return err
+}
+
+func (j *WorkflowJob) PreProcess(ctx context.Context) {
+	jd := j.JobData()
+	d, err := ParseDefinition(jd.QASM)
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("invalid workflow of job(%s)/reason:%s", jd.ID, err))
+		core.SetFailureWithError(j, err)
+		return
+	}
+	order, err := d.sort()
+	if err != nil {
+		zap.L().Info(fmt.Sprintf("invalid workflow of job(%s)/reason:%s", jd.ID, err))
+		core.SetFailureWithError(j, err)
+		return
+	}
+	j.definition = d
+	j.order = order
+}
+
+// Process is never called by the scheduler, which runs the steps through RunChildren instead.
+func (j *WorkflowJob) Process(ctx context.Context) {
+	core.SetFailureWithError(j, core.NewInternalError("the workflow failed unexpectedly",
+		fmt.Errorf("job(%s) is processed without its steps", j.JobData().ID)))
+}
+
+// RunChildren handles the steps in the scheduler one by one in the order of their dependencies.
+// The workflow fails at the first step which does not succeed.
+func (j *WorkflowJob) RunChildren(ctx context.Context, handle func(core.Job) error) {
+	jd := j.JobData()
+	children := map[string]core.Job{}
+	stepResults := map[string]*core.Result{}
+	summary := []string{}
+	var executionTime time.Duration
+	// the results of the steps run so far are kept whichever way the workflow ends
+	defer func() { jd.Result.StepResults = stepResults }()
+	for _, s := range j.order {
+		if ctx.Err() != nil {
+			// the scheduler reports the cancellation or the timeout
+			zap.L().Info(fmt.Sprintf("stop the workflow of job(%s) before step %s", jd.ID, s.Name))
+			return
+		}
+		child, err := j.newChild(s, children)
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to make step %s of job(%s)/reason:%s", s.Name, jd.ID, err))
+			core.SetFailureWithError(j, fmt.Errorf("step %s: %w", s.Name, err))
+			return
+		}
+		zap.L().Debug(fmt.Sprintf("running step %s of job(%s) as %s job", s.Name, jd.ID, s.JobType))
+		// the timeout of the workflow stops the step too
+		stop := context.AfterFunc(ctx, child.JobContext().Cancel)
+		err = handle(child)
+		stop()
+		child.JobContext().Cancel()
+		child.JobContext().Close()
+		cjd := child.JobData()
+		stepResults[s.Name] = cjd.Result
+		if err != nil {
+			zap.L().Error(fmt.Sprintf("failed to handle step %s of job(%s)/reason:%s", s.Name, jd.ID, err))
+			core.SetFailureWithError(j, fmt.Errorf("step %s: %w", s.Name, err))
+			return
+		}
+		children[s.Name] = child
+		executionTime += cjd.Result.ExecutionTime
+		summary = append(summary, fmt.Sprintf("step %s: %s", s.Name, cjd.Status))
+		if cjd.Status != core.SUCCEEDED {
+			if ctx.Err() != nil {
+				return
+			}
+			core.SetFailureWithError(j, fmt.Errorf("step %s is %s: %s", s.Name, cjd.Status, cjd.Result.Message))
+			return
+		}
+		// the result of the workflow is that of the last step
+		if len(cjd.Result.Counts) != 0 {
+			jd.Result.Counts = cjd.Result.Counts
+		}
+		if cjd.Result.Estimation != nil {
+			jd.Result.Estimation = cjd.Result.Estimation
+		}
+	}
+	jd.Result.ExecutionTime = executionTime
+	jd.Result.Message = strings.Join(summary, "; ")
+	if err := jd.SetStatus(core.SUCCEEDED); err != nil {
+		core.SetFailureWithError(j, core.NewInternalError("the workflow failed unexpectedly", err))
+	}
+}
+
+func (j *WorkflowJob) PostProcess(ctx context.Context) {
+	return
+}
+
+func (j *WorkflowJob) IsFinished() bool {
+	return j.JobData().Status == core.SUCCEEDED || j.JobData().Status == core.FAILED || j.JobData().Status == core.CANCELLED
+}
+
+func (j *WorkflowJob) JobData() *core.JobData {
+	return j.jobData
+}
+
+func (j *WorkflowJob) JobType() string {
+	return WORKFLOW_JOB
+}
+
+func (j *WorkflowJob) JobContext() *core.JobContext {
+	return j.jobContext
+}
+
+func (j *WorkflowJob) Clone() core.Job {
+	return &WorkflowJob{
+		jobData:    j.jobData.Clone(),
+		jobContext: j.jobContext,
+		definition: j.definition,
+		order:      j.order,
+	}
+}
+
+func (j *WorkflowJob) newChild(s *Step, finished map[string]core.Job) (core.Job, error) {
+	jd := j.JobData()
+	cjd := core.NewJobData()
+	cjd.ID = fmt.Sprintf("%s/%s", jd.ID, s.Name)
+	// the steps are routed and scheduled as the workflow
+	cjd.DeviceID = jd.DeviceID
+	cjd.Owner = jd.Owner
+	cjd.Priority = jd.Priority
+	if err := cjd.SetStatus(core.READY); err != nil {
+		return nil, err
+	}
+	cjd.JobType = s.JobType
+	cjd.QASM = s.Program
+	cjd.Shots = s.Shots
+	if cjd.Shots == 0 {
+		cjd.Shots = jd.Shots
+	}
+	cjd.Transpiler = s.Transpiler
+	if cjd.Transpiler == nil {
+		cjd.Transpiler = jd.Transpiler
+	}
+	if s.ProgramFrom != "" {
+		from := finished[s.ProgramFrom].JobData()
+		if from.TranspiledQASM != "" {
+			cjd.QASM = from.TranspiledQASM
+		} else {
+			cjd.QASM = from.QASM
+		}
+		// the program is already on the physical qubits, which are mapped from the virtual qubits
+		// as in the step, e.g. for the operators of an estimation
+		cjd.Transpiler = &core.TranspilerConfig{TranspilerLib: nil}
+		cjd.Result.TranspilerInfo = core.CloneTranspilerInfo(from.Result.TranspilerInfo)
+	}
+	if cjd.Transpiler == nil {
+		cjd.Transpiler = &core.TranspilerConfig{TranspilerLib: nil}
+	}
+	if len(s.Operator) != 0 {
+		cjd.Info = string(s.Operator)
+	}
+	if len(s.MitigationInfo) != 0 {
+		cjd.MitigationInfo = string(s.MitigationInfo)
+	} else {
+		cjd.MitigationInfo = jd.MitigationInfo
+	}
+	jm := core.GetJobManager()
+	if jm == nil {
+		return nil, fmt.Errorf("job manager is not initialized")
+	}
+	child, err := jm.NewJobFromJobData(cjd, core.NewChildJobContext(j.JobContext()))
+	if err != nil {
+		return nil, err
+	}
+	if err := child.Validate(); err != nil {
+		child.JobContext().Cancel()
+		child.JobContext().Close()
+		return nil, err
+	}
+	return child, nil
+}
+
+func ParseDefinition(program string) (*Definition, error) {
+	d := &Definition{}
+	if err := json.Unmarshal([]byte(program), d); err != nil {
+		return nil, fmt.Errorf("failed to parse the workflow: %w", err)
+	}
+	if len(d.Steps) == 0 {
+		return nil, fmt.Errorf("the workflow has no step")
+	}
+	acceptable := map[string]bool{}
+	if jm := core.GetJobManager(); jm != nil {
+		for _, t := range jm.AcceptableJobTypes() {
+			acceptable[t] = true
+		}
+	}
+	names := map[string]bool{}
+	for _, s := range d.Steps {
+		if s.Name == "" {
+			return nil, fmt.Errorf("the name of a step is empty")
+		}
+		if strings.Contains(s.Name, "/") {
+			return nil, fmt.Errorf("the name of step %s contains '/'", s.Name)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("step %s is duplicated", s.Name)
+		}
+		names[s.Name] = true
+		if s.JobType == WORKFLOW_JOB || !acceptable[s.JobType] {
+			return nil, fmt.Errorf("job type %s of step %s is not acceptable", s.JobType, s.Name)
+		}
+	}
+	for _, s := range d.Steps {
+		for _, dep := range s.DependsOn {
+			if !names[dep] {
+				return nil, fmt.Errorf("step %s depends on unknown step %s", s.Name, dep)
+			}
+		}
+		if s.ProgramFrom != "" && !contains(s.DependsOn, s.ProgramFrom) {
+			return nil, fmt.Errorf("step %s must depend on step %s to use its program", s.Name, s.ProgramFrom)
+		}
+		if s.ProgramFrom == "" && s.Program == "" {
+			return nil, fmt.Errorf("the program of step %s is empty", s.Name)
+		}
+	}
+	return d, nil
+}
+
+// sort returns the steps in a topological order. The order in the definition is kept among
+// the steps which do not depend on each other.
+func (d *Definition) sort() ([]*Step, error) {
+	done := map[string]bool{}
+	order := []*Step{}
+	for len(order) < len(d.Steps) {
+		progressed := false
+		for i := range d.Steps {
+			s := &d.Steps[i]
+			if done[s.Name] || !allDone(s.DependsOn, done) {
+				continue
+			}
+			done[s.Name] = true
+			order = append(order, s)
+			progressed = true
+			break
+		}
+		if !progressed {
+			return nil, fmt.Errorf("the workflow has a cyclic dependency")
+		}
+	}
+	return order, nil
+}
+
+func allDone(names []string, done map[string]bool) bool {
+	for _, n := range names {
+		if !done[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
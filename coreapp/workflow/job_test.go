@@ -0,0 +1,245 @@
+//go:build unit
+// +build unit
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/core"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/estimation"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/sampling"
+	"github.com/oqtopus-team/oqtopus-engine/coreapp/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDefinition(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{}, &WorkflowJob{})
+
+	tests := []struct {
+		name    string
+		program string
+		order   []string
+		wantErr bool
+	}{
+		{
+			name: "dependency order",
+			program: `{"steps":[
+				{"name":"b","job_type":"normal","program":"q","depends_on":["a"]},
+				{"name":"a","job_type":"normal","program":"q"},
+				{"name":"c","job_type":"normal","depends_on":["a"],"program_from":"a"}]}`,
+			order: []string{"a", "b", "c"},
+		},
+		{
+			name: "cyclic",
+			program: `{"steps":[
+				{"name":"a","job_type":"normal","program":"q","depends_on":["b"]},
+				{"name":"b","job_type":"normal","program":"q","depends_on":["a"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown dependency",
+			program: `{"steps":[{"name":"a","job_type":"normal","program":"q","depends_on":["x"]}]}`,
+			wantErr: true,
+		},
+		{
+			name: "duplicated step",
+			program: `{"steps":[
+				{"name":"a","job_type":"normal","program":"q"},
+				{"name":"a","job_type":"normal","program":"q"}]}`,
+			wantErr: true,
+		},
+		{
+			name: "program_from without dependency",
+			program: `{"steps":[
+				{"name":"a","job_type":"normal","program":"q"},
+				{"name":"b","job_type":"normal","program_from":"a"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested workflow",
+			program: `{"steps":[{"name":"a","job_type":"workflow","program":"q"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDefinition(tt.program)
+			if err == nil {
+				var order []*Step
+				order, err = d.sort()
+				if err == nil {
+					names := []string{}
+					for _, s := range order {
+						names = append(names, s.Name)
+					}
+					assert.Equal(t, tt.order, names)
+				}
+			}
+			if tt.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkflowJob(t *testing.T) {
+	nsc := &scheduler.NormalScheduler{}
+	s := core.SCWithScheduler(nsc)
+	defer s.TearDown()
+	assert.Nil(t, s.StartContainer())
+	jm, err := core.NewJobManager(&core.NormalJob{}, &WorkflowJob{})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		program     string
+		wantStatus  core.Status
+		wantResults []string
+	}{
+		{
+			name: "steps in the scheduler",
+			program: `{"steps":[
+				{"name":"first","job_type":"normal","program":"OPENQASM 3;"},
+				{"name":"second","job_type":"normal","depends_on":["first"],"program_from":"first"}]}`,
+			wantStatus:  core.SUCCEEDED,
+			wantResults: []string{"first", "second"},
+		},
+		{
+			name: "invalid step",
+			program: `{"steps":[
+				{"name":"first","job_type":"normal","program":"OPENQASM 3;"},
+				{"name":"second","job_type":"normal","shots":-1,"program":"OPENQASM 3;","depends_on":["first"]}]}`,
+			wantStatus:  core.FAILED,
+			wantResults: []string{"first"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := core.NewJobData()
+			jd.ID = uuid.NewString()
+			jd.Status = core.READY
+			jd.JobType = WORKFLOW_JOB
+			jd.Shots = 100
+			jd.Transpiler = &core.TranspilerConfig{TranspilerLib: nil}
+			jd.QASM = tt.program
+			jc, err := core.NewJobContext()
+			assert.Nil(t, err)
+			j, err := jm.NewJobFromJobData(jd, jc)
+			assert.Nil(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			assert.Nil(t, nsc.HandleJobForTest(j, &wg))
+			wg.Wait()
+			assert.Equal(t, tt.wantStatus, j.JobData().Status)
+			assert.Len(t, j.JobData().Result.StepResults, len(tt.wantResults))
+			for _, name := range tt.wantResults {
+				assert.Contains(t, j.JobData().Result.StepResults, name)
+			}
+			// the slots of the steps are released
+			assert.Equal(t, 0, nsc.GetCurrentQueueSize())
+			assert.False(t, jm.IsActive(jd.ID+"/first"))
+		})
+	}
+}
+
+func TestRunChildren(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&core.NormalJob{}, &WorkflowJob{})
+
+	jd := core.NewJobData()
+	jd.ID = "parent"
+	jd.Status = core.READY
+	jd.JobType = WORKFLOW_JOB
+	jd.Shots = 100
+	jd.DeviceID = "device1"
+	jd.Owner = "owner1"
+	jd.Priority = "high"
+	jd.Transpiler = &core.TranspilerConfig{TranspilerLib: nil}
+	jd.QASM = `{"steps":[
+		{"name":"first","job_type":"normal","program":"OPENQASM 3;"},
+		{"name":"second","job_type":"normal","program":"OPENQASM 3;","depends_on":["first"]}]}`
+	jc, err := core.NewJobContext()
+	assert.Nil(t, err)
+	j := (&WorkflowJob{}).New(jd, jc).(*WorkflowJob)
+	j.PreProcess(context.Background())
+	assert.Equal(t, core.READY, jd.Status)
+
+	handled := []string{}
+	j.RunChildren(context.Background(), func(child core.Job) error {
+		cjd := child.JobData()
+		handled = append(handled, cjd.ID)
+		// the steps are routed and scheduled as the workflow
+		assert.Equal(t, "device1", cjd.DeviceID)
+		assert.Equal(t, "owner1", cjd.Owner)
+		assert.Equal(t, "high", cjd.Priority)
+		if cjd.ID == "parent/second" {
+			cjd.Result.Message = "the QPU is unavailable"
+			return fmt.Errorf("failed to handle")
+		}
+		cjd.Status = core.SUCCEEDED
+		return nil
+	})
+	assert.Equal(t, []string{"parent/first", "parent/second"}, handled)
+	assert.Equal(t, core.FAILED, jd.Status)
+	// the result of the step failed in the handling is kept too
+	assert.Len(t, jd.Result.StepResults, 2)
+	assert.Equal(t, "the QPU is unavailable", jd.Result.StepResults["second"].Message)
+}
+
+func TestRunChildrenWithProgramFrom(t *testing.T) {
+	s := core.SCWithUnimplementedContainer()
+	defer s.TearDown()
+	core.NewJobManager(&sampling.SamplingJob{}, &estimation.EstimationJob{}, &WorkflowJob{})
+
+	jd := core.NewJobData()
+	jd.ID = "parent"
+	jd.Status = core.READY
+	jd.JobType = WORKFLOW_JOB
+	jd.Shots = 100
+	lib := "qiskit"
+	jd.Transpiler = &core.TranspilerConfig{TranspilerLib: &lib}
+	jd.QASM = `{"steps":[
+		{"name":"sample","job_type":"sampling","program":"OPENQASM 3.0;\nqubit[2] q;\nh q[0];\n"},
+		{"name":"estimate","job_type":"estimation_job","depends_on":["sample"],"program_from":"sample",
+		 "operator":[{"pauli":"Z0 Z1","coeff":1.0}]}]}`
+	jc, err := core.NewJobContext()
+	assert.Nil(t, err)
+	j := (&WorkflowJob{}).New(jd, jc).(*WorkflowJob)
+	j.PreProcess(context.Background())
+	assert.Equal(t, core.READY, jd.Status)
+
+	assert.Nil(t, jd.SetStatus(core.RUNNING))
+
+	transpiled := "OPENQASM 3.0;\nqubit[4] q;\nh q[3];\n"
+	mapping := core.VirtualPhysicalMappingRaw(`{"0":3,"1":2}`)
+	j.RunChildren(context.Background(), func(child core.Job) error {
+		cjd := child.JobData()
+		switch cjd.ID {
+		case "parent/sample":
+			assert.True(t, cjd.NeedTranspiling())
+			// transpiled onto the physical qubits
+			cjd.TranspiledQASM = transpiled
+			cjd.Result.TranspilerInfo.VirtualPhysicalMappingRaw = mapping
+		case "parent/estimate":
+			// the estimation is on the physical qubits of the sampling without transpiling
+			assert.False(t, cjd.NeedTranspiling())
+			assert.Equal(t, transpiled, cjd.QASM)
+			assert.Equal(t, mapping, cjd.Result.TranspilerInfo.VirtualPhysicalMappingRaw)
+		}
+		cjd.Status = core.SUCCEEDED
+		return nil
+	})
+	assert.Equal(t, core.SUCCEEDED, jd.Status, jd.Result.Message)
+	assert.Len(t, jd.Result.StepResults, 2)
+}